@@ -0,0 +1,198 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/generation"
+	"github.com/vanviethieuanh/unitd/install"
+	"github.com/vanviethieuanh/unitd/manager"
+	"github.com/vanviethieuanh/unitd/output"
+	"github.com/vanviethieuanh/unitd/transport"
+)
+
+// ApplyCommand deploys the units of each host block to its machine.
+type ApplyCommand struct {
+	Meta
+}
+
+func (c *ApplyCommand) Synopsis() string {
+	return "Deploy the units of each host over SSH"
+}
+
+func (c *ApplyCommand) Run(args []string) int {
+	var root, hostName string
+	var out outputFlags
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&hostName, "host", "", "deploy the host block `name` only")
+	fs.StringVar(&root, "root", "", "deploy each host to <dir>/<host> instead of over SSH")
+	out.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd apply [-host=name] [-root=dir] [-mode=0644] [-owner=user] [-group=group] <src.hcl>\n\n")
+		fmt.Fprintf(c.Stderr, "Writes the units of each host block to /%s on the host, reached\n", install.UnitDir)
+		fmt.Fprintf(c.Stderr, "as its ssh block says. The tree is staged on the host and swapped in at\n")
+		fmt.Fprintf(c.Stderr, "once; files an earlier apply wrote that are no longer rendered are removed.\n")
+		fmt.Fprintf(c.Stderr, "systemd is then reloaded, new units started, changed ones restarted as\n")
+		fmt.Fprintf(c.Stderr, "their restart_policy says and removed ones stopped. With -root, only the\n")
+		fmt.Fprintf(c.Stderr, "files are written. Each deployment is recorded on the host as a generation\n")
+		fmt.Fprintf(c.Stderr, "in %s, which keeps the last %d; see unitd\n", generation.Dir, generation.Keep)
+		fmt.Fprintf(c.Stderr, "generations and unitd rollback.\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return 1
+	}
+	opts, err := out.options()
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+
+	config, ok := c.loadConfig(rest[0])
+	if !ok {
+		return 1
+	}
+	files, err := config.Render()
+	if err != nil {
+		c.errorf("Failed to render units: %s", err)
+		return 1
+	}
+
+	hosts := config.Hosts
+	if hostName != "" {
+		host, ok := lookupHost(config, hostName)
+		if !ok {
+			c.errorf("No host block named %q.", hostName)
+			return 1
+		}
+		hosts = []configs.Host{host}
+	}
+	if len(hosts) == 0 {
+		c.errorf("%s has no host blocks to apply.", rest[0])
+		return 1
+	}
+
+	src, err := os.ReadFile(rest[0])
+	if err != nil {
+		c.errorf("Failed to load configuration: %s", err)
+		return 1
+	}
+	policies := config.RestartPolicies()
+	status := 0
+	for _, host := range hosts {
+		t, m, err := hostTransport(host, root)
+		if err != nil {
+			c.errorf("%s: %s", host.Name, err)
+			status = 1
+			continue
+		}
+
+		tree, err := install.Host(files, host)
+		if err != nil {
+			c.errorf("%s: %s", host.Name, err)
+			status = 1
+		} else if !c.deploy(t, m, host, tree, policies, opts, generation.Generation{Source: output.Hash(src)}) {
+			status = 1
+		}
+		if err := t.Close(); err != nil {
+			c.errorf("%s: %s", host.Name, err)
+			status = 1
+		}
+	}
+	return status
+}
+
+// hostTransport returns the transport to host and its service manager:
+// the ssh block of the host, or the directory <root>/<host> without
+// service manager when root is set.
+func hostTransport(host configs.Host, root string) (transport.Transport, manager.Manager, error) {
+	if root != "" {
+		return &transport.Local{Root: filepath.Join(root, host.Name)}, nil, nil
+	}
+	if host.SSH == nil {
+		return nil, nil, fmt.Errorf("no ssh block; use -root to deploy the host to a directory")
+	}
+	ssh, err := transport.NewSSH(host.SSH)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ssh, &manager.Systemctl{Transport: ssh}, nil
+}
+
+// deploy brings the unit directory of host in line with tree, records the
+// deployment as generation gen, then tells the service manager, if m is
+// not nil.
+func (m *Meta) deploy(t transport.Transport, mgr manager.Manager, host configs.Host, tree []configs.File, policies map[string]string, opts output.Options, gen generation.Generation) bool {
+	dir := "/" + install.UnitDir
+	current, err := t.Snapshot(dir)
+	if err != nil {
+		m.errorf("%s: failed to read %s: %s", host.Name, dir, err)
+		return false
+	}
+	plan, err := output.NewPlanFS(dir, current, tree)
+	if err != nil {
+		m.errorf("%s: %s", host.Name, err)
+		return false
+	}
+	for _, name := range plan.Kept {
+		fmt.Fprintf(m.Stderr, "Warning: %s:%s is no longer rendered but was edited since unitd wrote it; leaving it in place.\n",
+			host.Name, path.Join(dir, name))
+	}
+	for _, name := range plan.Foreign {
+		fmt.Fprintf(m.Stderr, "Warning: %s:%s already has the rendered content but was not written by unitd; leaving it unmanaged.\n",
+			host.Name, path.Join(dir, name))
+	}
+
+	err = t.Apply(plan, opts, func(ch output.Change) {
+		verb := "wrote"
+		if ch.Action == output.Delete {
+			verb = "deleted"
+		}
+		fmt.Fprintf(m.Stdout, "%s %s:%s\n", verb, host.Name, path.Join(dir, ch.Name))
+	})
+	if err != nil {
+		m.errorf("%s: failed to update %s: %s", host.Name, dir, err)
+		return false
+	}
+	if plan.Empty() {
+		fmt.Fprintf(m.Stdout, "%s is up to date.\n", host.Name)
+		return true
+	}
+
+	gen.Time = time.Now().UTC().Truncate(time.Second)
+	gen.Files = tree
+	// The tree is in place: failing to record it must not keep systemd
+	// from picking it up.
+	ok := true
+	n, err := generation.Record(t, gen, plan)
+	if n != 0 {
+		fmt.Fprintf(m.Stdout, "%s: recorded generation %d\n", host.Name, n)
+	}
+	if err != nil {
+		m.errorf("%s: failed to record the generation: %s", host.Name, err)
+		ok = false
+	}
+
+	if mgr == nil {
+		return ok
+	}
+	err = manager.Run(mgr, manager.Actions(plan, tree, policies), func(a manager.Action) {
+		fmt.Fprintf(m.Stdout, "%s: %s\n", host.Name, a)
+	})
+	if err != nil {
+		m.errorf("%s: %s", host.Name, err)
+		return false
+	}
+	return ok
+}
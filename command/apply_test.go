@@ -0,0 +1,55 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/generation"
+	"github.com/vanviethieuanh/unitd/output"
+	"github.com/vanviethieuanh/unitd/transport"
+)
+
+// recorder is a manager.Manager that records the calls made to it.
+type recorder struct{ calls []string }
+
+func (r *recorder) call(verb, unit string) error {
+	r.calls = append(r.calls, strings.TrimSpace(verb+" "+unit))
+	return nil
+}
+
+func (r *recorder) DaemonReload() error       { return r.call("daemon-reload", "") }
+func (r *recorder) Enable(unit string) error  { return r.call("enable", unit) }
+func (r *recorder) Start(unit string) error   { return r.call("start", unit) }
+func (r *recorder) Stop(unit string) error    { return r.call("stop", unit) }
+func (r *recorder) Restart(unit string) error { return r.call("restart", unit) }
+func (r *recorder) Reload(unit string) error  { return r.call("reload", unit) }
+
+func TestDeployRunsManagerWhenRecordFails(t *testing.T) {
+	root := t.TempDir()
+	// A file where the generations directory should be makes Record fail.
+	gens := filepath.Join(root, filepath.FromSlash(generation.Dir))
+	if err := os.MkdirAll(filepath.Dir(gens), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(gens, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	m := &Meta{Stdout: &stdout, Stderr: &stderr}
+	mgr := &recorder{}
+	tree := []configs.File{{Name: "web.service", Content: "[Service]\nExecStart=/usr/bin/web\n", Source: "service.web"}}
+	if m.deploy(&transport.Local{Root: root}, mgr, configs.Host{Name: "h1"}, tree, nil, output.DefaultOptions, generation.Generation{}) {
+		t.Error("deploy succeeded although the generation was not recorded")
+	}
+	if !strings.Contains(stderr.String(), "failed to record the generation") {
+		t.Errorf("stderr: %s", stderr.String())
+	}
+	if len(mgr.calls) == 0 || mgr.calls[0] != "daemon-reload" {
+		t.Errorf("manager calls %q, want a daemon-reload first", mgr.calls)
+	}
+}
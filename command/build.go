@@ -0,0 +1,218 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/install"
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+// BuildCommand renders a configuration into unit files.
+type BuildCommand struct {
+	Meta
+
+	opts output.Options
+}
+
+func (c *BuildCommand) Synopsis() string {
+	return "Render a configuration into systemd unit files"
+}
+
+func (c *BuildCommand) Run(args []string) int {
+	var watch bool
+	var out outputFlags
+	fs := flag.NewFlagSet("build", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.BoolVar(&watch, "watch", false, "rebuild whenever the source changes")
+	out.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd build [-watch] [-mode=0644] [-owner=user] [-group=group] <src.hcl> <outdir>\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return 1
+	}
+	srcFile, outDir := rest[0], rest[1]
+
+	c.opts, err = out.options()
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+
+	if !watch {
+		if !c.build(srcFile, outDir) {
+			return 1
+		}
+		return 0
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	return c.watch(srcFile, outDir, interrupt)
+}
+
+// build renders srcFile into outDir. Only files whose content changed are
+// written, so that tools watching outDir see the real changes, and files
+// from an earlier build that are no longer rendered are removed.
+func (c *BuildCommand) build(srcFile, outDir string) bool {
+	config, ok := c.loadConfig(srcFile)
+	if !ok {
+		return false
+	}
+
+	files, err := config.Render()
+	if err != nil {
+		c.errorf("Failed to render units: %s", err)
+		return false
+	}
+
+	trees, err := outputTrees(config, files)
+	if err != nil {
+		c.errorf("%s", err)
+		return false
+	}
+	for _, name := range sortedKeys(trees) {
+		if !c.writeTree(filepath.Join(outDir, name), trees[name], c.opts) {
+			return false
+		}
+	}
+	return true
+}
+
+// outputTrees splits the rendered files into the trees written under the
+// output directory, keyed by their path in it. A configuration without host
+// blocks is one tree, the directory itself; otherwise each host gets the
+// tree of its units and enablement links, in a directory of its name.
+func outputTrees(config *configs.Config, files []configs.File) (map[string][]configs.File, error) {
+	if len(config.Hosts) == 0 {
+		return map[string][]configs.File{"": files}, nil
+	}
+	trees := make(map[string][]configs.File, len(config.Hosts))
+	for _, host := range config.Hosts {
+		tree, err := install.Host(files, host)
+		if err != nil {
+			return nil, err
+		}
+		trees[host.Name] = tree
+	}
+	return trees, nil
+}
+
+// writeTree brings dir in line with files and reports each change.
+func (m *Meta) writeTree(dir string, files []configs.File, opts output.Options) bool {
+	plan, err := output.NewPlan(dir, files)
+	if err != nil {
+		m.errorf("%s", err)
+		return false
+	}
+	m.warnKept(plan)
+
+	err = plan.Apply(opts, func(ch output.Change) {
+		verb := "wrote"
+		if ch.Action == output.Delete {
+			verb = "deleted"
+		}
+		fmt.Fprintln(m.Stdout, verb, filepath.Join(dir, ch.Name))
+	})
+	if err != nil {
+		m.errorf("Failed to update %s: %s", dir, err)
+		return false
+	}
+	return true
+}
+
+// watchDelay groups the bursts of events editors produce when saving.
+const watchDelay = 100 * time.Millisecond
+
+// watch builds once, then again after every change to the inputs until
+// stop receives. Build errors are reported and watching goes on.
+func (c *BuildCommand) watch(srcFile, outDir string, stop <-chan os.Signal) int {
+	w, err := newWatcher(watchPaths(srcFile))
+	if err != nil {
+		c.errorf("Failed to watch %s: %s", srcFile, err)
+		return 1
+	}
+	defer w.Close()
+
+	c.build(srcFile, outDir)
+	fmt.Fprintf(c.Stderr, "Watching %s for changes. Press Ctrl-C to stop.\n", srcFile)
+
+	for {
+		select {
+		case <-stop:
+			return 0
+		case <-w.Changes():
+		}
+
+		// Let the burst settle, then drop the events it left behind.
+		time.Sleep(watchDelay)
+		select {
+		case <-w.Changes():
+		default:
+		}
+
+		fmt.Fprintf(c.Stderr, "\n%s changed, rebuilding.\n", srcFile)
+		c.build(srcFile, outDir)
+	}
+}
+
+// watchPaths lists the files a build of srcFile reads.
+func watchPaths(srcFile string) []string {
+	return []string{srcFile}
+}
+
+// outputFlags are the flags setting the metadata of written files.
+type outputFlags struct {
+	mode, owner, group string
+}
+
+func (f *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.mode, "mode", "0644", "octal permission `bits` of the unit files")
+	fs.StringVar(&f.owner, "owner", "", "`user` owning the unit files, by name or id")
+	fs.StringVar(&f.group, "group", "", "`group` of the unit files, by name or id")
+}
+
+func (f *outputFlags) options() (output.Options, error) {
+	opts := output.DefaultOptions
+
+	bits, err := strconv.ParseUint(f.mode, 8, 32)
+	if err != nil || bits > 0o777 {
+		return opts, fmt.Errorf("invalid -mode %q: expected octal permission bits such as 0644", f.mode)
+	}
+	opts.Mode = os.FileMode(bits)
+
+	if f.owner != "" {
+		id := f.owner
+		if u, err := user.Lookup(f.owner); err == nil {
+			id = u.Uid
+		}
+		if opts.UID, err = strconv.Atoi(id); err != nil {
+			return opts, fmt.Errorf("unknown -owner %q", f.owner)
+		}
+	}
+	if f.group != "" {
+		id := f.group
+		if g, err := user.LookupGroup(f.group); err == nil {
+			id = g.Gid
+		}
+		if opts.GID, err = strconv.Atoi(id); err != nil {
+			return opts, fmt.Errorf("unknown -group %q", f.group)
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,131 @@
+// Package command implements the unitd subcommands.
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// Command is a unitd subcommand. Run receives the arguments following the
+// subcommand name and returns the process exit code.
+type Command interface {
+	Synopsis() string
+	Run(args []string) int
+}
+
+// Meta holds what every command shares: the standard streams.
+type Meta struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// DefaultMeta writes to the process standard streams.
+func DefaultMeta() Meta {
+	return Meta{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+}
+
+// Commands returns every subcommand by name.
+func Commands(meta Meta) map[string]Command {
+	return map[string]Command{
+		"apply":       &ApplyCommand{Meta: meta},
+		"build":       &BuildCommand{Meta: meta},
+		"console":     &ConsoleCommand{Meta: meta},
+		"doc":         &DocCommand{Meta: meta},
+		"drift":       &DriftCommand{Meta: meta},
+		"fmt":         &FmtCommand{Meta: meta},
+		"generations": &GenerationsCommand{Meta: meta},
+		"graph":       &GraphCommand{Meta: meta},
+		"import":      &ImportCommand{Meta: meta},
+		"install":     &InstallCommand{Meta: meta},
+		"lsp":         &LSPCommand{Meta: meta},
+		"package":     &PackageCommand{Meta: meta},
+		"plan":        &PlanCommand{Meta: meta},
+		"rollback":    &RollbackCommand{Meta: meta},
+		"schema":      &SchemaCommand{Meta: meta},
+		"validate":    &ValidateCommand{Meta: meta},
+	}
+}
+
+// Usage writes the list of subcommands.
+func Usage(w io.Writer, commands map[string]Command) {
+	names := make([]string, 0, len(commands))
+	width := 0
+	for name := range commands {
+		names = append(names, name)
+		width = max(width, len(name))
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "usage: unitd <command> [args]\n\ncommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, name, commands[name].Synopsis())
+	}
+}
+
+func (m *Meta) errorf(format string, args ...any) {
+	fmt.Fprintf(m.Stderr, "Error: "+format+"\n", args...)
+}
+
+// showDiagnostics writes diags to Stderr, with source snippets for the
+// files that are known to the parser.
+func (m *Meta) showDiagnostics(diags hcl.Diagnostics, files map[string]*hcl.File) {
+	if len(diags) == 0 {
+		return
+	}
+	wr := hcl.NewDiagnosticTextWriter(m.Stderr, files, 78, false)
+	_ = wr.WriteDiagnostics(diags)
+}
+
+// loadConfig decodes and validates the configuration at path. Diagnostics
+// are written to Stderr; ok is false when there were errors.
+func (m *Meta) loadConfig(path string) (config *configs.Config, ok bool) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		m.errorf("Failed to load configuration: %s", err)
+		return nil, false
+	}
+	files := map[string]*hcl.File{path: {Bytes: src}}
+
+	config, diags := configs.Decode(path, src)
+	if !diags.HasErrors() {
+		diags = append(diags, config.Validate()...)
+	}
+	m.showDiagnostics(diags, files)
+	if diags.HasErrors() {
+		return nil, false
+	}
+	return config, true
+}
+
+// parseFlags parses args allowing flags after positional arguments, as in
+// "unitd import ./units -o units.hcl", and returns the positional ones.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ConsoleCommand evaluates expressions against a configuration.
+type ConsoleCommand struct {
+	Meta
+}
+
+func (c *ConsoleCommand) Synopsis() string {
+	return "Evaluate expressions against a configuration"
+}
+
+func (c *ConsoleCommand) Run(args []string) int {
+	fs := flag.NewFlagSet("console", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd console <src.hcl>\n\n")
+		fmt.Fprintf(c.Stderr, "Reads one expression per line, such as service.worker[\"email\"] or\n")
+		fmt.Fprintf(c.Stderr, "builtin.target.multi_user, and prints its value. Type exit or press\n")
+		fmt.Fprintf(c.Stderr, "Ctrl-D to quit.\n")
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return 1
+	}
+	path := rest[0]
+
+	if _, ok := c.loadConfig(path); !ok {
+		return 1
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		c.errorf("Failed to load configuration: %s", err)
+		return 1
+	}
+	ctx, diags := configs.DecodeEvalContext(path, src)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags, map[string]*hcl.File{path: {Bytes: src}})
+		return 1
+	}
+
+	interactive := isTerminal(c.Stdin)
+	prompt := func(s string) {
+		if interactive {
+			fmt.Fprint(c.Stdout, s)
+		}
+	}
+
+	failed := false
+	scanner := bufio.NewScanner(c.Stdin)
+	var expr strings.Builder
+	for prompt("> "); scanner.Scan(); {
+		expr.WriteString(scanner.Text())
+		expr.WriteString("\n")
+
+		line := strings.TrimSpace(expr.String())
+		switch {
+		case line == "":
+			expr.Reset()
+			prompt("> ")
+			continue
+		case line == "exit":
+			return 0
+		case unclosed(line):
+			prompt("... ")
+			continue
+		}
+		expr.Reset()
+
+		out, diags := evalConsole(line, ctx)
+		if diags.HasErrors() {
+			c.showDiagnostics(diags, map[string]*hcl.File{"<console>": {Bytes: []byte(line)}})
+			failed = true
+		} else {
+			fmt.Fprintln(c.Stdout, out)
+		}
+		prompt("> ")
+	}
+	if err := scanner.Err(); err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+	if interactive {
+		fmt.Fprintln(c.Stdout)
+	}
+	if failed && !interactive {
+		return 1
+	}
+	return 0
+}
+
+// evalConsole evaluates src in ctx and renders the value as HCL.
+func evalConsole(src string, ctx *hcl.EvalContext) (string, hcl.Diagnostics) {
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "<console>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	return string(hclwrite.Format(consoleTokens(val).Bytes())), diags
+}
+
+// consoleTokens renders val the way it would be written in a configuration:
+// self.* specifiers appear as %i, %n, … and the reset value as reset.
+func consoleTokens(val cty.Value) hclwrite.Tokens {
+	switch {
+	case val.IsNull() || !val.IsKnown():
+		return hclwrite.TokensForValue(val)
+
+	case val.Type() == cty.String:
+		s := val.AsString()
+		if configs.IsReset(s) {
+			return hclwrite.TokensForIdentifier("reset")
+		}
+		var b strings.Builder
+		configs.SplitSpecifiers(s, func(lit string) { b.WriteString(lit) }, func(r rune) {
+			b.WriteString("%" + string(r))
+		})
+		return hclwrite.TokensForValue(cty.StringVal(b.String()))
+
+	case val.Type().IsObjectType() || val.Type().IsMapType():
+		elems := make(map[string]cty.Value, val.LengthInt())
+		keys := make([]string, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			elems[k.AsString()] = v
+			keys = append(keys, k.AsString())
+		}
+		sort.Strings(keys)
+		attrs := make([]hclwrite.ObjectAttrTokens, len(keys))
+		for i, k := range keys {
+			name := hclwrite.TokensForValue(cty.StringVal(k))
+			if hclsyntax.ValidIdentifier(k) {
+				name = hclwrite.TokensForIdentifier(k)
+			}
+			attrs[i] = hclwrite.ObjectAttrTokens{Name: name, Value: consoleTokens(elems[k])}
+		}
+		return hclwrite.TokensForObject(attrs)
+
+	case val.CanIterateElements():
+		var elems []hclwrite.Tokens
+		for it := val.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			elems = append(elems, consoleTokens(v))
+		}
+		return hclwrite.TokensForTuple(elems)
+	}
+	return hclwrite.TokensForValue(val)
+}
+
+// unclosed reports whether src opens more brackets than it closes, in which
+// case the console reads another line.
+func unclosed(src string) bool {
+	tokens, _ := hclsyntax.LexExpression([]byte(src), "<console>", hcl.InitialPos)
+	depth := 0
+	for _, tok := range tokens {
+		switch tok.Type {
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen, hclsyntax.TokenTemplateInterp:
+			depth++
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen, hclsyntax.TokenTemplateSeqEnd:
+			depth--
+		}
+	}
+	return depth > 0
+}
+
+// isTerminal reports whether r is an interactive terminal.
+func isTerminal(r any) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
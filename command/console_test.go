@@ -0,0 +1,42 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConsole(t *testing.T) {
+	input := strings.Join([]string{
+		`service.worker["email"]`,
+		`builtin.target.multi_user`,
+		`instance.queue_workers`,
+		`[reset, "${self.instance}-50%"]`,
+		`service.missing`,
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	cmd := &ConsoleCommand{Meta: Meta{
+		Stdin:  strings.NewReader(input),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}}
+	if code := cmd.Run([]string{"../example/src/services.hcl"}); code != 1 {
+		t.Errorf("exit code %d, want 1 after a failed expression", code)
+	}
+
+	want := `"worker-email@.service"
+"multi-user.target"
+{
+  q1 = "worker-queue@q1.service"
+  q2 = "worker-queue@q2.service"
+}
+[reset, "%i-50%"]
+`
+	if stdout.String() != want {
+		t.Errorf("stdout:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+	if !strings.Contains(stderr.String(), `does not have an attribute named "missing"`) {
+		t.Errorf("stderr lacks the evaluation error:\n%s", stderr.String())
+	}
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// docWidth is the width documentation text is wrapped at.
+const docWidth = 80
+
+// DocCommand prints the documentation of a directive or section block.
+type DocCommand struct {
+	Meta
+}
+
+func (c *DocCommand) Synopsis() string {
+	return "Show the documentation of a directive or block"
+}
+
+func (c *DocCommand) Run(args []string) int {
+	fs := flag.NewFlagSet("doc", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd doc <directive|block>\n\n")
+		fmt.Fprintf(c.Stderr, "Directives are named in HCL (exec_start), in systemd (ExecStart=) or\nqualified by their block (service.exec_start).\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 1
+	}
+	query := fs.Arg(0)
+
+	if section, ok := configs.LookupSection(query); ok {
+		writeSectionDoc(c.Stdout, section)
+		return 0
+	}
+
+	var found []configs.Directive
+	if section, name, ok := strings.Cut(query, "."); ok {
+		if d, ok := configs.LookupDirective(section, name); ok {
+			found = append(found, d)
+		}
+	} else {
+		found = configs.FindDirectives(query)
+	}
+
+	if len(found) == 0 {
+		c.errorf("No directive or block named %q.%s", query, docSuggestion(query))
+		return 1
+	}
+	for i, d := range found {
+		if i > 0 {
+			fmt.Fprintln(c.Stdout)
+		}
+		writeDirectiveDoc(c.Stdout, d)
+	}
+	return 0
+}
+
+func writeDirectiveDoc(w io.Writer, d configs.Directive) {
+	fmt.Fprintf(w, "%s= (%s.%s)\n", d.Systemd, d.Section, d.Name)
+	fmt.Fprintf(w, "  Type:       %s\n", d.Type)
+	if len(d.Values) > 0 {
+		fmt.Fprintf(w, "  Values:     %s\n", strings.Join(d.Values, ", "))
+	}
+	if d.Ref != "" {
+		fmt.Fprintf(w, "  References: %s names\n", d.Ref)
+	}
+	fmt.Fprintf(w, "  Unit types: %s\n", strings.Join(d.UnitTypes, ", "))
+	fmt.Fprintf(w, "  Man page:   %s\n", d.ManPage)
+	if d.Doc != "" {
+		fmt.Fprintf(w, "\n%s", wrapText(d.Doc, docWidth))
+	}
+}
+
+func writeSectionDoc(w io.Writer, s configs.Section) {
+	fmt.Fprintf(w, "%s (%s block)\n", s.Systemd, s.Name)
+	fmt.Fprintf(w, "  Unit types: %s\n", strings.Join(s.UnitTypes, ", "))
+	fmt.Fprintf(w, "  Man page:   %s\n", s.ManPage)
+	if s.Doc != "" {
+		fmt.Fprintf(w, "\n%s", wrapText(s.Doc, docWidth))
+	}
+
+	fmt.Fprintf(w, "\nDirectives:\n")
+	for _, d := range configs.SectionDirectives(s.Name) {
+		fmt.Fprintf(w, "  %-36s %s=\n", d.Name, d.Systemd)
+	}
+}
+
+// docSuggestion returns a " Did you mean …?" hint for a misspelled query.
+func docSuggestion(query string) string {
+	var names []string
+	for _, s := range configs.Sections {
+		names = append(names, s.Name)
+	}
+	for _, d := range configs.Directives {
+		names = append(names, d.Name, d.Systemd)
+	}
+	best := configs.NameSuggestion(query, names)
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" Did you mean %q?", best)
+}
+
+// wrapText wraps each paragraph of text at width columns.
+func wrapText(text string, width int) string {
+	var b strings.Builder
+	for i, p := range strings.Split(text, "\n\n") {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		col := 0
+		for _, word := range strings.Fields(p) {
+			if col > 0 && col+1+len(word) > width {
+				b.WriteString("\n")
+				col = 0
+			}
+			if col > 0 {
+				b.WriteString(" ")
+				col++
+			}
+			b.WriteString(word)
+			col += len(word)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"path"
+
+	"github.com/vanviethieuanh/unitd/drift"
+	"github.com/vanviethieuanh/unitd/install"
+)
+
+// Exit codes of unitd drift.
+const (
+	driftNone  = 0 // the host matches the configuration
+	driftError = 1 // usage, configuration or connection error
+	driftFound = 2 // the host drifted
+)
+
+var driftSymbols = map[drift.Kind]string{
+	drift.Modified: "~",
+	drift.Missing:  "-",
+	drift.DropIn:   "+",
+	drift.Enabled:  "+",
+}
+
+// DriftCommand compares the unit files of a host with the configuration.
+type DriftCommand struct {
+	Meta
+}
+
+func (c *DriftCommand) Synopsis() string {
+	return "Report changes made to a host outside of unitd"
+}
+
+func (c *DriftCommand) Run(args []string) int {
+	var root string
+	fs := flag.NewFlagSet("drift", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&root, "root", "", "the hosts were deployed to <dir>/<host> instead of over SSH")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd drift [-root=dir] <src.hcl> <host>\n\n")
+		fmt.Fprintf(c.Stderr, "Compares /%s on the host with the units compiled for it:\n", install.UnitDir)
+		fmt.Fprintf(c.Stderr, "edited or missing unit files, drop-ins and enablement links added by hand.\n")
+		fmt.Fprintf(c.Stderr, "Exits %d when the host matches, %d when it drifted and %d on errors.\n\n", driftNone, driftFound, driftError)
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return driftError
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return driftError
+	}
+
+	config, ok := c.loadConfig(rest[0])
+	if !ok {
+		return driftError
+	}
+	host, ok := lookupHost(config, rest[1])
+	if !ok {
+		c.errorf("No host block named %q.", rest[1])
+		return driftError
+	}
+	files, err := config.Render()
+	if err != nil {
+		c.errorf("Failed to render units: %s", err)
+		return driftError
+	}
+	tree, err := install.Host(files, host)
+	if err != nil {
+		c.errorf("%s", err)
+		return driftError
+	}
+
+	t, _, err := hostTransport(host, root)
+	if err != nil {
+		c.errorf("%s: %s", host.Name, err)
+		return driftError
+	}
+	defer t.Close()
+
+	dir := "/" + install.UnitDir
+	live, err := t.Snapshot(dir)
+	if err != nil {
+		c.errorf("%s: failed to read %s: %s", host.Name, dir, err)
+		return driftError
+	}
+	findings, err := drift.Detect(tree, live)
+	if err != nil {
+		c.errorf("%s: %s", host.Name, err)
+		return driftError
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintf(c.Stdout, "No drift. %s matches the configuration.\n", host.Name)
+		return driftNone
+	}
+	for _, f := range findings {
+		fmt.Fprintf(c.Stdout, "%s %s:%s: %s\n", driftSymbols[f.Kind], host.Name, path.Join(dir, f.Name), f.Detail)
+	}
+	fmt.Fprintf(c.Stdout, "Drift: %d difference(s) on %s.\n", len(findings), host.Name)
+	return driftFound
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDriftAfterApply(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config.hcl")
+	if err := os.WriteFile(src, []byte(`
+service "web" {
+  unit {}
+  service {
+    exec_start = ["/usr/bin/web"]
+  }
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+host "h1" {
+  enable = ["web.service"]
+}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(dir, "out")
+
+	var stdout, stderr bytes.Buffer
+	apply := &ApplyCommand{Meta: Meta{Stdout: &stdout, Stderr: &stderr}}
+	if code := apply.Run([]string{"-root=" + root, src}); code != 0 {
+		t.Fatalf("apply exited %d\nstdout: %s\nstderr: %s", code, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	drift := &DriftCommand{Meta: Meta{Stdout: &stdout, Stderr: &stderr}}
+	if code := drift.Run([]string{"-root=" + root, src, "h1"}); code != driftNone {
+		t.Errorf("drift exited %d after apply\nstdout: %s\nstderr: %s", code, stdout.String(), stderr.String())
+	}
+
+	if err := os.Remove(filepath.Join(root, "h1/etc/systemd/system/web.service")); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if code := drift.Run([]string{"-root=" + root, src, "h1"}); code != driftFound {
+		t.Errorf("drift exited %d with web.service removed, want %d\nstdout: %s", code, driftFound, stdout.String())
+	}
+}
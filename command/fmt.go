@@ -0,0 +1,147 @@
+package command
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/vanviethieuanh/unitd/format"
+)
+
+// FmtCommand rewrites configuration files to the canonical layout.
+type FmtCommand struct {
+	Meta
+}
+
+func (c *FmtCommand) Synopsis() string {
+	return "Rewrite configuration files to the canonical format"
+}
+
+func (c *FmtCommand) Run(args []string) int {
+	var check, diff, recursive bool
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(c.Stderr)
+	flags.BoolVar(&check, "check", false, "do not write files; exit with status 3 if any file is not formatted")
+	flags.BoolVar(&diff, "diff", false, "print the changes as unified diffs")
+	flags.BoolVar(&recursive, "recursive", false, "also process subdirectories")
+	flags.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd fmt [-check] [-diff] [-recursive] [path ...]\n\n")
+		flags.PrintDefaults()
+	}
+	paths, err := parseFlags(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	if len(paths) == 1 && paths[0] == "-" {
+		src, err := io.ReadAll(c.Stdin)
+		if err != nil {
+			c.errorf("%s", err)
+			return 1
+		}
+		out, diags := format.Source("<stdin>", src)
+		if diags.HasErrors() {
+			c.showDiagnostics(diags, map[string]*hcl.File{"<stdin>": {Bytes: src}})
+			return 1
+		}
+		changed := !bytes.Equal(src, out)
+		if diff && changed {
+			fmt.Fprint(c.Stdout, unifiedDiff(diffName("old", "<stdin>"), diffName("new", "<stdin>"), string(src), string(out)))
+		}
+		switch {
+		case check && changed:
+			return 3
+		case !check && !diff:
+			_, _ = c.Stdout.Write(out)
+		}
+		return 0
+	}
+
+	var files []string
+	for _, path := range paths {
+		found, err := configFiles(path, recursive)
+		if err != nil {
+			c.errorf("%s", err)
+			return 1
+		}
+		files = append(files, found...)
+	}
+
+	status := 0
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			c.errorf("%s", err)
+			status = 1
+			continue
+		}
+		out, diags := format.Source(path, src)
+		if diags.HasErrors() {
+			c.showDiagnostics(diags, map[string]*hcl.File{path: {Bytes: src}})
+			status = 1
+			continue
+		}
+		if bytes.Equal(src, out) {
+			continue
+		}
+
+		fmt.Fprintln(c.Stdout, path)
+		if diff {
+			fmt.Fprint(c.Stdout, unifiedDiff(diffName("old", path), diffName("new", path), string(src), string(out)))
+		}
+		if check {
+			if status == 0 {
+				status = 3
+			}
+			continue
+		}
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			c.errorf("Failed to write %s: %s", path, err)
+			status = 1
+		}
+	}
+	return status
+}
+
+// configFiles returns path if it is a file, or the *.hcl files of the
+// directory at path. Hidden directories are skipped when recursing.
+func configFiles(path string, recursive bool) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && (!recursive || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".hcl") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func diffName(prefix, path string) string {
+	return prefix + "/" + strings.TrimPrefix(filepath.ToSlash(path), "/")
+}
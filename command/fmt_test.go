@@ -0,0 +1,45 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vanviethieuanh/unitd/format"
+)
+
+func TestFmtStdin(t *testing.T) {
+	src := "service \"web\" {\nrestart_policy=\"none\"\n}\n"
+	out, diags := format.Source("<stdin>", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	formatted := string(out)
+
+	tests := []struct {
+		args   []string
+		src    string
+		code   int
+		stdout string // exact output, or with -diff a part of it
+	}{
+		{[]string{"-"}, src, 0, formatted},
+		{[]string{"-check", "-"}, src, 3, ""},
+		{[]string{"-check", "-"}, formatted, 0, ""},
+		{[]string{"-diff", "-"}, src, 0, `+  restart_policy = "none"`},
+		{[]string{"-check", "-diff", "-"}, src, 3, "--- old/<stdin>"},
+		{[]string{"-check", "-diff", "-"}, formatted, 0, ""},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		cmd := &FmtCommand{Meta: Meta{Stdin: strings.NewReader(tt.src), Stdout: &stdout, Stderr: &stderr}}
+		name := strings.Join(tt.args, " ")
+		if got := cmd.Run(tt.args); got != tt.code {
+			t.Errorf("%s: exit code %d, want %d\nstderr: %s", name, got, tt.code, stderr.String())
+		}
+		got := stdout.String()
+		diffMode := strings.Contains(name, "-diff") && tt.stdout != ""
+		if (diffMode && !strings.Contains(got, tt.stdout)) || (!diffMode && got != tt.stdout) {
+			t.Errorf("%s: stdout = %q, want %q", name, got, tt.stdout)
+		}
+	}
+}
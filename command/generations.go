@@ -0,0 +1,94 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/vanviethieuanh/unitd/generation"
+)
+
+// GenerationsCommand lists the generations recorded on a host.
+type GenerationsCommand struct {
+	Meta
+}
+
+func (c *GenerationsCommand) Synopsis() string {
+	return "List the generations deployed to a host"
+}
+
+func (c *GenerationsCommand) Run(args []string) int {
+	var root string
+	fs := flag.NewFlagSet("generations", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&root, "root", "", "the hosts were deployed to <dir>/<host> instead of over SSH")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd generations [-root=dir] <src.hcl> <host>\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return 1
+	}
+
+	config, ok := c.loadConfig(rest[0])
+	if !ok {
+		return 1
+	}
+	host, ok := lookupHost(config, rest[1])
+	if !ok {
+		c.errorf("No host block named %q.", rest[1])
+		return 1
+	}
+	t, _, err := hostTransport(host, root)
+	if err != nil {
+		c.errorf("%s: %s", host.Name, err)
+		return 1
+	}
+	defer t.Close()
+
+	gens, err := generation.List(t)
+	if err != nil {
+		c.errorf("%s: failed to read the generations: %s", host.Name, err)
+		return 1
+	}
+	if len(gens) == 0 {
+		fmt.Fprintf(c.Stdout, "No generations recorded on %s.\n", host.Name)
+		return 0
+	}
+
+	w := tabwriter.NewWriter(c.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "GENERATION\tTIME\tSOURCE\tFILES")
+	for i, g := range gens {
+		var notes []string
+		if g.Restores != 0 {
+			notes = append(notes, fmt.Sprintf("restores %d", g.Restores))
+		}
+		if i == len(gens)-1 {
+			notes = append(notes, "live")
+		}
+		files := strconv.Itoa(g.Count)
+		if len(notes) > 0 {
+			files += "  (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", g.Number, g.Time.Format(time.RFC3339), shortHash(g.Source), files)
+	}
+	w.Flush()
+	return 0
+}
+
+// shortHash abbreviates a "sha256:<hex>" hash to 12 digits.
+func shortHash(hash string) string {
+	algo, digits, ok := strings.Cut(hash, ":")
+	if !ok || len(digits) <= 12 {
+		return hash
+	}
+	return algo + ":" + digits[:12]
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/graph"
+)
+
+// GraphCommand prints the dependency graph between the units of a
+// configuration.
+type GraphCommand struct {
+	Meta
+}
+
+func (c *GraphCommand) Synopsis() string {
+	return "Print the unit dependency graph"
+}
+
+func (c *GraphCommand) Run(args []string) int {
+	var format, edges, focus string
+	var depth int
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&format, "format", "dot", "output `format`: dot, mermaid or json")
+	fs.StringVar(&edges, "edges", "all", "edges to show: all, ordering or requirement")
+	fs.StringVar(&focus, "focus", "", "only show the neighbourhood of `unit` (file name or service.<name>)")
+	fs.IntVar(&depth, "depth", 1, "neighbourhood radius used with -focus")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd graph [-format=dot|mermaid|json] [-edges=all|ordering|requirement] [-focus=unit] [-depth=n] <src.hcl>\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return 1
+	}
+
+	config, ok := c.loadConfig(rest[0])
+	if !ok {
+		return 1
+	}
+
+	g, err := graph.Build(config)
+	if err != nil {
+		c.errorf("Failed to build graph: %s", err)
+		return 1
+	}
+
+	// The focus units stay in the graph when they have no edges of the
+	// class shown.
+	var units []string
+	if focus != "" {
+		units = focusUnits(config, focus)
+	}
+	switch edges {
+	case "all":
+	case "ordering":
+		g = g.FilterClass(graph.ClassOrdering, units...)
+	case "requirement":
+		g = g.FilterClass(graph.ClassRequirement, units...)
+	default:
+		c.errorf("Unknown edge filter %q; expected all, ordering or requirement.", edges)
+		return 1
+	}
+
+	if focus != "" {
+		g, err = g.Neighbourhood(units, depth)
+		if err != nil {
+			c.errorf("%s", err)
+			return 1
+		}
+	}
+
+	switch format {
+	case "dot":
+		err = g.WriteDOT(c.Stdout)
+	case "mermaid":
+		err = g.WriteMermaid(c.Stdout)
+	case "json":
+		err = g.WriteJSON(c.Stdout)
+	default:
+		c.errorf("Unknown graph format %q; expected dot, mermaid or json.", format)
+		return 1
+	}
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+	return 0
+}
+
+// focusUnits resolves a -focus value. A service.<name> address stands for
+// every unit file rendered from that block.
+func focusUnits(config *configs.Config, focus string) []string {
+	name, ok := strings.CutPrefix(focus, "service.")
+	if !ok {
+		return []string{focus}
+	}
+	var units []string
+	for _, svc := range config.Services {
+		if svc.Name == name {
+			units = append(units, svc.UnitFilenames()...)
+		}
+	}
+	if len(units) == 0 {
+		return []string{focus}
+	}
+	return units
+}
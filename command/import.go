@@ -0,0 +1,181 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/importer"
+)
+
+// ImportCommand converts existing unit files into a unitd configuration.
+type ImportCommand struct {
+	Meta
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Convert existing unit files into HCL"
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	var out string
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&out, "o", "", "write the configuration to `file` instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd import [-o out.hcl] <dir|unit file>...\n\n")
+		fs.PrintDefaults()
+	}
+	paths, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return 1
+	}
+
+	im := importer.New()
+	var diags hcl.Diagnostics
+	var hints []string
+	for _, path := range paths {
+		units, links, moreDiags := collectUnits(path)
+		diags = append(diags, moreDiags...)
+		for _, unit := range units {
+			im.AddUnit(unit)
+		}
+		hints = append(hints, links...)
+	}
+	for _, name := range hints {
+		im.AddInstanceHint(name)
+	}
+
+	f, moreDiags := im.File()
+	diags = append(diags, moreDiags...)
+	c.showDiagnostics(diags, diagnosticSources(diags))
+	if diags.HasErrors() {
+		return 1
+	}
+
+	src := hclwrite.Format(f.Bytes())
+	if out == "" {
+		_, _ = c.Stdout.Write(src)
+		return 0
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		c.errorf("Failed to write %s: %s", out, err)
+		return 1
+	}
+	fmt.Fprintln(c.Stdout, "wrote", out)
+	return 0
+}
+
+// enablementDirs are the directories systemctl enable links units into. The
+// names of the links found there tell which template instances are in use.
+var enablementDirs = []string{".wants", ".requires", ".upholds"}
+
+// collectUnits loads the unit file at path, or every unit file of the
+// directory at path. Aliases (symlinks) and drop-in directories are skipped;
+// drop-ins are merged by LoadUnitFile.
+func collectUnits(path string) ([]*configs.SystemdUnit, []string, hcl.Diagnostics) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read unit files",
+			Detail:   err.Error(),
+		}}
+	}
+	if !info.IsDir() {
+		unit, diags := configs.LoadUnitFile(path)
+		if unit == nil {
+			return nil, nil, diags
+		}
+		return []*configs.SystemdUnit{unit}, nil, diags
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read unit files",
+			Detail:   err.Error(),
+		}}
+	}
+
+	var units []*configs.SystemdUnit
+	var links []string
+	var diags hcl.Diagnostics
+	for _, e := range entries {
+		full := filepath.Join(path, e.Name())
+		switch {
+		case e.IsDir():
+			if hasAnySuffix(e.Name(), enablementDirs) {
+				links = append(links, dirNames(full)...)
+			}
+		case e.Type()&os.ModeSymlink != 0:
+			continue
+		case isUnitFileName(e.Name()):
+			unit, moreDiags := configs.LoadUnitFile(full)
+			diags = append(diags, moreDiags...)
+			if unit != nil {
+				units = append(units, unit)
+			}
+		}
+	}
+	return units, links, diags
+}
+
+// diagnosticSources reads the unit files diags point into, so that the
+// diagnostics quote the offending lines.
+func diagnosticSources(diags hcl.Diagnostics) map[string]*hcl.File {
+	files := make(map[string]*hcl.File)
+	for _, diag := range diags {
+		if diag.Subject == nil {
+			continue
+		}
+		name := diag.Subject.Filename
+		if _, ok := files[name]; ok {
+			continue
+		}
+		if src, err := os.ReadFile(name); err == nil {
+			files[name] = &hcl.File{Bytes: src}
+		}
+	}
+	return files
+}
+
+func dirNames(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name()
+	}
+	return names
+}
+
+var unitSuffixes = []string{
+	".service", ".socket", ".timer", ".path", ".mount", ".automount",
+	".swap", ".target", ".slice", ".scope", ".device",
+}
+
+func isUnitFileName(name string) bool {
+	return hasAnySuffix(name, unitSuffixes)
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/install"
+)
+
+// InstallCommand installs and enables the units of a configuration in a
+// root filesystem.
+type InstallCommand struct {
+	Meta
+}
+
+func (c *InstallCommand) Synopsis() string {
+	return "Install and enable units in a root filesystem"
+}
+
+func (c *InstallCommand) Run(args []string) int {
+	var root, hostName string
+	var preset bool
+	var out outputFlags
+	fs := flag.NewFlagSet("install", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&root, "root", "", "root filesystem `dir` to install into")
+	fs.StringVar(&hostName, "host", "", "install the units of the host block `name` only")
+	fs.BoolVar(&preset, "preset", false, "write a preset file for \"systemctl preset-all\" instead of enabling units; needs -host")
+	out.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd install -root=<dir> [-host=name [-preset]] [-mode=0644] [-owner=user] [-group=group] <src.hcl>\n\n")
+		fmt.Fprintf(c.Stderr, "Writes the units to <dir>/%s and enables them as\n", install.UnitDir)
+		fmt.Fprintf(c.Stderr, "\"systemctl --root=<dir> enable\" would. Without -host, every unit is\n")
+		fmt.Fprintf(c.Stderr, "installed and enabled. With -preset, the enable and disable lists of the\n")
+		fmt.Fprintf(c.Stderr, "host go to <dir>/%s/%s instead,\n", install.PresetDir, install.PresetName("<host>"))
+		fmt.Fprintf(c.Stderr, "for \"systemctl preset-all\" to apply at first boot.\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 1 || root == "" || (preset && hostName == "") {
+		fs.Usage()
+		return 1
+	}
+	opts, err := out.options()
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+
+	config, ok := c.loadConfig(rest[0])
+	if !ok {
+		return 1
+	}
+	files, err := config.Render()
+	if err != nil {
+		c.errorf("Failed to render units: %s", err)
+		return 1
+	}
+
+	var tree, presets []configs.File
+	if hostName != "" {
+		host, ok := lookupHost(config, hostName)
+		if !ok {
+			c.errorf("No host block named %q.", hostName)
+			return 1
+		}
+		if preset {
+			tree, err = install.Closure(files, host.Units())
+			presets = []configs.File{install.Preset(host)}
+		} else {
+			tree, err = install.Host(files, host)
+		}
+	} else {
+		var links []configs.File
+		links, err = install.Enable(files, install.Names(files, instanceUnits(config)))
+		tree = append(files, links...)
+	}
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+
+	if !c.writeTree(filepath.Join(root, install.UnitDir), tree, opts) {
+		return 1
+	}
+	if preset && !c.writeTree(filepath.Join(root, install.PresetDir), presets, opts) {
+		return 1
+	}
+	return 0
+}
+
+// instanceUnits lists the units of the instance blocks of config.
+func instanceUnits(config *configs.Config) []string {
+	var names []string
+	for _, inst := range config.Instances {
+		for _, id := range inst.Instances {
+			names = append(names, configs.InstanceUnitName(inst.Template, id))
+		}
+	}
+	return names
+}
+
+func lookupHost(config *configs.Config, name string) (configs.Host, bool) {
+	for _, h := range config.Hosts {
+		if h.Name == name {
+			return h, true
+		}
+	}
+	return configs.Host{}, false
+}
@@ -0,0 +1,34 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/vanviethieuanh/unitd/lsp"
+)
+
+// LSPCommand runs the language server on stdin and stdout.
+type LSPCommand struct {
+	Meta
+}
+
+func (c *LSPCommand) Synopsis() string {
+	return "Run the language server over stdio"
+}
+
+func (c *LSPCommand) Run(args []string) int {
+	fs := flag.NewFlagSet("lsp", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd lsp\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := lsp.NewServer(c.Stdin, c.Stdout).Run(); err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,196 @@
+package command
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/deb"
+	"github.com/vanviethieuanh/unitd/install"
+	"github.com/vanviethieuanh/unitd/manager"
+)
+
+// packageUnitDir is where packages install unit files: the directory of
+// the distribution, which /etc/systemd/system overrides.
+const packageUnitDir = "/usr/lib/systemd/system"
+
+// PackageCommand builds a package of the units of each host.
+type PackageCommand struct {
+	Meta
+}
+
+func (c *PackageCommand) Synopsis() string {
+	return "Build .deb packages of the units of hosts"
+}
+
+func (c *PackageCommand) Run(args []string) int {
+	var format, hostName string
+	fs := flag.NewFlagSet("package", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&format, "format", "deb", "package `format`; only deb is supported")
+	fs.StringVar(&hostName, "host", "", "package the host block `name` only")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd package [-format=deb] [-host=name] <src.hcl> <outdir>\n\n")
+		fmt.Fprintf(c.Stderr, "Builds <name>_<version>_<arch>.deb in <outdir> for each host with a package\n")
+		fmt.Fprintf(c.Stderr, "block. The package installs the units of the host in %s;\n", packageUnitDir)
+		fmt.Fprintf(c.Stderr, "its maintainer scripts enable the units of the enable list, start them and\n")
+		fmt.Fprintf(c.Stderr, "restart them on upgrade. Files are dated SOURCE_DATE_EPOCH, or 1970, so that\n")
+		fmt.Fprintf(c.Stderr, "the same configuration always builds the same package.\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return 1
+	}
+	if format != "deb" {
+		c.errorf("Unsupported package format %q: only deb is supported.", format)
+		return 1
+	}
+	srcFile, outDir := rest[0], rest[1]
+
+	stamp := time.Unix(0, 0)
+	if env := os.Getenv("SOURCE_DATE_EPOCH"); env != "" {
+		secs, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			c.errorf("Invalid SOURCE_DATE_EPOCH %q: want seconds since 1970.", env)
+			return 1
+		}
+		stamp = time.Unix(secs, 0)
+	}
+
+	config, ok := c.loadConfig(srcFile)
+	if !ok {
+		return 1
+	}
+	var hosts []configs.Host
+	if hostName != "" {
+		host, ok := lookupHost(config, hostName)
+		if !ok {
+			c.errorf("No host block named %q.", hostName)
+			return 1
+		}
+		if host.Package == nil {
+			c.errorf("Host %q has no package block.", hostName)
+			return 1
+		}
+		hosts = append(hosts, host)
+	} else {
+		for _, host := range config.Hosts {
+			if host.Package != nil {
+				hosts = append(hosts, host)
+			}
+		}
+		if len(hosts) == 0 {
+			c.errorf("No host block has a package block.")
+			return 1
+		}
+	}
+
+	files, err := config.Render()
+	if err != nil {
+		c.errorf("Failed to render units: %s", err)
+		return 1
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+	policies := config.RestartPolicies()
+	for _, host := range hosts {
+		pkg, err := hostPackage(host, files, policies)
+		if err != nil {
+			c.errorf("%s", err)
+			return 1
+		}
+		pkg.Time = stamp
+
+		var buf bytes.Buffer
+		if err := pkg.Write(&buf); err != nil {
+			c.errorf("host %q: %s", host.Name, err)
+			return 1
+		}
+		name := filepath.Join(outDir, pkg.Filename())
+		if err := os.WriteFile(name, buf.Bytes(), 0o644); err != nil {
+			c.errorf("%s", err)
+			return 1
+		}
+		fmt.Fprintf(c.Stdout, "wrote %s\n", name)
+	}
+	return 0
+}
+
+// hostPackage returns the package of host: the units it references with
+// their dependencies, and maintainer scripts for its enable and disable
+// lists.
+func hostPackage(host configs.Host, files []configs.File, policies map[string]string) (*deb.Package, error) {
+	tree, err := install.Closure(files, host.Units())
+	if err != nil {
+		return nil, fmt.Errorf("host %q: %w", host.Name, err)
+	}
+
+	var enable []string
+	for _, name := range host.Enable {
+		if !slices.Contains(host.Disable, name) {
+			enable = append(enable, name)
+		}
+	}
+	// Templates are enabled and started through their DefaultInstance=.
+	enable, err = install.Instances(tree, enable)
+	if err != nil {
+		return nil, fmt.Errorf("host %q: %w", host.Name, err)
+	}
+
+	var units deb.Units
+	for _, name := range enable {
+		units.Enable = append(units.Enable, name)
+		switch manager.Policy(name, policies) {
+		case configs.RestartPolicyReload:
+			units.Reload = append(units.Reload, name)
+		case configs.RestartPolicyNone:
+		default:
+			units.Restart = append(units.Restart, name)
+		}
+	}
+	units.Disable = host.Disable
+
+	spec := host.Package
+	control := deb.Control{
+		Package:      spec.Name,
+		Version:      spec.Version,
+		Architecture: spec.Architecture,
+		Maintainer:   spec.Maintainer,
+		Depends:      spec.Depends,
+		Section:      "admin",
+		Priority:     "optional",
+		Description:  spec.Description,
+	}
+	if control.Architecture == "" {
+		control.Architecture = "all"
+	}
+	if control.Maintainer == "" {
+		control.Maintainer = "unitd"
+	}
+	if control.Description == "" {
+		control.Description = fmt.Sprintf("systemd units of host %s\nGenerated by unitd.", host.Name)
+	}
+
+	pkg := &deb.Package{Control: control, Scripts: deb.Scripts(units)}
+	for _, f := range tree {
+		pkg.Files = append(pkg.Files, deb.File{
+			Path:    path.Join(packageUnitDir, f.Name),
+			Content: []byte(f.Content),
+		})
+	}
+	return pkg, nil
+}
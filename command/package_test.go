@@ -0,0 +1,97 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func TestHostPackageTemplates(t *testing.T) {
+	config, diags := configs.Decode("test.hcl", []byte(`
+service "getty" {
+  template = true
+  unit {}
+  service {
+    exec_start = ["/sbin/agetty ${self.instance}"]
+  }
+  install {
+    default_instance = "tty1"
+    wanted_by        = [builtin.target.multi_user]
+  }
+}
+
+service "worker" {
+  template = true
+  unit {}
+  service {
+    exec_start = ["/usr/bin/worker ${self.instance}"]
+  }
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+service "web" {
+  unit {}
+  service {
+    exec_start = ["/usr/bin/web"]
+  }
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+host "web01" {
+  enable = ["getty@.service", "worker@.service", "web.service"]
+
+  package {
+    name    = "web01-units"
+    version = "1.0"
+  }
+}
+`))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	files, err := config.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := hostPackage(config.Hosts[0], files, config.RestartPolicies())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// getty@.service is started through its default instance; worker@.service
+	// has none, so there is nothing to start.
+	want := `#!/bin/sh
+# Generated by unitd.
+set -e
+
+if [ "$1" = configure ]; then
+	systemctl --no-reload enable 'getty@tty1.service' 'web.service'
+	if [ -d /run/systemd/system ]; then
+		systemctl daemon-reload
+		systemctl try-restart 'getty@tty1.service' 'web.service'
+		systemctl start 'getty@tty1.service' 'web.service'
+	fi
+fi
+`
+	if diff := cmp.Diff(want, pkg.Scripts["postinst"]); diff != "" {
+		t.Errorf("postinst (-want +got):\n%s", diff)
+	}
+
+	var paths []string
+	for _, f := range pkg.Files {
+		paths = append(paths, f.Path)
+	}
+	wantPaths := []string{
+		"/usr/lib/systemd/system/getty@.service",
+		"/usr/lib/systemd/system/web.service",
+		"/usr/lib/systemd/system/worker@.service",
+	}
+	if diff := cmp.Diff(wantPaths, paths); diff != "" {
+		t.Errorf("files (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+// PlanCommand shows what a build would change in an output directory.
+type PlanCommand struct {
+	Meta
+}
+
+func (c *PlanCommand) Synopsis() string {
+	return "Show the changes a build would make to an output directory"
+}
+
+func (c *PlanCommand) Run(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd plan <src.hcl> <outdir>\n")
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return 1
+	}
+	srcFile, outDir := rest[0], rest[1]
+
+	config, ok := c.loadConfig(srcFile)
+	if !ok {
+		return 1
+	}
+	files, err := config.Render()
+	if err != nil {
+		c.errorf("Failed to render units: %s", err)
+		return 1
+	}
+	trees, err := outputTrees(config, files)
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+
+	counts := make(map[output.Action]int)
+	for _, name := range sortedKeys(trees) {
+		dir := filepath.Join(outDir, name)
+		plan, err := output.NewPlan(dir, trees[name])
+		if err != nil {
+			c.errorf("%s", err)
+			return 1
+		}
+		c.warnKept(plan)
+		for _, ch := range plan.Changes {
+			counts[ch.Action]++
+			c.showChange(dir, ch)
+		}
+	}
+
+	if len(counts) == 0 {
+		fmt.Fprintf(c.Stdout, "No changes. %s is up to date.\n", outDir)
+		return 0
+	}
+	fmt.Fprintf(c.Stdout, "Plan: %d to create, %d to update, %d to delete.\n",
+		counts[output.Create], counts[output.Update], counts[output.Delete])
+	return 0
+}
+
+// showChange prints one planned change with the diff of the file.
+func (c *PlanCommand) showChange(dir string, ch output.Change) {
+	path := filepath.Join(dir, ch.Name)
+	if ch.Symlink {
+		target := ch.New
+		if ch.Action == output.Delete {
+			target = ch.Old
+		}
+		fmt.Fprintf(c.Stdout, "%s %s -> %s (%s)\n\n", planSymbols[ch.Action], path, target, ch.Source)
+		return
+	}
+
+	fmt.Fprintf(c.Stdout, "%s %s (%s)\n", planSymbols[ch.Action], path, ch.Source)
+	oldName, newName := diffName("old", path), diffName("new", path)
+	switch ch.Action {
+	case output.Create:
+		oldName = "/dev/null"
+	case output.Delete:
+		newName = "/dev/null"
+	}
+	fmt.Fprint(c.Stdout, unifiedDiff(oldName, newName, ch.Old, ch.New))
+	fmt.Fprintln(c.Stdout)
+}
+
+var planSymbols = map[output.Action]string{
+	output.Create: "+",
+	output.Update: "~",
+	output.Delete: "-",
+}
+
+// warnKept reports the files a plan leaves in place.
+func (m *Meta) warnKept(plan *output.Plan) {
+	for _, name := range plan.Kept {
+		fmt.Fprintf(m.Stderr, "Warning: %s is no longer rendered but was edited since unitd wrote it; leaving it in place.\n",
+			filepath.Join(plan.Dir, name))
+	}
+	for _, name := range plan.Foreign {
+		fmt.Fprintf(m.Stderr, "Warning: %s already has the rendered content but was not written by unitd; leaving it unmanaged.\n",
+			filepath.Join(plan.Dir, name))
+	}
+}
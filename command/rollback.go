@@ -0,0 +1,112 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/vanviethieuanh/unitd/generation"
+)
+
+// RollbackCommand deploys a previous generation of a host again.
+type RollbackCommand struct {
+	Meta
+}
+
+func (c *RollbackCommand) Synopsis() string {
+	return "Restore a previous generation of a host"
+}
+
+func (c *RollbackCommand) Run(args []string) int {
+	var root string
+	var to int
+	var out outputFlags
+	fs := flag.NewFlagSet("rollback", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.IntVar(&to, "to", 0, "restore generation `N` instead of the one before the live one")
+	fs.StringVar(&root, "root", "", "the hosts were deployed to <dir>/<host> instead of over SSH")
+	out.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd rollback [-to=N] [-root=dir] <src.hcl> <host>\n\n")
+		fmt.Fprintf(c.Stderr, "Deploys a generation recorded by unitd apply again, reloading and\n")
+		fmt.Fprintf(c.Stderr, "restarting units as apply does, and records it as a new generation.\n")
+		fmt.Fprintf(c.Stderr, "The configuration gives the ssh block and restart policies of the host.\n\n")
+		fs.PrintDefaults()
+	}
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return 1
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return 1
+	}
+	opts, err := out.options()
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+
+	config, ok := c.loadConfig(rest[0])
+	if !ok {
+		return 1
+	}
+	host, ok := lookupHost(config, rest[1])
+	if !ok {
+		c.errorf("No host block named %q.", rest[1])
+		return 1
+	}
+	t, m, err := hostTransport(host, root)
+	if err != nil {
+		c.errorf("%s: %s", host.Name, err)
+		return 1
+	}
+	defer t.Close()
+
+	gens, err := generation.List(t)
+	if err != nil {
+		c.errorf("%s: failed to read the generations: %s", host.Name, err)
+		return 1
+	}
+	target, err := rollbackTarget(gens, to)
+	if err != nil {
+		c.errorf("%s: %s", host.Name, err)
+		return 1
+	}
+
+	target, err = generation.Load(t, target.Number)
+	if err != nil {
+		c.errorf("%s: failed to read the generation: %s", host.Name, err)
+		return 1
+	}
+
+	fmt.Fprintf(c.Stdout, "%s: restoring generation %d\n", host.Name, target.Number)
+	gen := generation.Generation{Source: target.Source, Restores: target.Number}
+	if !c.deploy(t, m, host, target.Files, config.RestartPolicies(), opts, gen) {
+		return 1
+	}
+	return 0
+}
+
+// rollbackTarget returns generation to, or the latest generation older
+// than the live one when to is 0.
+func rollbackTarget(gens []generation.Generation, to int) (generation.Generation, error) {
+	if len(gens) == 0 {
+		return generation.Generation{}, fmt.Errorf("no generations recorded")
+	}
+	if to != 0 {
+		for _, g := range gens {
+			if g.Number == to {
+				return g, nil
+			}
+		}
+		return generation.Generation{}, fmt.Errorf("no generation %d", to)
+	}
+
+	live := gens[len(gens)-1].Live()
+	for i := len(gens) - 1; i >= 0; i-- {
+		if gens[i].Number < live && gens[i].Restores == 0 {
+			return gens[i], nil
+		}
+	}
+	return generation.Generation{}, fmt.Errorf("no generation before %d", live)
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/vanviethieuanh/unitd/schema"
+)
+
+// SchemaCommand prints a machine-readable schema of the configuration.
+type SchemaCommand struct {
+	Meta
+}
+
+func (c *SchemaCommand) Synopsis() string {
+	return "Print the configuration schema as JSON"
+}
+
+func (c *SchemaCommand) Run(args []string) int {
+	var format string
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.StringVar(&format, "format", "json-schema", "`format` of the schema: json-schema or hcl-schema")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd schema [-format=json-schema|hcl-schema]\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 1
+	}
+
+	var v any
+	switch format {
+	case "json-schema":
+		v = schema.JSONSchema()
+	case "hcl-schema":
+		v = schema.Config()
+	default:
+		c.errorf("Unknown schema format %q; expected json-schema or hcl-schema.", format)
+		return 1
+	}
+
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		c.errorf("%s", err)
+		return 1
+	}
+	fmt.Fprintf(c.Stdout, "%s\n", out)
+	return 0
+}
@@ -0,0 +1,155 @@
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// Exit codes of unitd validate, from the most to the least severe finding.
+const (
+	validateOK       = 0
+	validateFailed   = 1 // usage error or unreadable file
+	validateSyntax   = 2
+	validateSemantic = 3
+	validateWarnings = 4
+)
+
+// ValidateCommand checks configurations without rendering them.
+type ValidateCommand struct {
+	Meta
+}
+
+func (c *ValidateCommand) Synopsis() string {
+	return "Check configurations for errors without writing files"
+}
+
+// validateDiagnostic is the -json form of one diagnostic. Line and column
+// are 1-based and omitted when the diagnostic has no source location.
+type validateDiagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+type validateResult struct {
+	Valid        bool                 `json:"valid"`
+	ErrorCount   int                  `json:"error_count"`
+	WarningCount int                  `json:"warning_count"`
+	Diagnostics  []validateDiagnostic `json:"diagnostics"`
+}
+
+func (c *ValidateCommand) Run(args []string) int {
+	var jsonOut bool
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	fs.BoolVar(&jsonOut, "json", false, "print the diagnostics as JSON on stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(c.Stderr, "usage: unitd validate [-json] <src.hcl>...\n\n")
+		fmt.Fprintf(c.Stderr, "Exit status is 0 when valid, 2 on syntax errors, 3 on semantic errors\n")
+		fmt.Fprintf(c.Stderr, "and 4 when there are only warnings.\n\n")
+		fs.PrintDefaults()
+	}
+	paths, err := parseFlags(fs, args)
+	if err != nil {
+		return validateFailed
+	}
+	if len(paths) == 0 {
+		fs.Usage()
+		return validateFailed
+	}
+
+	code := validateOK
+	var all hcl.Diagnostics
+	files := make(map[string]*hcl.File)
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			c.errorf("Failed to read %s: %s", path, err)
+			return validateFailed
+		}
+		files[path] = &hcl.File{Bytes: src}
+
+		diags, fileCode := validateSource(path, src)
+		all = append(all, diags...)
+		if fileCode != validateOK && (code == validateOK || fileCode < code) {
+			code = fileCode
+		}
+	}
+
+	if jsonOut {
+		if err := c.writeValidateJSON(paths, all); err != nil {
+			c.errorf("%s", err)
+			return validateFailed
+		}
+		return code
+	}
+
+	c.showDiagnostics(all, files)
+	if code == validateOK || code == validateWarnings {
+		fmt.Fprintf(c.Stdout, "Success! The configuration is valid.\n")
+	}
+	return code
+}
+
+// validateSource runs the decode and validation pipeline on one file and
+// returns its diagnostics with the matching exit code.
+func validateSource(path string, src []byte) (hcl.Diagnostics, int) {
+	_, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags, validateSyntax
+	}
+
+	config, diags := configs.Decode(path, src)
+	if !diags.HasErrors() {
+		diags = append(diags, config.Validate()...)
+	}
+	switch {
+	case diags.HasErrors():
+		return diags, validateSemantic
+	case len(diags) > 0:
+		return diags, validateWarnings
+	}
+	return diags, validateOK
+}
+
+func (c *ValidateCommand) writeValidateJSON(paths []string, diags hcl.Diagnostics) error {
+	result := validateResult{Diagnostics: []validateDiagnostic{}}
+	for _, diag := range diags {
+		d := validateDiagnostic{
+			Severity: "error",
+			Summary:  diag.Summary,
+			Detail:   diag.Detail,
+		}
+		if diag.Severity == hcl.DiagWarning {
+			d.Severity = "warning"
+			result.WarningCount++
+		} else {
+			result.ErrorCount++
+		}
+		if diag.Subject != nil {
+			d.File = diag.Subject.Filename
+			d.Line = diag.Subject.Start.Line
+			d.Column = diag.Subject.Start.Column
+		} else if len(paths) == 1 {
+			d.File = paths[0]
+		}
+		result.Diagnostics = append(result.Diagnostics, d)
+	}
+	result.Valid = result.ErrorCount == 0
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Stdout, "%s\n", out)
+	return err
+}
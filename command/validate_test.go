@@ -0,0 +1,99 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateExitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		code int
+	}{
+		{"valid", "../example/src/services.hcl", validateOK},
+		{"syntax", `service "x" {`, validateSyntax},
+		{"semantic", `service "x" {
+  unit {}
+  service {
+    exec_start       = ["/bin/x"]
+    environment_file = ["relative"]
+  }
+  install {}
+}
+`, validateSemantic},
+		{"undefined host unit", `host "web01" {
+  enable = ["nginx.service"]
+}
+`, validateSemantic},
+		{"invalid restart policy", `service "x" {
+  restart_policy = "bounce"
+  unit {}
+  service {
+    exec_start = ["/bin/x"]
+  }
+  install {}
+}
+`, validateSemantic},
+		{"enabled and disabled", `service "nginx" {
+  unit {}
+  service {
+    exec_start = ["/usr/sbin/nginx"]
+  }
+  install {}
+}
+
+host "web01" {
+  enable  = ["nginx.service"]
+  disable = ["nginx.service"]
+}
+`, validateSemantic},
+		{"ssh without destination", `host "web01" {
+  ssh {
+    user = "deploy"
+  }
+}
+`, validateSemantic},
+		{"invalid package version", `host "web01" {
+  package {
+    name    = "web01-units"
+    version = "v1.0"
+  }
+}
+`, validateSemantic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.src
+			if _, err := os.Stat(path); err != nil {
+				path = filepath.Join(t.TempDir(), "config.hcl")
+				if err := os.WriteFile(path, []byte(tt.src), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var stdout, stderr bytes.Buffer
+			cmd := &ValidateCommand{Meta: Meta{Stdout: &stdout, Stderr: &stderr}}
+			if got := cmd.Run([]string{"-json", path}); got != tt.code {
+				t.Fatalf("exit code %d, want %d\nstdout: %s\nstderr: %s", got, tt.code, stdout.String(), stderr.String())
+			}
+
+			var result validateResult
+			if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+				t.Fatalf("invalid JSON: %s\n%s", err, stdout.String())
+			}
+			if result.Valid != (tt.code == validateOK) {
+				t.Errorf("valid = %v, want %v", result.Valid, tt.code == validateOK)
+			}
+			for _, d := range result.Diagnostics {
+				if d.File != path || d.Line == 0 || d.Column == 0 {
+					t.Errorf("diagnostic without location: %+v", d)
+				}
+			}
+		})
+	}
+}
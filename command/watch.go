@@ -0,0 +1,81 @@
+package command
+
+import (
+	"os"
+	"time"
+)
+
+// watcher reports changes to a set of files. Changes are coalesced: a
+// receive on Changes stands for one or more changes since the last one.
+type watcher interface {
+	Changes() <-chan struct{}
+	Close() error
+}
+
+// pollInterval is how often pollWatcher looks at the files.
+const pollInterval = 500 * time.Millisecond
+
+// pollWatcher detects changes by comparing the size and modification time
+// of the files. It is used where inotify is not available.
+type pollWatcher struct {
+	changes chan struct{}
+	done    chan struct{}
+}
+
+func newPollWatcher(paths []string, interval time.Duration) *pollWatcher {
+	w := &pollWatcher{
+		changes: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	type state struct {
+		size    int64
+		modTime time.Time
+		exists  bool
+	}
+	stat := func(path string) state {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return state{}
+		}
+		return state{fi.Size(), fi.ModTime(), true}
+	}
+
+	last := make(map[string]state, len(paths))
+	for _, p := range paths {
+		last[p] = stat(p)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+			}
+			for _, p := range paths {
+				if s := stat(p); s != last[p] {
+					last[p] = s
+					w.notify()
+				}
+			}
+		}
+	}()
+	return w
+}
+
+func (w *pollWatcher) notify() {
+	select {
+	case w.changes <- struct{}{}:
+	default:
+	}
+}
+
+func (w *pollWatcher) Changes() <-chan struct{} { return w.changes }
+
+func (w *pollWatcher) Close() error {
+	close(w.done)
+	return nil
+}
@@ -0,0 +1,97 @@
+//go:build linux
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// inotifyMask selects the events that may change a file's content. Editors
+// often save by writing a new file and renaming it over the old one, so
+// the parent directories are watched rather than the files.
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE | syscall.IN_DELETE
+
+// newWatcher uses inotify, falling back to polling when it is unavailable.
+func newWatcher(paths []string) (watcher, error) {
+	w, err := newInotifyWatcher(paths)
+	if err != nil {
+		return newPollWatcher(paths, pollInterval), nil
+	}
+	return w, nil
+}
+
+type inotifyWatcher struct {
+	file    *os.File
+	changes chan struct{}
+}
+
+func newInotifyWatcher(paths []string) (*inotifyWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	// A non-blocking descriptor goes through the runtime poller, so Close
+	// interrupts a pending Read.
+	file := os.NewFile(uintptr(fd), "inotify")
+
+	// watched maps a watch descriptor to the base names it covers.
+	watched := make(map[int32]map[string]bool)
+	dirs := make(map[string]int32)
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		dir := filepath.Dir(abs)
+		wd, ok := dirs[dir]
+		if !ok {
+			n, err := syscall.InotifyAddWatch(fd, dir, inotifyMask)
+			if err != nil {
+				file.Close()
+				return nil, os.NewSyscallError("inotify_add_watch", err)
+			}
+			wd = int32(n)
+			dirs[dir] = wd
+			watched[wd] = make(map[string]bool)
+		}
+		watched[wd][filepath.Base(abs)] = true
+	}
+
+	w := &inotifyWatcher{file: file, changes: make(chan struct{}, 1)}
+	go w.read(watched)
+	return w, nil
+}
+
+func (w *inotifyWatcher) read(watched map[int32]map[string]bool) {
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			nameStart := off + syscall.SizeofInotifyEvent
+			name := string(buf[nameStart : nameStart+int(ev.Len)])
+			for len(name) > 0 && name[len(name)-1] == 0 {
+				name = name[:len(name)-1]
+			}
+			off = nameStart + int(ev.Len)
+
+			if watched[ev.Wd][name] {
+				select {
+				case w.changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+func (w *inotifyWatcher) Changes() <-chan struct{} { return w.changes }
+
+func (w *inotifyWatcher) Close() error { return w.file.Close() }
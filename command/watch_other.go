@@ -0,0 +1,8 @@
+//go:build !linux
+
+package command
+
+// newWatcher polls paths: inotify is Linux only.
+func newWatcher(paths []string) (watcher, error) {
+	return newPollWatcher(paths, pollInterval), nil
+}
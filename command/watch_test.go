@@ -0,0 +1,57 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchers(t *testing.T) {
+	tests := map[string]func([]string) (watcher, error){
+		"default": newWatcher,
+		"poll": func(paths []string) (watcher, error) {
+			return newPollWatcher(paths, 10*time.Millisecond), nil
+		},
+	}
+
+	for name, newW := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "units.hcl")
+			if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			w, err := newW([]string{path})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+
+			// Changes to other files of the directory are ignored.
+			if err := os.WriteFile(filepath.Join(dir, "other"), []byte("x"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-w.Changes():
+				t.Fatal("change reported for an unwatched file")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			// Saving by rename, as editors do, is seen.
+			tmp := filepath.Join(dir, ".units.hcl.tmp")
+			if err := os.WriteFile(tmp, []byte("bb"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Rename(tmp, path); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-w.Changes():
+			case <-time.After(2 * time.Second):
+				t.Fatal("no change reported")
+			}
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package configs
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// unitCodec implements UnitCodec for the generated unit structs (Service,
+// Timer, Socket, …). Every `hcl:",block"` field maps to the unit file section
+// named after the field: Unit → [Unit], Service → [Service], Install → [Install].
+type unitCodec[T any] struct{}
+
+// NewUnitCodec returns the UnitCodec for a generated unit struct type.
+func NewUnitCodec[T any]() UnitCodec[T] {
+	return unitCodec[T]{}
+}
+
+func (unitCodec[T]) Encode(v T) (*SystemdUnit, error) {
+	rv := reflect.ValueOf(v)
+	rt := rv.Type()
+
+	b := NewSystemdUnitBuilder()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !isBlockField(field) {
+			continue
+		}
+		entries, err := EncodeSystemdSection(rv.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			b.AddEntries(field.Name, entries...)
+		}
+	}
+
+	name := ""
+	if f := rv.FieldByName("Name"); f.IsValid() {
+		name = f.String()
+	}
+	filename := name + "." + strings.ToLower(rt.Name())
+	if t := rv.FieldByName("Template"); t.IsValid() && t.Bool() {
+		filename = TemplateUnitName(name, "", strings.ToLower(rt.Name()))
+	}
+	return b.Build(filename), nil
+}
+
+func (unitCodec[T]) Decode(su *SystemdUnit) (T, error) {
+	var v T
+	diags := DecodeUnit(su, &v)
+	if diags.HasErrors() {
+		return v, diags
+	}
+	return v, nil
+}
+
+// DecodeUnit decodes su into the unit struct pointed to by v. The unit name
+// and template flag are derived from su.Filename. Unknown sections and
+// directives are reported with the range of the offending line; sections and
+// keys prefixed with "X-" are ignored, as systemd does.
+func DecodeUnit(su *SystemdUnit, v any) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+
+	if f := rv.FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String {
+		stem := strings.TrimSuffix(su.Filename, "."+strings.ToLower(rt.Name()))
+		if t := rv.FieldByName("Template"); strings.HasSuffix(stem, "@") && t.IsValid() {
+			stem = strings.TrimSuffix(stem, "@")
+			t.SetBool(true)
+		}
+		f.SetString(stem)
+	}
+
+	for _, section := range su.SectionNames() {
+		entries := su.Sections[section]
+		if strings.HasPrefix(section, "X-") {
+			continue
+		}
+
+		field, ok := rt.FieldByName(section)
+		if !ok || !isBlockField(field) {
+			var subject *hcl.Range
+			if len(entries) > 0 {
+				subject = entries[0].Range.Ptr()
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unknown section",
+				Detail:   fmt.Sprintf("[%s] is not a section of %s units.", section, strings.ToLower(rt.Name())),
+				Subject:  subject,
+			})
+			continue
+		}
+
+		diags = append(diags, DecodeSystemdSection(section, entries, rv.FieldByIndex(field.Index).Addr().Interface())...)
+	}
+
+	return diags
+}
+
+// DecodeSystemdSection applies the entries of one section to the block struct
+// pointed to by v, matching keys against the `systemd:` struct tags.
+//
+// List directives accumulate, and an empty assignment resets them. A scalar
+// directive assigned more than once in the same file is an error, since
+// only the last value would be kept; a drop-in overriding the value of the
+// unit file is not. Unit reference lists (`unitd:"ref=unit"`) are split into
+// words like systemd does.
+func DecodeSystemdSection(section string, entries []Entry, v any) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+
+	fields := make(map[string]int, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		if key := rt.Field(i).Tag.Get("systemd"); key != "" && key != "-" {
+			fields[key] = i
+		}
+	}
+
+	assigned := make(map[string]hcl.Range)
+	for _, e := range entries {
+		idx, ok := fields[e.Key]
+		if !ok {
+			if strings.HasPrefix(e.Key, "X-") {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unknown directive",
+				Detail:   fmt.Sprintf("%s= is not a known directive of the [%s] section.", e.Key, section),
+				Subject:  e.Range.Ptr(),
+			})
+			continue
+		}
+
+		field := rt.Field(idx)
+		if field.Tag.Get("hcl") == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unsupported directive",
+				Detail:   fmt.Sprintf("%s= cannot be expressed in unitd yet and was ignored.", e.Key),
+				Subject:  e.Range.Ptr(),
+			})
+			continue
+		}
+
+		switch rv.Field(idx).Kind() {
+		case reflect.Slice, reflect.Map:
+		default:
+			if prev, ok := assigned[e.Key]; ok && prev.Filename == e.Range.Filename {
+				// systemd accepts the repeat and keeps the last value.
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Duplicate directive",
+					Detail:   fmt.Sprintf("%s= is already set on line %d and takes a single value; the last one is kept, as systemd does.", e.Key, prev.Start.Line),
+					Subject:  e.Range.Ptr(),
+				})
+			}
+			assigned[e.Key] = e.Range
+		}
+
+		if err := decodeEntry(field, rv.Field(idx), e); err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value",
+				Detail:   fmt.Sprintf("%s=%s: %s.", e.Key, e.Value, err),
+				Subject:  e.Range.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+func decodeEntry(field reflect.StructField, value reflect.Value, e Entry) error {
+	raw := e.Value
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(UnescapeValue(e.Key, raw))
+
+	case reflect.Bool:
+		b, err := parseBoolean(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+
+	case reflect.Pointer:
+		if raw == "" {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		elem := reflect.New(value.Type().Elem())
+		if err := parseScalar(e.Key, raw, elem.Elem()); err != nil {
+			return err
+		}
+		value.Set(elem)
+
+	case reflect.Slice:
+		if raw == "" {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		items := []string{UnescapeValue(e.Key, raw)}
+		if strings.Contains(field.Tag.Get("unitd"), "ref=unit") {
+			words, err := SplitWords(raw, false)
+			if err != nil {
+				return err
+			}
+			items = words
+		}
+		for _, item := range items {
+			value.Set(reflect.Append(value, reflect.ValueOf(item).Convert(value.Type().Elem())))
+		}
+
+	case reflect.Map:
+		if raw == "" {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		words, err := SplitWords(raw, true)
+		if err != nil {
+			return err
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		for _, w := range words {
+			name, val, ok := strings.Cut(w, "=")
+			if !ok || !ValidEnvName(name) {
+				return fmt.Errorf("invalid assignment %q", w)
+			}
+			value.SetMapIndex(reflect.ValueOf(name), reflect.ValueOf(UnescapeValue(e.Key, val)))
+		}
+
+	default:
+		return fmt.Errorf("unsupported field kind %s", value.Kind())
+	}
+
+	return nil
+}
+
+func parseScalar(key, raw string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := parseBoolean(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if strings.HasSuffix(key, "Sec") {
+			n, err := parseTimespanSeconds(raw)
+			if err != nil {
+				return err
+			}
+			v.SetInt(n)
+			return nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("not an unsigned integer")
+		}
+		v.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
+
+// parseBoolean accepts the spellings of systemd's parse_boolean().
+func parseBoolean(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "1", "yes", "y", "true", "t", "on":
+		return true, nil
+	case "0", "no", "n", "false", "f", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("not a boolean")
+}
+
+var timespanUnits = map[string]float64{
+	"us": 1e-6, "usec": 1e-6, "µs": 1e-6,
+	"ms": 1e-3, "msec": 1e-3,
+	"": 1, "s": 1, "sec": 1, "second": 1, "seconds": 1,
+	"m": 60, "min": 60, "minute": 60, "minutes": 60,
+	"h": 3600, "hr": 3600, "hour": 3600, "hours": 3600,
+	"d": 86400, "day": 86400, "days": 86400,
+	"w": 604800, "week": 604800, "weeks": 604800,
+	"M": 2629800, "month": 2629800, "months": 2629800,
+	"y": 31557600, "year": 31557600, "years": 31557600,
+}
+
+// TimespanInfinity is the value of a time span directive set to "infinity",
+// which systemd accepts for every time span. It is written back as
+// "infinity", and bound to the HCL `infinity` variable.
+const TimespanInfinity = math.MaxInt64
+
+// parseTimespanSeconds parses a systemd time span ("90", "1min 30s", "2h",
+// "infinity") into whole seconds. Spans with a sub-second remainder are
+// rejected because unitd stores these directives as seconds.
+func parseTimespanSeconds(s string) (int64, error) {
+	var total float64
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return 0, fmt.Errorf("empty time span")
+	}
+	if rest == "infinity" {
+		return TimespanInfinity, nil
+	}
+
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid time span")
+		}
+		n, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time span")
+		}
+		rest = strings.TrimLeft(rest[i:], " ")
+
+		j := 0
+		for j < len(rest) && !(rest[j] >= '0' && rest[j] <= '9') && rest[j] != ' ' {
+			j++
+		}
+		unit, ok := timespanUnits[rest[:j]]
+		if !ok {
+			return 0, fmt.Errorf("unknown time unit %q", rest[:j])
+		}
+		total += n * unit
+		rest = strings.TrimLeft(rest[j:], " ")
+	}
+
+	if total != float64(int64(total)) {
+		return 0, fmt.Errorf("time span is not a whole number of seconds")
+	}
+	return int64(total), nil
+}
+
+func isBlockField(f reflect.StructField) bool {
+	return strings.HasSuffix(f.Tag.Get("hcl"), ",block")
+}
+
+// NormalizeUnit returns su as unitd writes it: decoded into the struct of
+// its unit type, named by the extension of su.Filename, and encoded again.
+// Two files that systemd reads the same way, whatever their layout,
+// comments or the way lists are split across lines, normalize to the same
+// entries.
+func NormalizeUnit(su *SystemdUnit) (*SystemdUnit, hcl.Diagnostics) {
+	switch path.Ext(su.Filename) {
+	case ".service":
+		return normalizeUnit[Service](su)
+	case ".socket":
+		return normalizeUnit[Socket](su)
+	case ".timer":
+		return normalizeUnit[Timer](su)
+	case ".path":
+		return normalizeUnit[Path](su)
+	case ".mount":
+		return normalizeUnit[Mount](su)
+	case ".automount":
+		return normalizeUnit[Automount](su)
+	case ".swap":
+		return normalizeUnit[Swap](su)
+	case ".target":
+		return normalizeUnit[Target](su)
+	case ".slice":
+		return normalizeUnit[Slice](su)
+	case ".scope":
+		return normalizeUnit[Scope](su)
+	case ".device":
+		return normalizeUnit[Device](su)
+	}
+	return nil, hcl.Diagnostics{{
+		Severity: hcl.DiagError,
+		Summary:  "Unknown unit type",
+		Detail:   fmt.Sprintf("%s is not a unit file name.", su.Filename),
+	}}
+}
+
+func normalizeUnit[T any](su *SystemdUnit) (*SystemdUnit, hcl.Diagnostics) {
+	var v T
+	if diags := DecodeUnit(su, &v); diags.HasErrors() {
+		return nil, diags
+	}
+	out, err := NewUnitCodec[T]().Encode(v)
+	if err != nil {
+		return nil, hcl.Diagnostics{{Severity: hcl.DiagError, Summary: "Failed to encode unit", Detail: err.Error()}}
+	}
+	out.Filename = su.Filename
+	return out, nil
+}
@@ -1,47 +1,202 @@
 // Package configs define all the blocks and syntax for this DSL.
 package configs
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+)
 
 type Config struct {
 	Services  []Service  `hcl:"service,block"`
 	Instances []Instance `hcl:"instance,block"`
+	Hosts     []Host     `hcl:"host,block"`
+
+	// Ranges maps block addresses ("service.web", "instance.workers") to
+	// the range of their header. It is set by Decode and may be nil.
+	Ranges map[string]hcl.Range
 }
 
-func (c *Config) Validate() error {
+// Validate checks the rules that span blocks or that decoding does not
+// enforce. Every problem found is reported.
+func (c *Config) Validate() hcl.Diagnostics {
 	type svcKey struct {
 		name    string
 		variant string
 	}
 	seen := make(map[svcKey]struct{})
 
+	var diags hcl.Diagnostics
 	for _, svc := range c.Services {
 		variant := ""
 		for k := range svc.ForEach {
 			variant = k
 			break // expanded services have exactly one entry
 		}
+		subject := c.subject("service." + svc.Name)
+
 		key := svcKey{svc.Name, variant}
 		if _, ok := seen[key]; ok {
+			detail := fmt.Sprintf("Service %q is defined more than once.", svc.Name)
 			if variant != "" {
-				return fmt.Errorf("duplicate service %q variant %q", svc.Name, variant)
+				detail = fmt.Sprintf("Variant %q of service %q is defined more than once.", variant, svc.Name)
 			}
-			return fmt.Errorf(
-				"duplicate service %q defined more than once",
-				svc.Name,
-			)
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate service",
+				Detail:   detail,
+				Subject:  subject,
+			})
+			continue
 		}
 		seen[key] = struct{}{}
+
+		if err := ValidateSectionEnvironment(svc.Service); err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid environment",
+				Detail:   fmt.Sprintf("Service %q: %s.", svc.Name, err),
+				Subject:  subject,
+			})
+		}
+		switch svc.RestartPolicy {
+		case "", RestartPolicyRestart, RestartPolicyReload, RestartPolicyNone:
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid restart policy",
+				Detail:   fmt.Sprintf("Service %q: restart_policy %q is not one of restart, reload or none.", svc.Name, svc.RestartPolicy),
+				Subject:  subject,
+			})
+		}
 	}
 
 	for _, inst := range c.Instances {
+		subject := c.subject("instance." + inst.Name)
 		if inst.Template == "" {
-			return fmt.Errorf("instance %q is missing template", inst.Name)
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing template",
+				Detail:   fmt.Sprintf("Instance %q has no template.", inst.Name),
+				Subject:  subject,
+			})
 		}
 		if len(inst.Instances) == 0 {
-			return fmt.Errorf("instance %q has no instances", inst.Name)
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "No instances",
+				Detail:   fmt.Sprintf("Instance %q has no instances.", inst.Name),
+				Subject:  subject,
+			})
 		}
 	}
 
-	return nil
+	units := c.unitNames()
+	hosts := make(map[string]bool, len(c.Hosts))
+	for _, host := range c.Hosts {
+		subject := c.subject("host." + host.Name)
+		if hosts[host.Name] {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate host",
+				Detail:   fmt.Sprintf("Host %q is defined more than once.", host.Name),
+				Subject:  subject,
+			})
+			continue
+		}
+		hosts[host.Name] = true
+
+		if ssh := host.SSH; ssh != nil {
+			if ssh.Name == "" && ssh.Host == "" {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing SSH destination",
+					Detail:   fmt.Sprintf("The ssh block of host %q needs a host address or the name of an ~/.ssh/config entry.", host.Name),
+					Subject:  subject,
+				})
+			}
+			if ssh.Port < 0 || ssh.Port > 65535 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid SSH port",
+					Detail:   fmt.Sprintf("Port %d of host %q is not between 1 and 65535.", ssh.Port, host.Name),
+					Subject:  subject,
+				})
+			}
+		}
+
+		if pkg := host.Package; pkg != nil {
+			if !debNamePattern.MatchString(pkg.Name) {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid package name",
+					Detail:   fmt.Sprintf("Package name %q of host %q must be lowercase letters, digits and + - . characters, starting with a letter or digit.", pkg.Name, host.Name),
+					Subject:  subject,
+				})
+			}
+			if !debVersionPattern.MatchString(pkg.Version) {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid package version",
+					Detail:   fmt.Sprintf("Package version %q of host %q must start with a digit and contain no spaces.", pkg.Version, host.Name),
+					Subject:  subject,
+				})
+			}
+		}
+
+		for _, name := range host.Enable {
+			for _, other := range host.Disable {
+				if name == other {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Conflicting host units",
+						Detail:   fmt.Sprintf("Host %q both enables and disables %s.", host.Name, name),
+						Subject:  subject,
+					})
+				}
+			}
+		}
+
+		for _, name := range host.Units() {
+			if tmpl, _, ok := SplitInstanceName(name); ok && units[tmpl] {
+				continue
+			}
+			if !units[name] {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Undefined unit",
+					Detail:   fmt.Sprintf("Host %q references %s, which no block of the configuration defines.", host.Name, name),
+					Subject:  subject,
+				})
+			}
+		}
+	}
+
+	return uniqueDiagnostics(diags)
+}
+
+// unitNames returns the units the configuration defines: rendered unit
+// files and the instances of instance blocks.
+func (c *Config) unitNames() map[string]bool {
+	names := make(map[string]bool)
+	for _, svc := range c.Services {
+		for _, name := range svc.UnitFilenames() {
+			names[name] = true
+		}
+	}
+	for _, inst := range c.Instances {
+		for _, id := range inst.Instances {
+			names[InstanceUnitName(inst.Template, id)] = true
+		}
+	}
+	return names
+}
+
+// subject returns the declaration range of the block at addr, if known.
+func (c *Config) subject(addr string) *hcl.Range {
+	rng, ok := c.Ranges[addr]
+	if !ok {
+		return nil
+	}
+	return &rng
 }
@@ -0,0 +1,86 @@
+package configs
+
+import "strings"
+
+// Directive describes a unit file directive as it is written in HCL. The
+// registry of every directive, Directives, is generated from the systemd
+// sources together with the block structs.
+type Directive struct {
+	Name      string   // HCL attribute: exec_start
+	Systemd   string   // unit file key: ExecStart
+	Section   string   // HCL block of the section: service
+	UnitTypes []string // unit types the section belongs to
+	Type      string   // HCL type: string, bool, number, list(string), map(string)
+	Values    []string // values accepted by enum directives
+	Ref       string   // kind of name the value holds ("unit"), if any
+	Verbatim  bool     // systemd reads "%" literally instead of expanding specifiers
+	ManPage   string   // systemd.service(5)
+	Doc       string
+}
+
+// Section describes a unit file section and the HCL block it is written as.
+type Section struct {
+	Name      string // HCL block: service
+	Systemd   string // [Service]
+	UnitTypes []string
+	ManPage   string
+	Doc       string
+}
+
+// LookupSection returns the section written as the HCL block name.
+func LookupSection(name string) (Section, bool) {
+	for _, s := range Sections {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// UnitSections returns the sections of a unit type in the order they are
+// written: unit, the type specific section if any, install.
+func UnitSections(unitType string) []Section {
+	var out []Section
+	for _, name := range []string{"unit", unitType, "install"} {
+		if s, ok := LookupSection(name); ok && containsString(s.UnitTypes, unitType) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// LookupDirective returns the directive written as attribute name in the
+// block of section.
+func LookupDirective(section, name string) (Directive, bool) {
+	for _, d := range Directives {
+		if d.Section == section && d.Name == name {
+			return d, true
+		}
+	}
+	return Directive{}, false
+}
+
+// SectionDirectives returns the directives of a section in generated
+// struct field order.
+func SectionDirectives(section string) []Directive {
+	var out []Directive
+	for _, d := range Directives {
+		if d.Section == section {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// FindDirectives returns the directives named query in any section, matching
+// either the HCL name (exec_start) or the systemd name (ExecStart, ExecStart=).
+func FindDirectives(query string) []Directive {
+	query = strings.TrimSuffix(query, "=")
+	var out []Directive
+	for _, d := range Directives {
+		if d.Name == query || strings.EqualFold(d.Systemd, query) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package configs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRegistryMatchesStructs checks that the generated registry and block
+// structs describe the same directives, in the same order.
+func TestRegistryMatchesStructs(t *testing.T) {
+	units := []any{Automount{}, Device{}, Mount{}, Path{}, Scope{}, Service{}, Slice{}, Socket{}, Swap{}, Target{}, Timer{}}
+
+	blocks := make(map[string]reflect.Type)
+	for _, u := range units {
+		ut := reflect.TypeOf(u)
+		for i := 0; i < ut.NumField(); i++ {
+			name, kind, _ := strings.Cut(ut.Field(i).Tag.Get("hcl"), ",")
+			if kind == "block" {
+				blocks[name] = ut.Field(i).Type
+			}
+		}
+	}
+
+	if len(Sections) != len(blocks) {
+		t.Errorf("registry has %d sections, structs have %d", len(Sections), len(blocks))
+	}
+	for _, s := range Sections {
+		bt, ok := blocks[s.Name]
+		if !ok {
+			t.Errorf("section %s has no block struct", s.Name)
+			continue
+		}
+
+		var want []string
+		for i := 0; i < bt.NumField(); i++ {
+			f := bt.Field(i)
+			if name, _, _ := strings.Cut(f.Tag.Get("hcl"), ","); name != "" {
+				want = append(want, name+"="+f.Tag.Get("systemd"))
+			}
+		}
+		var got []string
+		for _, d := range SectionDirectives(s.Name) {
+			got = append(got, d.Name+"="+d.Systemd)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("section %s: registry and %s differ", s.Name, bt.Name())
+		}
+	}
+}
+
+func TestFindDirectives(t *testing.T) {
+	for _, query := range []string{"exec_start", "ExecStart", "ExecStart="} {
+		found := FindDirectives(query)
+		if len(found) != 1 || found[0].Section != "service" || found[0].ManPage != "systemd.service(5)" {
+			t.Errorf("%s: got %+v", query, found)
+		}
+	}
+
+	d, ok := LookupDirective("service", "restart")
+	if !ok || !containsString(d.Values, "on-failure") {
+		t.Errorf("restart: got %+v", d)
+	}
+
+	var names []string
+	for _, s := range UnitSections("service") {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, ",") != "unit,service,install" {
+		t.Errorf("service sections: got %v", names)
+	}
+}
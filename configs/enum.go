@@ -0,0 +1,130 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Enum is implemented by the generated directive types that only accept a
+// fixed set of values (see enums.go).
+type Enum interface {
+	Values() []string
+}
+
+var enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// CheckEnums validates every enum-typed attribute of a decoded block against
+// its allowed values. body is the HCL body v was decoded from; it is used to
+// attach source ranges to the diagnostics.
+func CheckEnums(body hcl.Body, v any) hcl.Diagnostics {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return checkEnums(body, rv)
+}
+
+func checkEnums(body hcl.Body, rv reflect.Value) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, kind, _ := strings.Cut(field.Tag.Get("hcl"), ",")
+		if name == "" {
+			continue
+		}
+		value := rv.Field(i)
+
+		if kind == "block" {
+			if value.Kind() != reflect.Struct {
+				continue
+			}
+			content, _, _ := body.PartialContent(&hcl.BodySchema{
+				Blocks: []hcl.BlockHeaderSchema{{Type: name}},
+			})
+			if len(content.Blocks) > 0 {
+				diags = append(diags, checkEnums(content.Blocks[0].Body, value)...)
+			}
+			continue
+		}
+
+		var values []reflect.Value
+		switch {
+		case field.Type.Implements(enumType):
+			values = []reflect.Value{value}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Implements(enumType):
+			for j := 0; j < value.Len(); j++ {
+				values = append(values, value.Index(j))
+			}
+		default:
+			continue
+		}
+
+		for _, ev := range values {
+			given := ev.String()
+			if given == "" {
+				continue
+			}
+			allowed := ev.Interface().(Enum).Values()
+			if containsString(allowed, given) {
+				continue
+			}
+			diags = append(diags, enumDiagnostic(body, name, field.Tag.Get("systemd"), given, allowed))
+		}
+	}
+
+	return diags
+}
+
+func enumDiagnostic(body hcl.Body, attrName, directive, given string, allowed []string) *hcl.Diagnostic {
+	detail := fmt.Sprintf("%q is not a valid value for %s=.", given, directive)
+	if suggestion := NameSuggestion(given, allowed); suggestion != "" {
+		detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+	} else {
+		detail += " Expected one of: " + strings.Join(allowed, ", ") + "."
+	}
+
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("Invalid value for %s", attrName),
+		Detail:   detail,
+	}
+
+	content, _, _ := body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: attrName}},
+	})
+	if attr, ok := content.Attributes[attrName]; ok {
+		diag.Subject = attr.Expr.Range().Ptr()
+	}
+
+	return diag
+}
+
+// NameSuggestion returns the candidate closest to given, or "" when none is
+// close enough to be a plausible typo. Ties go to the earlier candidate.
+func NameSuggestion(given string, candidates []string) string {
+	best, bestDist := "", 3
+	for _, c := range candidates {
+		if d := levenshtein.Distance(given, c, nil); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package configs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckEnums(t *testing.T) {
+	tests := []struct {
+		restart string
+		detail  string // part of the diagnostic detail, "" for none
+	}{
+		{`"on-failure"`, ""},
+		{`"on-failur"`, `Did you mean "on-failure"?`},
+		{`"sometimes"`, "Expected one of: no, on-success, on-failure"},
+	}
+	for _, tt := range tests {
+		_, diags := Decode("test.hcl", []byte(`
+service "web" {
+  unit {}
+  service {
+    exec_start = ["/usr/bin/web"]
+    restart    = `+tt.restart+`
+  }
+  install {}
+}
+`))
+		if tt.detail == "" {
+			if diags.HasErrors() {
+				t.Errorf("restart = %s: %s", tt.restart, diags.Error())
+			}
+			continue
+		}
+		if len(diags) != 1 || !strings.Contains(diags[0].Detail, tt.detail) {
+			t.Errorf("restart = %s: got %v, want one diagnostic containing %q", tt.restart, diags, tt.detail)
+			continue
+		}
+		if r := diags[0].Subject; r == nil || r.Start.Line != 6 {
+			t.Errorf("restart = %s: subject %v, want line 6", tt.restart, r)
+		}
+	}
+}
+
+func TestCheckEnumsAfterDecodeError(t *testing.T) {
+	_, diags := Decode("test.hcl", []byte(`
+service "web" {
+  unit {}
+  service {
+    exec_start = ["/usr/bin/web"]
+    restart    = "sometimes"
+    user       = ["nobody"]
+  }
+  install {}
+}
+`))
+	var found bool
+	for _, d := range diags {
+		found = found || d.Summary == "Invalid value for restart"
+	}
+	if !found {
+		t.Errorf("restart is not checked when another attribute fails to decode: %s", diags.Error())
+	}
+}
+
+func TestNameSuggestion(t *testing.T) {
+	tests := []struct {
+		given      string
+		candidates []string
+		want       string
+	}{
+		{"one", []string{"no", "on"}, "on"}, // "no" is also within reach
+		{"alway", []string{"no", "always"}, "always"},
+		{"sometimes", []string{"no", "always"}, ""},
+	}
+	for _, tt := range tests {
+		if got := NameSuggestion(tt.given, tt.candidates); got != tt.want {
+			t.Errorf("NameSuggestion(%q, %q) = %q, want %q", tt.given, tt.candidates, got, tt.want)
+		}
+	}
+}
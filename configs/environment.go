@@ -0,0 +1,126 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// validEnvNameChars mirrors VALID_BASH_ENV_NAME_CHARS from systemd's env-util.
+const validEnvNameChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_"
+
+// ValidEnvName reports whether name is accepted by systemd as an
+// environment variable name: non-empty, [A-Za-z0-9_], not starting with a digit.
+func ValidEnvName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !strings.ContainsRune(validEnvNameChars, rune(name[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateEnvironment checks the variable names of an Environment= map.
+func ValidateEnvironment(env map[string]string) error {
+	for _, name := range sortedKeys(env) {
+		if !ValidEnvName(name) {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+	}
+	return nil
+}
+
+// ValidateEnvironmentFiles checks EnvironmentFile= entries. Each entry must be
+// an absolute path, optionally prefixed with "-" to ignore a missing file. A
+// path may also start with a specifier such as ${self.home}.
+func ValidateEnvironmentFiles(files []string) error {
+	for _, f := range files {
+		path := strings.TrimPrefix(f, "-")
+		if IsReset(f) || strings.HasPrefix(path, "/") || strings.HasPrefix(path, string(specifierMark)) {
+			continue
+		}
+		return fmt.Errorf("environment file %q is not an absolute path", f)
+	}
+	return nil
+}
+
+// ValidateSectionEnvironment checks the Environment= and EnvironmentFile=
+// directives of a section block. Every section that starts processes
+// (service, socket, mount, swap) accepts them.
+func ValidateSectionEnvironment(block any) error {
+	rv := reflect.Indirect(reflect.ValueOf(block))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		var err error
+		switch v := rv.Field(i).Interface().(type) {
+		case map[string]string:
+			if rt.Field(i).Tag.Get("systemd") == "Environment" {
+				err = ValidateEnvironment(v)
+			}
+		case []string:
+			if rt.Field(i).Tag.Get("systemd") == "EnvironmentFile" {
+				err = ValidateEnvironmentFiles(v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QuoteEnvAssignment renders one KEY=VALUE assignment for Environment=.
+//
+// systemd splits the value of Environment= into words, unquotes them, resolves
+// C escapes and then expands specifiers. The assignment is therefore wrapped
+// in double quotes whenever the value contains whitespace, quotes, backslashes
+// or control characters, and "%" is doubled unless it comes from self.*.
+//
+//	("PORT", "8080")      →  PORT=8080
+//	("GREETING", "a b")   →  "GREETING=a b"
+//	("RATIO", "50%")      →  RATIO=50%%
+func QuoteEnvAssignment(name, value string) string {
+	assignment := name + "=" + escapeSpecifiers(value)
+	if !strings.ContainsFunc(assignment, needsEnvQuoting) {
+		return assignment
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range assignment {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\x%02x`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func needsEnvQuoting(r rune) bool {
+	switch r {
+	case ' ', '"', '\'', '\\':
+		return true
+	}
+	return r < 0x20 || r == 0x7f
+}
@@ -0,0 +1,97 @@
+package configs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidEnvName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"PORT", true},
+		{"_private", true},
+		{"HTTP_PROXY2", true},
+		{"", false},
+		{"2FA", false},
+		{"HTTP-PROXY", false},
+		{"MY VAR", false},
+		{"A=B", false},
+		{"$HOME", false},
+		{"CAFÉ", false},
+	}
+	for _, tt := range tests {
+		if got := ValidEnvName(tt.name); got != tt.want {
+			t.Errorf("ValidEnvName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteEnvAssignment(t *testing.T) {
+	tests := []struct {
+		name, value string
+		want        string
+	}{
+		{"PORT", "8080", `PORT=8080`},
+		{"EMPTY", "", `EMPTY=`},
+		{"GREETING", "hello world", `"GREETING=hello world"`},
+		{"QUOTE", `say "hi"`, `"QUOTE=say \"hi\""`},
+		{"APOSTROPHE", "it's", `"APOSTROPHE=it's"`},
+		{"WINDOWS", `C:\temp`, `"WINDOWS=C:\\temp"`},
+		{"PRICE", "$5", `PRICE=$5`},
+		{"PATH", "$PATH:/opt/bin", `PATH=$PATH:/opt/bin`},
+		{"RATIO", "50%", `RATIO=50%%`},
+		{"UNIT", "web-" + specifier('i'), `UNIT=web-%i`},
+		{"LINES", "a\nb\tc", `"LINES=a\nb\tc"`},
+		{"BELL", "\a", `"BELL=\x07"`},
+	}
+	for _, tt := range tests {
+		if got := QuoteEnvAssignment(tt.name, tt.value); got != tt.want {
+			t.Errorf("QuoteEnvAssignment(%q, %q) = %s, want %s", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestQuoteEnvAssignmentRoundTrip checks that systemd's word splitting reads
+// the assignment back.
+func TestQuoteEnvAssignmentRoundTrip(t *testing.T) {
+	for _, value := range []string{"hello world", `say "hi"`, "it's", `C:\temp`, "$5", "a\nb\tc"} {
+		words, err := SplitWords(QuoteEnvAssignment("V", value), true)
+		if err != nil || len(words) != 1 || words[0] != "V="+value {
+			t.Errorf("%q reads back as %q, %v", value, words, err)
+		}
+	}
+}
+
+func TestValidateSectionEnvironment(t *testing.T) {
+	tests := []struct {
+		name  string
+		block any
+		err   string // part of the error, "" for none
+	}{
+		{"service", ServiceBlock{Environment: map[string]string{"PORT": "8080"}}, ""},
+		{"socket", SocketBlock{Environment: map[string]string{"BAD-NAME": "x"}}, `"BAD-NAME"`},
+		{"mount", &MountBlock{Environment: map[string]string{"": "x"}}, "invalid environment variable name"},
+		{"swap", SwapBlock{EnvironmentFile: []string{"-/etc/default/swap", specifier('h') + "/env", resetValue}}, ""},
+		{"swap relative", SwapBlock{EnvironmentFile: []string{"-etc/default/swap"}}, "not an absolute path"},
+		{"unit", UnitBlock{}, ""},
+	}
+	for _, tt := range tests {
+		err := ValidateSectionEnvironment(tt.block)
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.err)
+		}
+	}
+
+	// Blocks other than service are checked when they are written.
+	if _, err := EncodeSystemdSection(SocketBlock{Environment: map[string]string{"1X": "y"}}); err == nil {
+		t.Error("EncodeSystemdSection accepted an invalid variable name in a socket block")
+	}
+}
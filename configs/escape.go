@@ -0,0 +1,268 @@
+package configs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// specifierMark prefixes a specifier produced by a self.* reference. The
+// escaping layer turns mark+c into %c, while every literal "%" coming from
+// user text is doubled so systemd does not treat it as a specifier.
+const specifierMark = '\uE001'
+
+// specifier returns the marked form of the systemd specifier %c.
+func specifier(c rune) string {
+	return string([]rune{specifierMark, c})
+}
+
+// verbatimDirectives are parsed by systemd without specifier expansion, so a
+// literal "%" (e.g. CPUQuota=20%, MemoryMax=50%) must not be doubled. The
+// generator marks them in the registry from their parser.
+var verbatimDirectives = func() map[string]bool {
+	verbatim := make(map[string]bool)
+	for _, d := range Directives {
+		if d.Verbatim {
+			verbatim[d.Systemd] = true
+		}
+	}
+	return verbatim
+}()
+
+// supportsSpecifiers reports whether systemd expands %-specifiers in directive.
+func supportsSpecifiers(directive string) bool {
+	return !verbatimDirectives[directive]
+}
+
+// isCommandLine reports whether directive holds a command line (Exec*=).
+// Command lines are split into words with C-style unescaping, so control
+// characters can be written as escapes and newlines become line continuations.
+func isCommandLine(directive string) bool {
+	return strings.HasPrefix(directive, "Exec")
+}
+
+// EscapeValue converts a decoded HCL string into the text written after
+// "Directive=" in a unit file.
+//
+//   - "%" becomes "%%" in directives that expand specifiers; self.* references
+//     become the real specifier (%i, %I).
+//   - In command lines, trailing newlines are dropped, an inner newline becomes
+//     a line continuation ("\" + newline, read back by systemd as a space) and
+//     other control characters become C escapes. Elsewhere, newlines and
+//     control characters are rejected.
+//   - A trailing backslash is rejected, since systemd would read it as a line
+//     continuation.
+//   - `reset` is rejected inside a larger string: it only stands for an empty
+//     assignment on its own.
+func EscapeValue(directive, value string) (string, error) {
+	specifiers := supportsSpecifiers(directive)
+	command := isCommandLine(directive)
+	if command {
+		// Heredocs end in a newline; a continuation there would swallow
+		// the next line of the unit file.
+		value = strings.TrimRight(value, "\n")
+	}
+
+	var b strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == specifierMark:
+			if i+1 >= len(runes) {
+				return "", fmt.Errorf("dangling specifier marker")
+			}
+			if !specifiers {
+				return "", fmt.Errorf("%s= does not expand specifiers; self references cannot be used here", directive)
+			}
+			i++
+			b.WriteByte('%')
+			b.WriteRune(runes[i])
+		case r == resetMark:
+			return "", fmt.Errorf("%s= value contains reset, which can only be used on its own", directive)
+		case r == '%' && specifiers:
+			b.WriteString("%%")
+		case r == '\n' && command:
+			b.WriteString("\\\n")
+		case r == '\t':
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			if !command {
+				return "", fmt.Errorf("%s= cannot contain control character %q", directive, r)
+			}
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	out := b.String()
+	if strings.HasSuffix(out, `\`) {
+		return "", fmt.Errorf("%s= value ends with a backslash, which systemd reads as a line continuation", directive)
+	}
+	return out, nil
+}
+
+// escapeSpecifiers doubles literal "%" and resolves self.* markers, for values
+// that are otherwise quoted by their own rules (see QuoteEnvAssignment).
+func escapeSpecifiers(value string) string {
+	var b strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == specifierMark && i+1 < len(runes):
+			i++
+			b.WriteByte('%')
+			b.WriteRune(runes[i])
+		case runes[i] == '%':
+			b.WriteString("%%")
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+	return b.String()
+}
+
+// UnescapeValue is the inverse of EscapeValue for values read from a unit
+// file: "%%" becomes "%" and any other specifier %c becomes its marked form,
+// so that encoding the decoded value reproduces the original text.
+func UnescapeValue(directive, value string) string {
+	if !supportsSpecifiers(directive) {
+		return value
+	}
+
+	var b strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' || i+1 >= len(runes) {
+			b.WriteRune(runes[i])
+			continue
+		}
+		i++
+		if runes[i] == '%' {
+			b.WriteByte('%')
+		} else {
+			b.WriteString(specifier(runes[i]))
+		}
+	}
+	return b.String()
+}
+
+// SplitWords splits a value into whitespace-separated words the way systemd's
+// extract_first_word() does with EXTRACT_UNQUOTE: single and double quotes
+// group words and are removed. With cunescape, C escapes (\n, \t, \xNN, …) are
+// resolved as well; otherwise a backslash only protects the next character.
+func SplitWords(value string, cunescape bool) ([]string, error) {
+	var (
+		words   []string
+		cur     strings.Builder
+		inWord  bool
+		quote   rune
+		runes   = []rune(value)
+		isSpace = func(r rune) bool { return r == ' ' || r == '\t' || r == '\n' || r == '\r' }
+	)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("trailing backslash in %q", value)
+			}
+			i++
+			if !cunescape {
+				cur.WriteRune(runes[i])
+			} else {
+				n, err := cunescapeOne(runes[i:], &cur)
+				if err != nil {
+					return nil, fmt.Errorf("%w in %q", err, value)
+				}
+				i += n - 1
+			}
+			inWord = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			cur.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case isSpace(r):
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", value)
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+// cunescapeOne decodes the escape sequence following a backslash. It returns
+// the number of runes consumed.
+func cunescapeOne(rs []rune, b *strings.Builder) (int, error) {
+	switch rs[0] {
+	case 'a':
+		b.WriteByte('\a')
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case 'n':
+		b.WriteByte('\n')
+	case 'r':
+		b.WriteByte('\r')
+	case 's':
+		b.WriteByte(' ')
+	case 't':
+		b.WriteByte('\t')
+	case 'v':
+		b.WriteByte('\v')
+	case '\\', '"', '\'', ' ':
+		b.WriteRune(rs[0])
+	case 'x':
+		if len(rs) < 3 {
+			return 0, fmt.Errorf("short \\x escape")
+		}
+		var v int
+		if _, err := fmt.Sscanf(string(rs[1:3]), "%02x", &v); err != nil {
+			return 0, fmt.Errorf("invalid \\x escape")
+		}
+		b.WriteByte(byte(v))
+		return 3, nil
+	default:
+		return 0, fmt.Errorf("unknown escape \\%c", rs[0])
+	}
+	return 1, nil
+}
+
+// SplitSpecifiers splits a decoded value into literal text and the specifiers
+// marked in it, calling lit for each literal run and spec for each specifier.
+func SplitSpecifiers(value string, lit func(string), spec func(rune)) {
+	var b strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == specifierMark && i+1 < len(runes) {
+			if b.Len() > 0 {
+				lit(b.String())
+				b.Reset()
+			}
+			i++
+			spec(runes[i])
+			continue
+		}
+		b.WriteRune(runes[i])
+	}
+	if b.Len() > 0 {
+		lit(b.String())
+	}
+}
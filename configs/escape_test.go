@@ -0,0 +1,85 @@
+package configs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeValue(t *testing.T) {
+	tests := []struct {
+		directive string
+		value     string
+		want      string
+		err       string // part of the error, if any
+	}{
+		{"Description", "plain", "plain", ""},
+		{"Description", "100% sure", "100%% sure", ""},
+		{"Description", "%i", "%%i", ""},
+		{"Description", "web " + specifier('i'), "web %i", ""},
+		{"ExecStart", "/bin/echo 50% " + specifier('I'), "/bin/echo 50%% %I", ""},
+		{"ExecStart", "/bin/sh -c 'a'\n-c 'b'\n", "/bin/sh -c 'a'\\\n-c 'b'", ""},
+		{"ExecStart", "/bin/printf \x1b", `/bin/printf \x1b`, ""},
+		{"Description", "tab\there", "tab\there", ""},
+
+		// These directives read percentages and expand no specifiers.
+		{"CPUQuota", "20%", "20%", ""},
+		{"MemoryMax", "50%", "50%", ""},
+		{"TasksMax", "10%", "10%", ""},
+		{"CPUQuota", specifier('i'), "", "does not expand specifiers"},
+
+		{"Description", "a\nb", "", "control character"},
+		{"Description", `C:\`, "", "ends with a backslash"},
+		{"Description", "web " + resetValue, "", "reset"},
+		{"ExecStart", resetValue + " /bin/web", "", "reset"},
+		{"Description", string(specifierMark), "", "dangling"},
+	}
+	for _, tt := range tests {
+		got, err := EscapeValue(tt.directive, tt.value)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("EscapeValue(%s, %q) = %q, %v; want error %q", tt.directive, tt.value, got, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("EscapeValue(%s, %q) = %q, %v; want %q", tt.directive, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestUnescapeValue(t *testing.T) {
+	for _, tt := range []struct{ directive, text string }{
+		{"Description", "100%% sure"},
+		{"ExecStart", "/bin/echo %i %%I"},
+		{"CPUQuota", "20%"},
+	} {
+		decoded := UnescapeValue(tt.directive, tt.text)
+		if got, err := EscapeValue(tt.directive, decoded); err != nil || got != tt.text {
+			t.Errorf("%s=%s does not round-trip: %q, %v", tt.directive, tt.text, got, err)
+		}
+	}
+}
+
+func TestEncodeRejectsEmbeddedReset(t *testing.T) {
+	for _, service := range []string{
+		`working_directory = "/srv/${reset}"`,
+		`environment = { PORT = "${reset}8080" }`,
+	} {
+		config, diags := Decode("test.hcl", []byte(`
+service "web" {
+  unit {}
+  service {
+    exec_start = ["/usr/bin/web"]
+    `+service+`
+  }
+  install {}
+}
+`))
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		if _, err := config.Render(); err == nil || !strings.Contains(err.Error(), "reset") {
+			t.Errorf("%s: err = %v, want an error about reset", service, err)
+		}
+	}
+}
@@ -22,12 +22,101 @@ func BuiltinVars(knownUnits []KnownUnit) map[string]cty.Value {
 	return builtinObj
 }
 
+// selfSpecifiers maps the attributes of the self namespace to the systemd
+// specifiers they stand for (see "Specifiers" in systemd.unit(5)).
+var selfSpecifiers = []struct {
+	Name      string
+	Specifier rune
+}{
+	{"architecture", 'a'},
+	{"boot_id", 'b'},
+	{"cache_directory", 'C'},
+	{"config_directory", 'E'},
+	{"credentials_directory", 'd'},
+	{"fragment_directory", 'Y'},
+	{"fragment_path", 'y'},
+	{"group", 'g'},
+	{"group_id", 'G'},
+	{"home", 'h'},
+	{"hostname", 'H'},
+	{"instance", 'i'},
+	{"instance_unescaped", 'I'},
+	{"kernel_release", 'v'},
+	{"logs_directory", 'L'},
+	{"machine_id", 'm'},
+	{"os_build_id", 'B'},
+	{"os_id", 'o'},
+	{"os_image_id", 'M'},
+	{"os_image_version", 'A'},
+	{"os_variant_id", 'W'},
+	{"os_version_id", 'w'},
+	{"path_unescaped", 'f'},
+	{"prefix", 'p'},
+	{"prefix_last", 'j'},
+	{"prefix_last_unescaped", 'J'},
+	{"prefix_unescaped", 'P'},
+	{"pretty_hostname", 'q'},
+	{"runtime_directory", 't'},
+	{"shared_data_directory", 'D'},
+	{"shell", 's'},
+	{"short_hostname", 'l'},
+	{"state_directory", 'S'},
+	{"temp_directory", 'T'},
+	{"unit_name", 'n'},
+	{"unit_name_without_suffix", 'N'},
+	{"user", 'u'},
+	{"user_id", 'U'},
+	{"var_temp_directory", 'V'},
+}
+
 // SelfVars returns the cty variables for the self namespace (template specifiers).
+// The values are marked specifiers that EscapeValue renders as %i, %I, ….
 func SelfVars() map[string]cty.Value {
-	return map[string]cty.Value{
-		"instance":           cty.StringVal("%i"),
-		"instance_unescaped": cty.StringVal("%I"),
+	vars := make(map[string]cty.Value, len(selfSpecifiers))
+	for _, s := range selfSpecifiers {
+		vars[s.Name] = cty.StringVal(specifier(s.Specifier))
+	}
+	return vars
+}
+
+// SelfAttribute returns the self.* attribute standing for the specifier %c.
+func SelfAttribute(c rune) (string, bool) {
+	for _, s := range selfSpecifiers {
+		if s.Specifier == c {
+			return s.Name, true
+		}
+	}
+	return "", false
+}
+
+// SelfSpecifier returns the specifier the self.* attribute name stands for.
+func SelfSpecifier(name string) (rune, bool) {
+	for _, s := range selfSpecifiers {
+		if s.Name == name {
+			return s.Specifier, true
+		}
+	}
+	return 0, false
+}
+
+// SelfAttributes returns the attribute names of the self namespace.
+func SelfAttributes() []string {
+	names := make([]string, len(selfSpecifiers))
+	for i, s := range selfSpecifiers {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// BuiltinAddress returns the builtin.* address of a well-known unit:
+// "multi-user.target" → ("target", "multi_user").
+func BuiltinAddress(name string) (unitType, attr string, ok bool) {
+	for _, u := range DefaultKnownUnits {
+		if u.Name == name {
+			return u.UnitType, sanitizeHCLIdent(u.Name), true
+		}
 	}
+	return "", "", false
 }
 
 // EachVars returns the cty variables for the each namespace (for_each iteration).
@@ -58,6 +147,13 @@ func BuildEvalContext(
 
 	vars["self"] = cty.ObjectVal(SelfVars())
 
+	// `reset` emits an empty assignment, e.g. exec_start = [reset, "..."]
+	// or environment = { reset = reset, PORT = "8080" }.
+	vars["reset"] = cty.StringVal(resetValue)
+
+	// `infinity` disables a time span, e.g. timeout_start_sec = infinity.
+	vars["infinity"] = cty.NumberIntVal(TimespanInfinity)
+
 	return &hcl.EvalContext{
 		Variables: vars,
 	}
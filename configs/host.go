@@ -0,0 +1,46 @@
+package configs
+
+import "regexp"
+
+// Debian package names and versions, per deb-control(5). The version may
+// carry an epoch and a revision: 1:2.0-3.
+var (
+	debNamePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]+$`)
+	debVersionPattern = regexp.MustCompile(`^([0-9]+:)?[0-9][A-Za-z0-9.+~:-]*$`)
+)
+
+// Host selects the units applied to one machine. Units not referenced by
+// any host are inert.
+type Host struct {
+	Name    string   `hcl:"name,label"`
+	Enable  []string `hcl:"enable,optional"`
+	Disable []string `hcl:"disable,optional"`
+	SSH     *SSH     `hcl:"ssh,block"`
+	Package *Package `hcl:"package,block"`
+}
+
+// SSH is how unitd apply reaches a host. Name refers to a Host entry of
+// ~/.ssh/config; the other fields override or replace it.
+type SSH struct {
+	Name         string `hcl:"name,optional"`
+	User         string `hcl:"user,optional"`
+	Host         string `hcl:"host,optional"`
+	Port         int    `hcl:"port,optional"`
+	IdentityFile string `hcl:"identity_file,optional"`
+	KnownHosts   string `hcl:"known_hosts,optional"`
+}
+
+// Package describes the Debian package unitd package builds for a host.
+type Package struct {
+	Name         string   `hcl:"name"`
+	Version      string   `hcl:"version"`
+	Depends      []string `hcl:"depends,optional"`
+	Maintainer   string   `hcl:"maintainer,optional"`
+	Description  string   `hcl:"description,optional"`
+	Architecture string   `hcl:"architecture,optional"` // "all" when unset
+}
+
+// Units returns the unit names the host references, enabled ones first.
+func (h *Host) Units() []string {
+	return append(append([]string{}, h.Enable...), h.Disable...)
+}
@@ -19,6 +19,7 @@ type InstanceMeta struct {
 	Name         string
 	TemplateExpr hcl.Expression
 	Instances    []string
+	DeclRange    hcl.Range
 }
 
 // InstanceResolved holds a fully resolved instance block.
@@ -29,7 +30,7 @@ type InstanceResolved struct {
 }
 
 // ExtractInstanceMeta pre-scans instance blocks for their expressions.
-func ExtractInstanceMeta(body hcl.Body) ([]InstanceMeta, error) {
+func ExtractInstanceMeta(body hcl.Body) ([]InstanceMeta, hcl.Diagnostics) {
 	schema := &hcl.BodySchema{
 		Blocks: []hcl.BlockHeaderSchema{
 			{Type: "instance", LabelNames: []string{"name"}},
@@ -38,7 +39,7 @@ func ExtractInstanceMeta(body hcl.Body) ([]InstanceMeta, error) {
 
 	content, _, diags := body.PartialContent(schema)
 	if diags.HasErrors() {
-		return nil, fmt.Errorf("partial content: %s", diags.Error())
+		return nil, diags
 	}
 
 	innerSchema := &hcl.BodySchema{
@@ -54,11 +55,12 @@ func ExtractInstanceMeta(body hcl.Body) ([]InstanceMeta, error) {
 			continue
 		}
 
-		meta := InstanceMeta{Name: block.Labels[0]}
+		meta := InstanceMeta{Name: block.Labels[0], DeclRange: block.DefRange}
 
-		inner, _, diags := block.Body.PartialContent(innerSchema)
-		if diags.HasErrors() {
-			return nil, fmt.Errorf("instance %q: %s", meta.Name, diags.Error())
+		inner, _, moreDiags := block.Body.PartialContent(innerSchema)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return nil, diags
 		}
 
 		if attr, ok := inner.Attributes["template"]; ok {
@@ -80,24 +82,38 @@ func ExtractInstanceMeta(body hcl.Body) ([]InstanceMeta, error) {
 		result = append(result, meta)
 	}
 
-	return result, nil
+	return result, diags
 }
 
 // ResolveInstances evaluates instance template expressions using a partial
 // EvalContext and produces resolved instance metadata.
-func ResolveInstances(ctx *hcl.EvalContext, metas []InstanceMeta) ([]InstanceResolved, error) {
+func ResolveInstances(ctx *hcl.EvalContext, metas []InstanceMeta) ([]InstanceResolved, hcl.Diagnostics) {
 	var result []InstanceResolved
+	var diags hcl.Diagnostics
 	for _, m := range metas {
 		if m.TemplateExpr == nil {
-			return nil, fmt.Errorf("instance %q: missing template attribute", m.Name)
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing template attribute",
+				Detail:   fmt.Sprintf("Instance %q must set template to a template service.", m.Name),
+				Subject:  m.DeclRange.Ptr(),
+			})
+			continue
 		}
 
-		val, diags := m.TemplateExpr.Value(ctx)
-		if diags.HasErrors() {
-			return nil, fmt.Errorf("instance %q template: %s", m.Name, diags.Error())
+		val, moreDiags := m.TemplateExpr.Value(ctx)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			continue
 		}
 		if val.Type() != cty.String {
-			return nil, fmt.Errorf("instance %q template: expected string, got %s", m.Name, val.Type().FriendlyName())
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid instance template",
+				Detail:   fmt.Sprintf("Instance %q template: expected string, got %s.", m.Name, val.Type().FriendlyName()),
+				Subject:  m.TemplateExpr.Range().Ptr(),
+			})
+			continue
 		}
 
 		result = append(result, InstanceResolved{
@@ -106,7 +122,7 @@ func ResolveInstances(ctx *hcl.EvalContext, metas []InstanceMeta) ([]InstanceRes
 			Instances:    m.Instances,
 		})
 	}
-	return result, nil
+	return result, diags
 }
 
 // InstanceVars builds the cty variables for the instance namespace.
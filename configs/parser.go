@@ -1,11 +1,11 @@
 package configs
 
 import (
-	"fmt"
+	"os"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
-	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 // configFileSchema is the top-level schema for a unitd configuration file.
@@ -13,10 +13,24 @@ var configFileSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "service", LabelNames: []string{"name"}},
 		{Type: "instance", LabelNames: []string{"name"}},
+		{Type: "host", LabelNames: []string{"name"}},
 	},
 }
 
 // DecodeFile parses an HCL file and decodes it into a Config.
+func DecodeFile(path string) (*Config, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, diags := Decode(path, src)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return config, nil
+}
+
+// Decode parses and decodes the configuration src read from filename.
 //
 // Multi-phase decode:
 //  1. Pre-scan service blocks for labels, template, for_each
@@ -26,90 +40,165 @@ var configFileSchema = &hcl.BodySchema{
 //  5. Build full EvalContext (+ instances)
 //  6. Decode blocks individually — services with for_each are expanded
 //     (one Service per variant, each decoded with its own each.key/each.value)
-func DecodeFile(path string) (*Config, error) {
-	parser := hclparse.NewParser()
-	file, diags := parser.ParseHCLFile(path)
+//
+// Blocks failing to decode are left out of the returned Config and decoding
+// goes on, so that the diagnostics cover the whole file.
+func Decode(filename string, src []byte) (*Config, hcl.Diagnostics) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
 	if diags.HasErrors() {
-		return nil, fmt.Errorf("parse %s: %s", path, diags.Error())
+		return nil, diags
 	}
 
 	body := file.Body
 
-	// Phase 1: Pre-scan service blocks.
-	serviceMetas, err := ExtractServiceMeta(body)
-	if err != nil {
-		return nil, fmt.Errorf("extract services from %s: %w", path, err)
-	}
-
-	// Phase 2: Pre-scan instance blocks.
-	instanceMetas, err := ExtractInstanceMeta(body)
-	if err != nil {
-		return nil, fmt.Errorf("extract instances from %s: %w", path, err)
-	}
-
-	// Phase 3: Build partial context (builtins + services, no instances yet).
-	partialCtx := BuildEvalContext(DefaultKnownUnits, serviceMetas, nil)
-
-	// Phase 4: Resolve instance template expressions.
-	resolved, err := ResolveInstances(partialCtx, instanceMetas)
-	if err != nil {
-		return nil, fmt.Errorf("resolve instances in %s: %w", path, err)
+	baseCtx, serviceMetas, moreDiags := buildContext(body)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return nil, diags
 	}
 
-	// Phase 5: Build full base context.
-	baseCtx := BuildEvalContext(DefaultKnownUnits, serviceMetas, resolved)
-
 	// Phase 6: Decode blocks individually.
-	content, _, diags := body.PartialContent(configFileSchema)
-	if diags.HasErrors() {
-		return nil, fmt.Errorf("read blocks from %s: %s", path, diags.Error())
-	}
+	content, _, moreDiags := body.PartialContent(configFileSchema)
+	diags = append(diags, moreDiags...)
 
 	metaIndex := make(map[string]ServiceMeta, len(serviceMetas))
 	for _, m := range serviceMetas {
 		metaIndex[m.Name] = m
 	}
 
-	var config Config
+	config := Config{Ranges: make(map[string]hcl.Range)}
 	for _, block := range content.Blocks {
 		switch block.Type {
 		case "service":
 			name := block.Labels[0]
 			meta := metaIndex[name]
+			if _, ok := config.Ranges["service."+name]; !ok {
+				config.Ranges["service."+name] = block.DefRange
+			}
 
 			if len(meta.ForEach) > 0 {
-				// Expand: decode once per variant with each.key/each.value
-				for key, value := range meta.ForEach {
+				// Expand: decode once per variant with each.key/each.value,
+				// in key order so the output does not depend on map iteration.
+				for _, key := range sortedKeys(meta.ForEach) {
+					value := meta.ForEach[key]
 					ctx := WithEachVars(baseCtx, key, value)
-					var svc Service
-					diags := gohcl.DecodeBody(block.Body, ctx, &svc)
-					if diags.HasErrors() {
-						return nil, fmt.Errorf("decode service %q variant %q in %s: %s", name, key, path, diags.Error())
+					svc, moreDiags := decodeService(block, ctx)
+					diags = append(diags, moreDiags...)
+					if moreDiags.HasErrors() {
+						continue
 					}
-					svc.Name = name
 					svc.ForEach = map[string]string{key: value}
 					config.Services = append(config.Services, svc)
 				}
 			} else {
-				var svc Service
-				diags := gohcl.DecodeBody(block.Body, baseCtx, &svc)
-				if diags.HasErrors() {
-					return nil, fmt.Errorf("decode service %q in %s: %s", name, path, diags.Error())
+				svc, moreDiags := decodeService(block, baseCtx)
+				diags = append(diags, moreDiags...)
+				if moreDiags.HasErrors() {
+					continue
 				}
-				svc.Name = name
 				config.Services = append(config.Services, svc)
 			}
 
 		case "instance":
 			var inst Instance
-			diags := gohcl.DecodeBody(block.Body, baseCtx, &inst)
-			if diags.HasErrors() {
-				return nil, fmt.Errorf("decode instance %q in %s: %s", block.Labels[0], path, diags.Error())
+			moreDiags := gohcl.DecodeBody(block.Body, baseCtx, &inst)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				continue
 			}
 			inst.Name = block.Labels[0]
+			if _, ok := config.Ranges["instance."+inst.Name]; !ok {
+				config.Ranges["instance."+inst.Name] = block.DefRange
+			}
 			config.Instances = append(config.Instances, inst)
+
+		case "host":
+			var host Host
+			moreDiags := gohcl.DecodeBody(block.Body, baseCtx, &host)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				continue
+			}
+			host.Name = block.Labels[0]
+			if _, ok := config.Ranges["host."+host.Name]; !ok {
+				config.Ranges["host."+host.Name] = block.DefRange
+			}
+			config.Hosts = append(config.Hosts, host)
 		}
 	}
 
-	return &config, nil
+	return &config, uniqueDiagnostics(diags)
+}
+
+// DecodeEvalContext returns the context the blocks of the configuration src
+// are decoded with: builtin, service, instance, self and reset.
+func DecodeEvalContext(filename string, src []byte) (*hcl.EvalContext, hcl.Diagnostics) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	ctx, _, moreDiags := buildContext(file.Body)
+	return ctx, append(diags, moreDiags...)
+}
+
+// buildContext runs phases 1 to 5 of Decode.
+func buildContext(body hcl.Body) (*hcl.EvalContext, []ServiceMeta, hcl.Diagnostics) {
+	// Phase 1: Pre-scan service blocks.
+	serviceMetas, diags := ExtractServiceMeta(body)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	// Phase 2: Pre-scan instance blocks.
+	instanceMetas, moreDiags := ExtractInstanceMeta(body)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	// Phase 3: Build partial context (builtins + services, no instances yet).
+	partialCtx := BuildEvalContext(DefaultKnownUnits, serviceMetas, nil)
+
+	// Phase 4: Resolve instance template expressions.
+	resolved, moreDiags := ResolveInstances(partialCtx, instanceMetas)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	// Phase 5: Build full base context.
+	return BuildEvalContext(DefaultKnownUnits, serviceMetas, resolved), serviceMetas, diags
+}
+
+func decodeService(block *hcl.Block, ctx *hcl.EvalContext) (Service, hcl.Diagnostics) {
+	var svc Service
+	diags := gohcl.DecodeBody(block.Body, ctx, &svc)
+	// Attributes that failed to decode are left empty and skipped, so the
+	// other enum values are still checked.
+	diags = append(diags, CheckEnums(block.Body, &svc)...)
+	svc.Name = block.Labels[0]
+	return svc, diags
+}
+
+// uniqueDiagnostics drops the repeats of diagnostics reported once per
+// for_each variant of the same block.
+func uniqueDiagnostics(diags hcl.Diagnostics) hcl.Diagnostics {
+	type key struct {
+		summary, detail string
+		subject         hcl.Range
+	}
+	seen := make(map[key]bool, len(diags))
+	out := diags[:0:0]
+	for _, d := range diags {
+		k := key{summary: d.Summary, detail: d.Detail}
+		if d.Subject != nil {
+			k.subject = *d.Subject
+		}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, d)
+	}
+	return out
 }
@@ -0,0 +1,48 @@
+package configs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// File is a rendered unit file.
+type File struct {
+	Name    string // unit file name, e.g. "worker-queue@.service"
+	Content string
+	Source  string // address of the originating block, e.g. service.worker["queue"]
+
+	// Link, when set, makes the file a symlink to Link instead, as the
+	// "multi-user.target.wants/nginx.service" links of enabled units.
+	Link string
+}
+
+// Render encodes every unit of the configuration. The result is sorted by
+// file name so that repeated runs produce byte-identical output.
+func (c *Config) Render() ([]File, error) {
+	var files []File
+	seen := make(map[string]string)
+
+	for _, svc := range c.Services {
+		content, err := svc.Encode()
+		if err != nil {
+			return nil, fmt.Errorf("encode %s: %w", svc.Address(), err)
+		}
+
+		for _, name := range svc.UnitFilenames() {
+			if prev, ok := seen[name]; ok {
+				return nil, fmt.Errorf("%s and %s both produce %s", prev, svc.Address(), name)
+			}
+			seen[name] = svc.Address()
+			files = append(files, File{
+				Name:    name,
+				Content: content,
+				Source:  svc.Address(),
+			})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
+}
@@ -0,0 +1,91 @@
+package configs
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	exampleSrc      = "../example/src/services.hcl"
+	exampleExpected = "../example/transplied"
+)
+
+func renderExample(t *testing.T) []File {
+	t.Helper()
+
+	config, err := DecodeFile(exampleSrc)
+	if err != nil {
+		t.Fatalf("decode: %s", err)
+	}
+	if diags := config.Validate(); diags.HasErrors() {
+		t.Fatalf("validate: %s", diags)
+	}
+	files, err := config.Render()
+	if err != nil {
+		t.Fatalf("render: %s", err)
+	}
+	return files
+}
+
+func hashFiles(files []File) [sha256.Size]byte {
+	h := sha256.New()
+	for _, f := range files {
+		h.Write([]byte(f.Name))
+		h.Write([]byte{0})
+		h.Write([]byte(f.Content))
+		h.Write([]byte{0})
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+func TestRenderMatchesGolden(t *testing.T) {
+	files := renderExample(t)
+
+	expected, err := filepath.Glob(filepath.Join(exampleExpected, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("rendered %d files, golden directory has %d", len(files), len(expected))
+	}
+
+	for _, f := range files {
+		want, err := os.ReadFile(filepath.Join(exampleExpected, f.Name))
+		if err != nil {
+			t.Errorf("%s: %s", f.Name, err)
+			continue
+		}
+		if f.Content != string(want) {
+			t.Errorf("%s differs from golden file\n--- want\n%s\n--- got\n%s", f.Name, want, f.Content)
+		}
+	}
+}
+
+func TestRenderIsReproducible(t *testing.T) {
+	first := hashFiles(renderExample(t))
+
+	for i := 0; i < 50; i++ {
+		if got := hashFiles(renderExample(t)); got != first {
+			t.Fatalf("run %d: output hash %x, first run %x", i, got, first)
+		}
+	}
+}
+
+func TestSectionOrder(t *testing.T) {
+	unit := NewSystemdUnitBuilder().
+		AddEntry("Install", "WantedBy", "multi-user.target").
+		AddEntry("Service", "ExecStart", "/bin/true").
+		AddEntry("Unit", "Description", "test").
+		Build("test.service")
+
+	want := "[Unit]\nDescription=test\n\n[Service]\nExecStart=/bin/true\n\n[Install]\nWantedBy=multi-user.target\n"
+	for i := 0; i < 20; i++ {
+		if got := unit.ToString(); got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package configs
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
@@ -18,20 +17,22 @@ type ServiceMeta struct {
 // UnitFilenames returns the systemd unit filenames this service produces.
 //
 //	no template, no for_each  →  ["nginx.service"]
-//	no template, for_each     →  ["worker-queue.service", "worker-email.service"]
+//	no template, for_each     →  ["worker-email.service", "worker-queue.service"]
 //	template, no for_each     →  ["worker@.service"]
-//	template + for_each       →  ["worker-queue@.service", "worker-email@.service"]
+//	template + for_each       →  ["worker-email@.service", "worker-queue@.service"]
+//
+// Variants are returned sorted by key.
 func (s *Service) UnitFilenames() []string {
 	switch {
 	case s.Template && len(s.ForEach) > 0:
 		names := make([]string, 0, len(s.ForEach))
-		for v := range s.ForEach {
+		for _, v := range sortedKeys(s.ForEach) {
 			names = append(names, TemplateUnitName(s.Name, v, "service"))
 		}
 		return names
 	case len(s.ForEach) > 0:
 		names := make([]string, 0, len(s.ForEach))
-		for v := range s.ForEach {
+		for _, v := range sortedKeys(s.ForEach) {
 			names = append(names, s.Name+"-"+v+".service")
 		}
 		return names
@@ -42,34 +43,52 @@ func (s *Service) UnitFilenames() []string {
 	}
 }
 
-// Encode converts a Service to a systemd .service unit file string.
-func (s *Service) Encode() (string, error) {
-	var b strings.Builder
-
-	sections := []struct {
-		name string
-		data any
-	}{
-		{"Unit", s.Unit},
-		{"Service", s.Service},
-		{"Install", s.Install},
+// Address returns the HCL address of the service: service.nginx, or
+// service.worker["queue"] for an expanded for_each variant.
+func (s *Service) Address() string {
+	if len(s.ForEach) == 1 {
+		for _, k := range sortedKeys(s.ForEach) {
+			return fmt.Sprintf("service.%s[%q]", s.Name, k)
+		}
 	}
+	return "service." + s.Name
+}
 
-	for _, sec := range sections {
-		entries, err := EncodeSystemdSection(sec.data)
-		if err != nil {
-			return "", err
+// Restart policies say what unitd apply does to a running unit whose file
+// changed: restart it, reload it, or leave it for the next restart.
+const (
+	RestartPolicyRestart = "restart"
+	RestartPolicyReload  = "reload"
+	RestartPolicyNone    = "none"
+)
+
+// RestartPolicies maps the unit files of the services of c to their restart
+// policy, restart when unset.
+func (c *Config) RestartPolicies() map[string]string {
+	policies := make(map[string]string)
+	for _, svc := range c.Services {
+		policy := svc.RestartPolicy
+		if policy == "" {
+			policy = RestartPolicyRestart
 		}
-		if len(entries) > 0 {
-			writeSection(&b, sec.name, entries)
+		for _, name := range svc.UnitFilenames() {
+			policies[name] = policy
 		}
 	}
+	return policies
+}
 
-	return strings.TrimSpace(b.String()) + "\n", nil
+// Encode converts a Service to a systemd .service unit file string.
+func (s *Service) Encode() (string, error) {
+	unit, err := NewUnitCodec[Service]().Encode(*s)
+	if err != nil {
+		return "", err
+	}
+	return unit.ToString(), nil
 }
 
 // ExtractServiceMeta pre-scans service blocks for template/for_each metadata.
-func ExtractServiceMeta(body hcl.Body) ([]ServiceMeta, error) {
+func ExtractServiceMeta(body hcl.Body) ([]ServiceMeta, hcl.Diagnostics) {
 	schema := &hcl.BodySchema{
 		Blocks: []hcl.BlockHeaderSchema{
 			{Type: "service", LabelNames: []string{"name"}},
@@ -78,7 +97,7 @@ func ExtractServiceMeta(body hcl.Body) ([]ServiceMeta, error) {
 
 	content, _, diags := body.PartialContent(schema)
 	if diags.HasErrors() {
-		return nil, fmt.Errorf("partial content: %s", diags.Error())
+		return nil, diags
 	}
 
 	innerSchema := &hcl.BodySchema{
@@ -96,9 +115,10 @@ func ExtractServiceMeta(body hcl.Body) ([]ServiceMeta, error) {
 
 		meta := ServiceMeta{Name: block.Labels[0]}
 
-		inner, _, diags := block.Body.PartialContent(innerSchema)
-		if diags.HasErrors() {
-			return nil, fmt.Errorf("service %q: %s", meta.Name, diags.Error())
+		inner, _, moreDiags := block.Body.PartialContent(innerSchema)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return nil, diags
 		}
 
 		if attr, ok := inner.Attributes["template"]; ok {
@@ -128,7 +148,7 @@ func ExtractServiceMeta(body hcl.Body) ([]ServiceMeta, error) {
 		result = append(result, meta)
 	}
 
-	return result, nil
+	return result, diags
 }
 
 // ServiceVars builds the cty variables for the service namespace.
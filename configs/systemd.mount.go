@@ -10,25 +10,29 @@ import (
 
 // MountBlock is for [Mount] systemd unit block
 //
-// A unit configuration file whose name ends in encodes information about a file system mount point
-// controlled and supervised by systemd. This man page lists the configuration options specific to this
-// unit type. See for the common options of all unit configuration files. The common configuration
-// items are configured in the generic [Unit] and [Install] sections. The mount specific configuration
-// options are configured in the [Mount] section. Additional options are listed in , which define the
-// execution environment the program is executed in, and in , which define the way the processes are
-// terminated, and in , which configure resource control settings for the processes of the service.
-// Note that the options and are not useful for mount units. systemd passes two parameters to ; the
-// values of and . When invoked in this way, does not read any options from , and must be run as UID 0.
-// Mount units must be named after the mount point directories they control. Example: the mount point
-// must be configured in a unit file . For details about the escaping logic used to convert a file
-// system path to a unit name, see . Note that mount units cannot be templated, nor is possible to add
-// multiple names to a mount unit by creating symlinks to its unit file. Optionally, a mount unit may
-// be accompanied by an automount unit, to allow on-demand or parallelized mounting. See . Mount points
-// created at runtime (independently of unit files or ) will be monitored by systemd and appear like
-// any other mount unit in systemd. See description in . Some file systems have special semantics as
-// API file systems for kernel-to-userspace and userspace-to-userspace interfaces. Some of them may not
-// be changed via mount units, and cannot be disabled. For a longer discussion see . The command allows
-// creating and units dynamically and transiently from the command line.
+// A unit configuration file whose name ends in .mount encodes information about a file system mount
+// point controlled and supervised by systemd. This man page lists the configuration options specific
+// to this unit type. See systemd.unit(5) for the common options of all unit configuration files. The
+// common configuration items are configured in the generic [Unit] and [Install] sections. The mount
+// specific configuration options are configured in the [Mount] section. Additional options are listed
+// in systemd.exec(5), which define the execution environment the mount(8) program is executed in, and
+// in systemd.kill(5), which define the way the processes are terminated, and in
+// systemd.resource-control(5), which configure resource control settings for the processes of the
+// service. Note that the options User= and Group= are not useful for mount units. systemd passes two
+// parameters to mount(8); the values of What= and Where=. When invoked in this way, mount(8) does not
+// read any options from /etc/fstab, and must be run as UID 0. Mount units must be named after the
+// mount point directories they control. Example: the mount point /home/lennart must be configured in a
+// unit file home-lennart.mount. For details about the escaping logic used to convert a file system
+// path to a unit name, see systemd.unit(5). Note that mount units cannot be templated, nor is possible
+// to add multiple names to a mount unit by creating symlinks to its unit file. Optionally, a mount
+// unit may be accompanied by an automount unit, to allow on-demand or parallelized mounting. See
+// systemd.automount(5). Mount points created at runtime (independently of unit files or /etc/fstab)
+// will be monitored by systemd and appear like any other mount unit in systemd. See
+// /proc/self/mountinfo description in proc(5). Some file systems have special semantics as API file
+// systems for kernel-to-userspace and userspace-to-userspace interfaces. Some of them may not be
+// changed via mount units, and cannot be disabled. For a longer discussion see API File Systems. The
+// systemd-mount(1) command allows creating .mount and .automount units dynamically and transiently
+// from the command line.
 type MountBlock struct {
 	AllowedCPUs        string `hcl:"allowed_cp_us,optional" systemd:"AllowedCPUs"`
 	AllowedMemoryNodes string `hcl:"allowed_memory_nodes,optional" systemd:"AllowedMemoryNodes"`
@@ -92,7 +96,7 @@ type MountBlock struct {
 	// This option is implied when LogNamespace= is used, when MountAPIVFS=yes, or when PrivateDevices=yes
 	// is used in conjunction with either RootDirectory= or RootImage=.
 	//
-	BindLogSockets       bool     `hcl:"bind_log_sockets,optional" systemd:"BindLogSockets"`
+	BindLogSockets       *bool    `hcl:"bind_log_sockets,optional" systemd:"BindLogSockets"`
 	BindNetworkInterface []string `hcl:"bind_network_interface,optional" systemd:"BindNetworkInterface"`
 	// Configures unit-specific bind mounts. A bind mount makes a particular file or directory available at
 	// an additional place in the unit's view of the file system. Any bind mounts created with this option
@@ -165,10 +169,10 @@ type MountBlock struct {
 	// project="man-pages"><refentrytitle>sched_setaffinity</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details.
 	CPUAffinity             string `hcl:"cpu_affinity,optional" systemd:"CPUAffinity"`
-	CPUPressureThresholdSec int    `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
+	CPUPressureThresholdSec *int   `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
 	CPUPressureWatch        string `hcl:"cpu_pressure_watch,optional" systemd:"CPUPressureWatch"`
 	CPUQuota                string `hcl:"cpu_quota,optional" systemd:"CPUQuota"`
-	CPUQuotaPeriodSec       int    `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
+	CPUQuotaPeriodSec       *int   `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
 	// Sets the CPU scheduling policy for executed processes. Takes one of other, batch, idle, fifo, rr or
 	// ext. See <citerefentry
 	// project="man-pages"><refentrytitle>sched_setscheduler</refentrytitle><manvolnum>2</manvolnum></citerefentry>
@@ -188,8 +192,8 @@ type MountBlock struct {
 	// can hence not leak into child processes. See <citerefentry
 	// project="man-pages"><refentrytitle>sched_setscheduler</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details. Defaults to false.
-	CPUSchedulingResetOnFork bool   `hcl:"cpu_scheduling_reset_on_fork,optional" systemd:"CPUSchedulingResetOnFork"`
-	CPUWeight                uint64 `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
+	CPUSchedulingResetOnFork *bool   `hcl:"cpu_scheduling_reset_on_fork,optional" systemd:"CPUSchedulingResetOnFork"`
+	CPUWeight                *uint64 `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
 	// /var/cache/
 	CacheDirectory []string `hcl:"cache_directory,optional" systemd:"CacheDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -200,7 +204,7 @@ type MountBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	CacheDirectoryAccounting bool `hcl:"cache_directory_accounting,optional" systemd:"CacheDirectoryAccounting"`
+	CacheDirectoryAccounting *bool `hcl:"cache_directory_accounting,optional" systemd:"CacheDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -270,7 +274,7 @@ type MountBlock struct {
 	// the meaning of the mapping types. When specified multiple times, all specified masks are ORed. When
 	// not set, or if the empty value is assigned, the inherited value is not changed.
 	CoredumpFilter  string `hcl:"coredump_filter,optional" systemd:"CoredumpFilter"`
-	CoredumpReceive bool   `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
+	CoredumpReceive *bool  `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
 	Delegate        string `hcl:"delegate,optional" systemd:"Delegate"`
 	// Delegates ownership of the given namespace types to the user namespace of the processes of this
 	// unit. For details about Linux namespaces, see <citerefentry
@@ -356,7 +360,7 @@ type MountBlock struct {
 	// and access guarantees of the service. Note that this option is currently incompatible with D-Bus
 	// policies, thus a service using this option may currently not allocate a D-Bus service name (note
 	// that this does not affect calling into other D-Bus services). Defaults to off.
-	DynamicUser bool `hcl:"dynamic_user,optional" systemd:"DynamicUser"`
+	DynamicUser *bool `hcl:"dynamic_user,optional" systemd:"DynamicUser"`
 	// Sets environment variables for executed processes. Each line is unquoted using the rules described
 	// in "Quoting" section in
 	// <citerefentry><refentrytitle>systemd.syntax</refentrytitle><manvolnum>7</manvolnum></citerefentry>
@@ -390,7 +394,7 @@ type MountBlock struct {
 	// have access to the secret data. Use LoadCredential=, LoadCredentialEncrypted= or
 	// SetCredentialEncrypted= (see below) to pass data to unit processes securely.
 	//
-	Environment []string `hcl:"environment,optional" systemd:"Environment"`
+	Environment map[string]string `hcl:"environment,optional" systemd:"Environment"`
 	// Similar to Environment=, but reads the environment variables from a text file. The text file should
 	// contain newline-separated variable assignments. Empty lines, lines without an = separator, or lines
 	// starting with ; or # will be ignored, which may be used for commenting. The file must be encoded
@@ -440,7 +444,7 @@ type MountBlock struct {
 	// twice from these files, the files will be read in the order they are specified and the later setting
 	// will override the earlier setting.
 	//
-	EnvironmentFile string `hcl:"environment_file,optional" systemd:"EnvironmentFile"`
+	EnvironmentFile []string `hcl:"environment_file,optional" systemd:"EnvironmentFile"`
 	// Sets up a new file system namespace for executed processes. These options may be used to limit
 	// access a process has to the file system. Each setting takes a space-separated list of paths relative
 	// to the host's root directory (i.e. the system running the service manager). Note that if paths
@@ -612,7 +616,7 @@ type MountBlock struct {
 	// corresponds with <citerefentry
 	// project="man-pages"><refentrytitle>umount</refentrytitle><manvolnum>8</manvolnum></citerefentry>'s
 	// <parameter>-f</parameter> switch. Defaults to off.
-	ForceUnmount bool `hcl:"force_unmount,optional" systemd:"ForceUnmount"`
+	ForceUnmount *bool `hcl:"force_unmount,optional" systemd:"ForceUnmount"`
 	// Set the UNIX user or group that the processes are executed as, respectively. Takes a single user or
 	// group name, or a numeric ID as argument. For system services (services run by the system service
 	// manager, i.e. managed by PID 1) and for user services of the root user (services managed by root's
@@ -645,10 +649,10 @@ type MountBlock struct {
 	// configured through the SupplementaryGroups= setting (see below).
 	//
 	Group                    string   `hcl:"group,optional" systemd:"Group"`
-	IOAccounting             bool     `hcl:"io_accounting,optional" systemd:"IOAccounting"`
+	IOAccounting             *bool    `hcl:"io_accounting,optional" systemd:"IOAccounting"`
 	IODeviceLatencyTargetSec []string `hcl:"io_device_latency_target_sec,optional" systemd:"IODeviceLatencyTargetSec"`
 	IODeviceWeight           []string `hcl:"io_device_weight,optional" systemd:"IODeviceWeight"`
-	IOPressureThresholdSec   int      `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
+	IOPressureThresholdSec   *int     `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
 	IOPressureWatch          string   `hcl:"io_pressure_watch,optional" systemd:"IOPressureWatch"`
 	IOReadBandwidthMax       []string `hcl:"io_read_bandwidth_max,optional" systemd:"IOReadBandwidthMax"`
 	IOReadIOPSMax            []string `hcl:"io_read_iops_max,optional" systemd:"IOReadIOPSMax"`
@@ -658,7 +662,7 @@ type MountBlock struct {
 	// IOSchedulingPriority= have no effect. See
 	// <citerefentry><refentrytitle>ioprio_set</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details.
-	IOSchedulingClass string `hcl:"io_scheduling_class,optional" systemd:"IOSchedulingClass"`
+	IOSchedulingClass IOClass `hcl:"io_scheduling_class,optional" systemd:"IOSchedulingClass"`
 	// Sets the I/O scheduling priority for executed processes. Takes an integer between 0 (highest
 	// priority) and 7 (lowest priority). In case of I/O contention, smaller values mean more I/O bandwidth
 	// is made available to the unit's processes, larger values mean less bandwidth. The available
@@ -667,11 +671,11 @@ type MountBlock struct {
 	// effect. For the kernel's default scheduling class (best-effort) this defaults to 4. See
 	// <citerefentry><refentrytitle>ioprio_set</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details.
-	IOSchedulingPriority int      `hcl:"io_scheduling_priority,optional" systemd:"IOSchedulingPriority"`
-	IOWeight             uint64   `hcl:"io_weight,optional" systemd:"IOWeight"`
+	IOSchedulingPriority *int     `hcl:"io_scheduling_priority,optional" systemd:"IOSchedulingPriority"`
+	IOWeight             *uint64  `hcl:"io_weight,optional" systemd:"IOWeight"`
 	IOWriteBandwidthMax  []string `hcl:"io_write_bandwidth_max,optional" systemd:"IOWriteBandwidthMax"`
 	IOWriteIOPSMax       []string `hcl:"io_write_iops_max,optional" systemd:"IOWriteIOPSMax"`
-	IPAccounting         bool     `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
+	IPAccounting         *bool    `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
 	IPAddressAllow       []string `hcl:"ip_address_allow,optional" systemd:"IPAddressAllow"`
 	IPAddressDeny        []string `hcl:"ip_address_deny,optional" systemd:"IPAddressDeny"`
 	// Takes an absolute file system path referring to a Linux IPC namespace pseudo-file (i.e. a file like
@@ -686,7 +690,7 @@ type MountBlock struct {
 	IPIngressFilterPath []string `hcl:"ip_ingress_filter_path,optional" systemd:"IPIngressFilterPath"`
 	// Takes a boolean argument. If true, SIGPIPE is ignored in the executed process. Defaults to true
 	// since SIGPIPE is generally only useful in shell pipelines.
-	IgnoreSIGPIPE bool `hcl:"ignore_sigpipe,optional" systemd:"IgnoreSIGPIPE"`
+	IgnoreSIGPIPE *bool `hcl:"ignore_sigpipe,optional" systemd:"IgnoreSIGPIPE"`
 	// Pass one or more credentials to the unit. Takes a credential name for which we will attempt to find
 	// a credential that the service manager itself received under the specified name — which may be used
 	// to propagate credentials from an invoking environment (e.g. a container manager that invoked the
@@ -842,7 +846,7 @@ type MountBlock struct {
 	// anymore. This corresponds with <citerefentry
 	// project="man-pages"><refentrytitle>umount</refentrytitle><manvolnum>8</manvolnum></citerefentry>'s
 	// <parameter>-l</parameter> switch. Defaults to off.
-	LazyUnmount bool `hcl:"lazy_unmount,optional" systemd:"LazyUnmount"`
+	LazyUnmount *bool `hcl:"lazy_unmount,optional" systemd:"LazyUnmount"`
 	// ulimit -v
 	LimitAS string `hcl:"limit_as,optional" systemd:"LimitAS"`
 	// ulimit -c
@@ -1126,7 +1130,7 @@ type MountBlock struct {
 	// system call so that the kernel execution domain may not be changed from the default or the
 	// personality selected with Personality= directive. This may be useful to improve security, because
 	// odd personality emulations may be poorly tested and source of vulnerabilities.
-	LockPersonality bool `hcl:"lock_personality,optional" systemd:"LockPersonality"`
+	LockPersonality *bool `hcl:"lock_personality,optional" systemd:"LockPersonality"`
 	// Configures additional log metadata fields to include in all log records generated by processes
 	// associated with this unit, including systemd. This setting takes one or more journal field
 	// assignments in the format FIELD=VALUE separated by whitespace. See
@@ -1190,7 +1194,7 @@ type MountBlock struct {
 	// might prohibit messages of higher log levels to be stored on disk, even though the per-unit
 	// LogLevelMax= permitted it to be processed.
 	//
-	LogLevelMax int `hcl:"log_level_max,optional" systemd:"LogLevelMax"`
+	LogLevelMax *int `hcl:"log_level_max,optional" systemd:"LogLevelMax"`
 	// Run the unit's processes in the specified journal namespace. Expects a short user-defined string
 	// identifying the namespace. If not used the processes of the service are run in the default journal
 	// namespace, i.e. their log stream is collected and processed by systemd-journald.service. If this
@@ -1232,7 +1236,7 @@ type MountBlock struct {
 	// enforced for messages generated via <citerefentry
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> and
 	// similar functions).
-	LogRateLimitBurst uint64 `hcl:"log_rate_limit_burst,optional" systemd:"LogRateLimitBurst"`
+	LogRateLimitBurst *uint64 `hcl:"log_rate_limit_burst,optional" systemd:"LogRateLimitBurst"`
 	// Configures the rate limiting that is applied to log messages generated by this unit. If, in the time
 	// interval defined by LogRateLimitIntervalSec=, more messages than specified in LogRateLimitBurst= are
 	// logged by a service, all further messages within the interval are dropped until the interval is
@@ -1248,7 +1252,7 @@ type MountBlock struct {
 	// enforced for messages generated via <citerefentry
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> and
 	// similar functions).
-	LogRateLimitIntervalSec int `hcl:"log_rate_limit_interval_sec,optional" systemd:"LogRateLimitIntervalSec"`
+	LogRateLimitIntervalSec *int `hcl:"log_rate_limit_interval_sec,optional" systemd:"LogRateLimitIntervalSec"`
 	// /var/log/
 	LogsDirectory []string `hcl:"logs_directory,optional" systemd:"LogsDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -1259,7 +1263,7 @@ type MountBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	LogsDirectoryAccounting bool `hcl:"logs_directory_accounting,optional" systemd:"LogsDirectoryAccounting"`
+	LogsDirectoryAccounting *bool `hcl:"logs_directory_accounting,optional" systemd:"LogsDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -1285,11 +1289,11 @@ type MountBlock struct {
 	//
 	LogsDirectoryQuota                  string `hcl:"logs_directory_quota,optional" systemd:"LogsDirectoryQuota"`
 	ManagedOOMMemoryPressure            string `hcl:"managed_oom_memory_pressure,optional" systemd:"ManagedOOMMemoryPressure"`
-	ManagedOOMMemoryPressureDurationSec int    `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
+	ManagedOOMMemoryPressureDurationSec *int   `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
 	ManagedOOMMemoryPressureLimit       string `hcl:"managed_oom_memory_pressure_limit,optional" systemd:"ManagedOOMMemoryPressureLimit"`
 	ManagedOOMPreference                string `hcl:"managed_oom_preference,optional" systemd:"ManagedOOMPreference"`
 	ManagedOOMSwap                      string `hcl:"managed_oom_swap,optional" systemd:"ManagedOOMSwap"`
-	MemoryAccounting                    bool   `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
+	MemoryAccounting                    *bool  `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
 	// Takes a boolean argument. If set, attempts to create memory mappings that are writable and
 	// executable at the same time, or to change existing memory mappings to become executable, or mapping
 	// shared memory segments as executable, are prohibited. Specifically, a system call filter is added
@@ -1314,7 +1318,7 @@ type MountBlock struct {
 	// recommended to turn off alternative ABIs for services, so that they cannot be used to circumvent the
 	// restrictions of this option. Specifically, it is recommended to combine this option with
 	// SystemCallArchitectures=native or similar.
-	MemoryDenyWriteExecute bool   `hcl:"memory_deny_write_execute,optional" systemd:"MemoryDenyWriteExecute"`
+	MemoryDenyWriteExecute *bool  `hcl:"memory_deny_write_execute,optional" systemd:"MemoryDenyWriteExecute"`
 	MemoryHigh             string `hcl:"memory_high,optional" systemd:"MemoryHigh"`
 	// Takes a boolean argument. When set, it enables KSM (kernel samepage merging) for the processes. KSM
 	// is a memory-saving de-duplication feature. Anonymous memory pages with identical content can be
@@ -1327,11 +1331,11 @@ type MountBlock struct {
 	// or the kernel does not support controlling KSM at the process level through
 	// <citerefentry><refentrytitle>prctl</refentrytitle><manvolnum>2</manvolnum></citerefentry>.
 	//
-	MemoryKSM                  bool   `hcl:"memory_ksm,optional" systemd:"MemoryKSM"`
+	MemoryKSM                  *bool  `hcl:"memory_ksm,optional" systemd:"MemoryKSM"`
 	MemoryLow                  string `hcl:"memory_low,optional" systemd:"MemoryLow"`
 	MemoryMax                  string `hcl:"memory_max,optional" systemd:"MemoryMax"`
 	MemoryMin                  string `hcl:"memory_min,optional" systemd:"MemoryMin"`
-	MemoryPressureThresholdSec int    `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
+	MemoryPressureThresholdSec *int   `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
 	MemoryPressureWatch        string `hcl:"memory_pressure_watch,optional" systemd:"MemoryPressureWatch"`
 	MemorySwapMax              string `hcl:"memory_swap_max,optional" systemd:"MemorySwapMax"`
 	// Transparent Hugepages (THPs) is a Linux kernel feature that manages memory using larger pages (2MB
@@ -1362,7 +1366,7 @@ type MountBlock struct {
 	//
 	MemoryTHP            string `hcl:"memory_thp,optional" systemd:"MemoryTHP"`
 	MemoryZSwapMax       string `hcl:"memory_z_swap_max,optional" systemd:"MemoryZSwapMax"`
-	MemoryZSwapWriteback bool   `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
+	MemoryZSwapWriteback *bool  `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
 	// Takes a boolean argument. If on, a private mount namespace for the unit's processes is created and
 	// the API file systems /proc/, /sys/, /dev/ and /run/ (as an empty tmpfs) are mounted inside of it,
 	// unless they are already mounted. Note that this option has no effect unless used in conjunction with
@@ -1376,7 +1380,7 @@ type MountBlock struct {
 	// host will be used to set up new mounts, and /run/host/incoming/ in the private namespace will be
 	// used as an intermediate step to store them before being moved to the final mount point.
 	//
-	MountAPIVFS bool `hcl:"mount_apivfs,optional" systemd:"MountAPIVFS"`
+	MountAPIVFS *bool `hcl:"mount_apivfs,optional" systemd:"MountAPIVFS"`
 	// Takes a mount propagation setting: shared, slave or private, which controls whether file system
 	// mount points in the file system namespaces set up for this unit's processes will receive or
 	// propagate mounts and unmounts from other file system namespaces. See <citerefentry
@@ -1556,7 +1560,7 @@ type MountBlock struct {
 	// <citerefentry><refentrytitle>systemd-run</refentrytitle><manvolnum>1</manvolnum></citerefentry>, or
 	// arbitrary IPC services.
 	//
-	NoNewPrivileges bool `hcl:"no_new_privileges,optional" systemd:"NoNewPrivileges"`
+	NoNewPrivileges *bool `hcl:"no_new_privileges,optional" systemd:"NoNewPrivileges"`
 	// Sets the adjustment value for the Linux kernel's Out-Of-Memory (OOM) killer score for executed
 	// processes. Takes an integer between -1000 (to disable OOM killing of processes of this unit) and
 	// 1000 (to make killing of processes of this unit under memory pressure very likely). See <ulink
@@ -1678,7 +1682,7 @@ type MountBlock struct {
 	// instead. See
 	// <citerefentry><refentrytitle>systemd.resource-control</refentrytitle><manvolnum>5</manvolnum></citerefentry>.
 	//
-	PrivateDevices bool `hcl:"private_devices,optional" systemd:"PrivateDevices"`
+	PrivateDevices *bool `hcl:"private_devices,optional" systemd:"PrivateDevices"`
 	// Takes a boolean argument. If true, sets up a new IPC namespace for the executed processes. Each IPC
 	// namespace has its own set of System V IPC identifiers and its own POSIX message queue file system.
 	// This is useful to avoid name clash of IPC identifiers. Defaults to false. It is possible to run two
@@ -1699,7 +1703,7 @@ type MountBlock struct {
 	// not available), and the unit should be written in a way that does not solely rely on this setting
 	// for security.
 	//
-	PrivateIPC bool `hcl:"private_ipc,optional" systemd:"PrivateIPC"`
+	PrivateIPC *bool `hcl:"private_ipc,optional" systemd:"PrivateIPC"`
 	// Takes a boolean parameter. If set, the processes of this unit will be run in their own private file
 	// system (mount) namespace with all mount propagation from the processes towards the host's main file
 	// system namespace turned off. This means any file system mount points established or removed by the
@@ -1727,7 +1731,7 @@ type MountBlock struct {
 	// — also enable file system namespacing in a fashion equivalent to this option. Hence it is primarily
 	// useful to explicitly request this behaviour if none of the other settings are used.
 	//
-	PrivateMounts bool `hcl:"private_mounts,optional" systemd:"PrivateMounts"`
+	PrivateMounts *bool `hcl:"private_mounts,optional" systemd:"PrivateMounts"`
 	// Takes a boolean argument. If true, sets up a new network namespace for the executed processes and
 	// configures only the loopback network device lo inside it. No other network devices will be available
 	// to the executed process. This is useful to turn off network access by the executed process. Defaults
@@ -1753,7 +1757,7 @@ type MountBlock struct {
 	// within a private network namespace. This may be combined with JoinsNamespaceOf= to listen on sockets
 	// inside of network namespaces of other services.
 	//
-	PrivateNetwork bool `hcl:"private_network,optional" systemd:"PrivateNetwork"`
+	PrivateNetwork *bool `hcl:"private_network,optional" systemd:"PrivateNetwork"`
 	// Takes a boolean argument. Defaults to false. If enabled, sets up a new PID namespace for the
 	// executed processes. Each executed process is now PID 1 - the init process - in the new namespace.
 	// /proc/ is mounted such that only processes in the PID namespace are visible. If PrivatePIDs= is set,
@@ -1844,7 +1848,7 @@ type MountBlock struct {
 	// It is recommended to turn this on for most services that do not need modify the clock or check its
 	// state.
 	//
-	ProtectClock bool `hcl:"protect_clock,optional" systemd:"ProtectClock"`
+	ProtectClock *bool `hcl:"protect_clock,optional" systemd:"ProtectClock"`
 	// Takes a boolean argument or the special values private or strict. If true, the Linux Control Groups
 	// (<citerefentry project="man-pages">
 	// <refentrytitle>cgroups</refentrytitle><manvolnum>7</manvolnum></citerefentry>) hierarchies
@@ -1913,7 +1917,7 @@ type MountBlock struct {
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> for
 	// userspace logging). The kernel exposes its log buffer to userspace via /dev/kmsg and /proc/kmsg. If
 	// enabled, these are made inaccessible to all the processes in the unit.
-	ProtectKernelLogs bool `hcl:"protect_kernel_logs,optional" systemd:"ProtectKernelLogs"`
+	ProtectKernelLogs *bool `hcl:"protect_kernel_logs,optional" systemd:"ProtectKernelLogs"`
 	// Takes a boolean argument. If true, explicit module loading will be denied. This allows module load
 	// and unload operations to be turned off on modular kernels. It is recommended to turn this on for
 	// most services that do not need special file systems or extra kernel modules to work. Defaults to
@@ -1925,7 +1929,7 @@ type MountBlock struct {
 	// user operations, both privileged and unprivileged. To disable module auto-load feature please see
 	// <citerefentry><refentrytitle>sysctl.d</refentrytitle><manvolnum>5</manvolnum></citerefentry>
 	// kernel.modules_disabled mechanism and /proc/sys/kernel/modules_disabled documentation.
-	ProtectKernelModules bool `hcl:"protect_kernel_modules,optional" systemd:"ProtectKernelModules"`
+	ProtectKernelModules *bool `hcl:"protect_kernel_modules,optional" systemd:"ProtectKernelModules"`
 	// Takes a boolean argument. If true, kernel variables accessible through /proc/sys/, /sys/,
 	// /proc/sysrq-trigger, /proc/latency_stats, /proc/acpi, /proc/timer_stats, /proc/fs and /proc/irq will
 	// be made read-only and /proc/kallsyms as well as /proc/kcore will be inaccessible to all processes of
@@ -1938,7 +1942,7 @@ type MountBlock struct {
 	// does not prevent indirect changes to kernel tunables affected by IPC calls to other processes.
 	// However, InaccessiblePaths= may be used to make relevant IPC file system objects inaccessible. If
 	// ProtectKernelTunables= is set, MountAPIVFS=yes is implied.
-	ProtectKernelTunables bool `hcl:"protect_kernel_tunables,optional" systemd:"ProtectKernelTunables"`
+	ProtectKernelTunables *bool `hcl:"protect_kernel_tunables,optional" systemd:"ProtectKernelTunables"`
 	// Takes one of noaccess, invisible, ptraceable or default (which it defaults to). When set, this
 	// controls the hidepid= mount option of the procfs instance for the unit that controls which
 	// directories with process metainformation (/proc/PID) are visible and accessible: when set to
@@ -2046,7 +2050,7 @@ type MountBlock struct {
 	// read-write mount attempt did not succeed. This corresponds with <citerefentry
 	// project="man-pages"><refentrytitle>mount</refentrytitle><manvolnum>8</manvolnum></citerefentry>'s
 	// <parameter>-w</parameter> switch. Defaults to off.
-	ReadWriteOnly bool `hcl:"read_write_only,optional" systemd:"ReadWriteOnly"`
+	ReadWriteOnly *bool `hcl:"read_write_only,optional" systemd:"ReadWriteOnly"`
 	// Sets up a new file system namespace for executed processes. These options may be used to limit
 	// access a process has to the file system. Each setting takes a space-separated list of paths relative
 	// to the host's root directory (i.e. the system running the service manager). Note that if paths
@@ -2114,7 +2118,7 @@ type MountBlock struct {
 	// POSIX shared memory segments and message queues. If multiple units use the same user or group the
 	// IPC objects are removed when the last of these units is stopped. This setting is implied if
 	// DynamicUser= is set.
-	RemoveIPC bool `hcl:"remove_ipc,optional" systemd:"RemoveIPC"`
+	RemoveIPC *bool `hcl:"remove_ipc,optional" systemd:"RemoveIPC"`
 	// Specifies which signal to use when restarting a service. The same as KillSignal= described above,
 	// with the exception that this setting is used in a restart job. Not set by default, and the value of
 	// KillSignal= is used.
@@ -2194,7 +2198,7 @@ type MountBlock struct {
 	// time for longer periods of time, and may hence be used to lock up or otherwise trigger
 	// Denial-of-Service situations on the system. It is hence recommended to restrict access to realtime
 	// scheduling to the few programs that actually require them. Defaults to off.
-	RestrictRealtime bool `hcl:"restrict_realtime,optional" systemd:"RestrictRealtime"`
+	RestrictRealtime *bool `hcl:"restrict_realtime,optional" systemd:"RestrictRealtime"`
 	// Takes a boolean argument. If set, any attempts to set the set-user-ID (SUID) or set-group-ID (SGID)
 	// bits on files or directories will be denied (for details on these bits see <citerefentry
 	// project="man-pages"><refentrytitle>inode</refentrytitle><manvolnum>7</manvolnum></citerefentry>). As
@@ -2208,7 +2212,7 @@ type MountBlock struct {
 	// <citerefentry><refentrytitle>systemd-system.conf</refentrytitle><manvolnum>5</manvolnum></citerefentry>,
 	// which defaults to off.
 	//
-	RestrictSUIDSGID bool `hcl:"restrict_suidsgid,optional" systemd:"RestrictSUIDSGID"`
+	RestrictSUIDSGID *bool `hcl:"restrict_suidsgid,optional" systemd:"RestrictSUIDSGID"`
 	// Takes a directory path relative to the host's root directory (i.e. the root of the system running
 	// the service manager). Sets the root directory for executed processes, with the <citerefentry
 	// project="man-pages"><refentrytitle>pivot_root</refentrytitle><manvolnum>2</manvolnum></citerefentry>
@@ -2251,7 +2255,7 @@ type MountBlock struct {
 	// can snapshot to make the ephemeral copy. For root images, a filesystem with support for reflinks
 	// should be used to ensure an efficient ephemeral copy.
 	//
-	RootEphemeral bool `hcl:"root_ephemeral,optional" systemd:"RootEphemeral"`
+	RootEphemeral *bool `hcl:"root_ephemeral,optional" systemd:"RootEphemeral"`
 	// Takes a data integrity (dm-verity) root hash specified in hexadecimal, or the path to a file
 	// containing a root hash in ASCII hexadecimal format. This option enables data integrity checks using
 	// dm-verity, if the used image contains the appropriate integrity data (see above) or if RootVerity=
@@ -2401,12 +2405,12 @@ type MountBlock struct {
 	// Specifies whether to send SIGHUP to remaining processes immediately after sending the signal
 	// configured with KillSignal=. This is useful to indicate to shells and shell-like programs that their
 	// connection has been severed. Takes a boolean value. Defaults to no.
-	SendSIGHUP bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
+	SendSIGHUP *bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
 	// Specifies whether to send SIGKILL (or the signal specified by FinalKillSignal=) to remaining
 	// processes after a timeout, if the normal shutdown procedure left processes of the service around.
 	// When disabled, a KillMode= of control-group or mixed service will not restart if processes from
 	// prior services exist within the control group. Takes a boolean value. Defaults to yes.
-	SendSIGKILL bool `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
+	SendSIGKILL *bool `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
 	// The SetCredential= setting is similar to LoadCredential= but accepts a literal value to use as data
 	// for the credential, instead of a file system path to read the data from. Do not use this option for
 	// data that is supposed to be secret, as it is accessible to unprivileged processes via IPC. It's only
@@ -2456,13 +2460,13 @@ type MountBlock struct {
 	// manager, no matter whether User=, DynamicUser=, or PAMName= are used or not. This option normally
 	// has no effect on services of the per-user service manager, since in that case these variables are
 	// typically inherited from user manager's own environment anyway.
-	SetLoginEnvironment bool   `hcl:"set_login_environment,optional" systemd:"SetLoginEnvironment"`
+	SetLoginEnvironment *bool  `hcl:"set_login_environment,optional" systemd:"SetLoginEnvironment"`
 	Slice               string `hcl:"slice,optional" systemd:"Slice"`
 	// Takes a boolean argument. If true, parsing of the options specified in Options= is relaxed, and
 	// unknown mount options are tolerated. This corresponds with <citerefentry
 	// project="man-pages"><refentrytitle>mount</refentrytitle><manvolnum>8</manvolnum></citerefentry>'s
 	// <parameter>-s</parameter> switch. Defaults to off.
-	SloppyOptions bool `hcl:"sloppy_options,optional" systemd:"SloppyOptions"`
+	SloppyOptions *bool `hcl:"sloppy_options,optional" systemd:"SloppyOptions"`
 	// Takes a SMACK64 security label as argument. The process executed by the unit will be started under
 	// this label and SMACK will decide whether the process is allowed to run or not, based on it. The
 	// process will continue to run under the label specified here unless the executable has its own
@@ -2674,16 +2678,16 @@ type MountBlock struct {
 	// which defaults to journal. Note that setting this parameter might result in additional dependencies
 	// to be added to the unit (see above).
 	//
-	StandardOutput            string `hcl:"standard_output,optional" systemd:"StandardOutput"`
-	StartupAllowedCPUs        string `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
-	StartupAllowedMemoryNodes string `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
-	StartupCPUWeight          uint64 `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
-	StartupIOWeight           uint64 `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
-	StartupMemoryHigh         string `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
-	StartupMemoryLow          string `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
-	StartupMemoryMax          string `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
-	StartupMemorySwapMax      string `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
-	StartupMemoryZSwapMax     string `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
+	StandardOutput            string  `hcl:"standard_output,optional" systemd:"StandardOutput"`
+	StartupAllowedCPUs        string  `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
+	StartupAllowedMemoryNodes string  `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
+	StartupCPUWeight          *uint64 `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
+	StartupIOWeight           *uint64 `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
+	StartupMemoryHigh         string  `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
+	StartupMemoryLow          string  `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
+	StartupMemoryMax          string  `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
+	StartupMemorySwapMax      string  `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
+	StartupMemoryZSwapMax     string  `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
 	// /var/lib/
 	StateDirectory []string `hcl:"state_directory,optional" systemd:"StateDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -2694,7 +2698,7 @@ type MountBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	StateDirectoryAccounting bool `hcl:"state_directory_accounting,optional" systemd:"StateDirectoryAccounting"`
+	StateDirectoryAccounting *bool `hcl:"state_directory_accounting,optional" systemd:"StateDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -2749,7 +2753,7 @@ type MountBlock struct {
 	// interpretation of these prefixes may be disabled with SyslogLevelPrefix=, see below. For details,
 	// see <citerefentry><refentrytitle>sd-daemon</refentrytitle><manvolnum>3</manvolnum></citerefentry>.
 	// Defaults to info.
-	SyslogLevel int `hcl:"syslog_level,optional" systemd:"SyslogLevel"`
+	SyslogLevel *int `hcl:"syslog_level,optional" systemd:"SyslogLevel"`
 	// Takes a boolean argument. If true and StandardOutput= or StandardError= are set to journal or kmsg
 	// (or to the same settings in combination with +console), log lines written by the executed process
 	// that are prefixed with a log level will be processed with this log level set but the prefix removed.
@@ -2758,7 +2762,7 @@ type MountBlock struct {
 	// prefixing see
 	// <citerefentry><refentrytitle>sd-daemon</refentrytitle><manvolnum>3</manvolnum></citerefentry>.
 	// Defaults to true.
-	SyslogLevelPrefix bool `hcl:"syslog_level_prefix,optional" systemd:"SyslogLevelPrefix"`
+	SyslogLevelPrefix *bool `hcl:"syslog_level_prefix,optional" systemd:"SyslogLevelPrefix"`
 	// Takes a space-separated list of architecture identifiers to include in the system call filter. The
 	// known architecture identifiers are the same as for ConditionArchitecture= described in
 	// <citerefentry><refentrytitle>systemd.unit</refentrytitle><manvolnum>5</manvolnum></citerefentry>, as
@@ -3015,26 +3019,26 @@ type MountBlock struct {
 	// Configure the size of the TTY specified with TTYPath=. If unset or set to the empty string, it is
 	// attempted to retrieve the dimensions of the terminal screen via ANSI sequences, and if that fails
 	// the kernel defaults (typically 80x24) are used.
-	TTYColumns uint64 `hcl:"tty_columns,optional" systemd:"TTYColumns"`
+	TTYColumns *uint64 `hcl:"tty_columns,optional" systemd:"TTYColumns"`
 	// Sets the terminal device node to use if standard input, output, or error are connected to a TTY (see
 	// above). Defaults to /dev/console.
 	TTYPath string `hcl:"tty_path,optional" systemd:"TTYPath"`
 	// Reset the terminal device specified with TTYPath= before and after execution. This does not erase
 	// the screen (see TTYVTDisallocate= below for that). Defaults to no.
-	TTYReset bool `hcl:"tty_reset,optional" systemd:"TTYReset"`
+	TTYReset *bool `hcl:"tty_reset,optional" systemd:"TTYReset"`
 	// Configure the size of the TTY specified with TTYPath=. If unset or set to the empty string, it is
 	// attempted to retrieve the dimensions of the terminal screen via ANSI sequences, and if that fails
 	// the kernel defaults (typically 80x24) are used.
-	TTYRows uint64 `hcl:"tty_rows,optional" systemd:"TTYRows"`
+	TTYRows *uint64 `hcl:"tty_rows,optional" systemd:"TTYRows"`
 	// Disconnect all clients which have opened the terminal device specified with TTYPath= before and
 	// after execution. Defaults to no.
-	TTYVHangup bool `hcl:"ttyv_hangup,optional" systemd:"TTYVHangup"`
+	TTYVHangup *bool `hcl:"ttyv_hangup,optional" systemd:"TTYVHangup"`
 	// If the terminal device specified with TTYPath= is a virtual console terminal, try to deallocate the
 	// TTY before and after execution. This ensures that the screen and scrollback buffer is cleared. If
 	// the terminal device is of any other type of TTY an attempt is made to clear the screen via ANSI
 	// sequences. Defaults to no.
-	TTYVTDisallocate bool   `hcl:"ttyvt_disallocate,optional" systemd:"TTYVTDisallocate"`
-	TasksAccounting  bool   `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
+	TTYVTDisallocate *bool  `hcl:"ttyvt_disallocate,optional" systemd:"TTYVTDisallocate"`
+	TasksAccounting  *bool  `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
 	TasksMax         string `hcl:"tasks_max,optional" systemd:"TasksMax"`
 	// Takes a space-separated list of mount points for temporary file systems (tmpfs). If set, a new file
 	// system namespace is set up for executed processes, and a temporary file system is mounted on each
@@ -3059,7 +3063,7 @@ type MountBlock struct {
 	// details. Takes the usual time values and defaults to infinity, i.e. by default no timeout is
 	// applied. If a timeout is configured the clean operation will be aborted forcibly when the timeout is
 	// reached, potentially leaving resources on disk.
-	TimeoutCleanSec int `hcl:"timeout_clean_sec,optional" systemd:"TimeoutCleanSec"`
+	TimeoutCleanSec *int `hcl:"timeout_clean_sec,optional" systemd:"TimeoutCleanSec"`
 	// Configures the time to wait for the mount command to finish. If a command does not exit within the
 	// configured time, the mount will be considered failed and be shut down again. All commands still
 	// running will be terminated forcibly via SIGTERM, and after another delay of this time with SIGKILL.
@@ -3068,13 +3072,13 @@ type MountBlock struct {
 	// Takes a unit-less value in seconds, or a time span value such as "5min 20s". Pass 0 to disable the
 	// timeout logic. The default value is set from DefaultTimeoutStartSec= option in
 	// <citerefentry><refentrytitle>systemd-system.conf</refentrytitle><manvolnum>5</manvolnum></citerefentry>.
-	TimeoutSec int `hcl:"timeout_sec,optional" systemd:"TimeoutSec"`
+	TimeoutSec *int `hcl:"timeout_sec,optional" systemd:"TimeoutSec"`
 	// Sets the timer slack in nanoseconds for the executed processes. The timer slack controls the
 	// accuracy of wake-ups triggered by timers. See
 	// <citerefentry><refentrytitle>prctl</refentrytitle><manvolnum>2</manvolnum></citerefentry> for more
 	// information. Note that in contrast to most other time span definitions this parameter takes an
 	// integer value in nano-seconds if no unit is specified. The usual time units are understood too.
-	TimerSlackNSec int `hcl:"timer_slack_n_sec,optional" systemd:"TimerSlackNSec"`
+	TimerSlackNSec *int `hcl:"timer_slack_n_sec,optional" systemd:"TimerSlackNSec"`
 	// Takes a string for the file system type. See <citerefentry
 	// project="man-pages"><refentrytitle>mount</refentrytitle><manvolnum>8</manvolnum></citerefentry> for
 	// details. This setting is optional.
@@ -9,22 +9,23 @@ import (
 
 // PathBlock is for [Path] systemd unit block
 //
-// A unit configuration file whose name ends in encodes information about a path monitored by systemd,
-// for path-based activation. This man page lists the configuration options specific to this unit type.
-// See for the common options of all unit configuration files. The common configuration items are
-// configured in the generic [Unit] and [Install] sections. The path specific configuration options are
-// configured in the [Path] section. For each path file, a matching unit file must exist, describing
-// the unit to activate when the path changes. By default, a service by the same name as the path
-// (except for the suffix) is activated. Example: a path file activates a matching service . The unit
-// to activate may be controlled by (see below). Internally, path units use the API to monitor file
-// systems. Due to that, it suffers by the same limitations as inotify, and for example cannot be used
-// to monitor files or directories changed by other machines on remote NFS file systems. When a service
-// unit triggered by a path unit terminates (regardless whether it exited successfully or failed),
-// monitored paths are checked immediately again, and the service accordingly restarted instantly. As
-// protection against busy looping in this trigger/start cycle, a start rate limit is enforced on the
-// service unit, see and in . Unlike other service failures, the error condition that the start rate
-// limit is hit is propagated from the service unit to the path unit and causes the path unit to fail
-// as well, thus ending the loop.
+// A unit configuration file whose name ends in .path encodes information about a path monitored by
+// systemd, for path-based activation. This man page lists the configuration options specific to this
+// unit type. See systemd.unit(5) for the common options of all unit configuration files. The common
+// configuration items are configured in the generic [Unit] and [Install] sections. The path specific
+// configuration options are configured in the [Path] section. For each path file, a matching unit file
+// must exist, describing the unit to activate when the path changes. By default, a service by the same
+// name as the path (except for the suffix) is activated. Example: a path file foo.path activates a
+// matching service foo.service. The unit to activate may be controlled by Unit= (see below).
+// Internally, path units use the inotify(7) API to monitor file systems. Due to that, it suffers by
+// the same limitations as inotify, and for example cannot be used to monitor files or directories
+// changed by other machines on remote NFS file systems. When a service unit triggered by a path unit
+// terminates (regardless whether it exited successfully or failed), monitored paths are checked
+// immediately again, and the service accordingly restarted instantly. As protection against busy
+// looping in this trigger/start cycle, a start rate limit is enforced on the service unit, see
+// StartLimitIntervalSec= and StartLimitBurst= in systemd.unit(5). Unlike other service failures, the
+// error condition that the start rate limit is hit is propagated from the service unit to the path
+// unit and causes the path unit to fail as well, thus ending the loop.
 type PathBlock struct {
 	// If MakeDirectory= is enabled, use the mode specified here to create the directories in question.
 	// Takes an access mode in octal notation. Defaults to 0755.
@@ -58,7 +59,7 @@ type PathBlock struct {
 	DirectoryNotEmpty string `hcl:"directory_not_empty,optional" systemd:"DirectoryNotEmpty"`
 	// Takes a boolean argument. If true, the directories to watch are created before watching. This option
 	// is ignored for PathExists= settings. Defaults to false.
-	MakeDirectory bool `hcl:"make_directory,optional" systemd:"MakeDirectory"`
+	MakeDirectory *bool `hcl:"make_directory,optional" systemd:"MakeDirectory"`
 	// Defines paths to monitor for certain changes: PathExists= may be used to watch the mere existence of
 	// a file or directory. If the file specified exists, the configured unit is activated. PathExistsGlob=
 	// works similarly, but checks for the existence of at least one file matching the globbing pattern
@@ -176,7 +177,7 @@ type PathBlock struct {
 	// 200. Set either to 0 to disable any form of trigger rate limiting. If the limit is hit, the unit is
 	// placed into a failure mode, and will not watch the paths anymore until restarted. Note that this
 	// limit is enforced before the service activation is enqueued.
-	TriggerLimitBurst uint64 `hcl:"trigger_limit_burst,optional" systemd:"TriggerLimitBurst"`
+	TriggerLimitBurst *uint64 `hcl:"trigger_limit_burst,optional" systemd:"TriggerLimitBurst"`
 	// Configures a limit on how often this path unit may be activated within a specific time interval. The
 	// TriggerLimitIntervalSec= may be used to configure the length of the time interval in the usual time
 	// units us, ms, s, min, h, … and defaults to 2s. See
@@ -186,7 +187,7 @@ type PathBlock struct {
 	// 200. Set either to 0 to disable any form of trigger rate limiting. If the limit is hit, the unit is
 	// placed into a failure mode, and will not watch the paths anymore until restarted. Note that this
 	// limit is enforced before the service activation is enqueued.
-	TriggerLimitIntervalSec int `hcl:"trigger_limit_interval_sec,optional" systemd:"TriggerLimitIntervalSec"`
+	TriggerLimitIntervalSec *int `hcl:"trigger_limit_interval_sec,optional" systemd:"TriggerLimitIntervalSec"`
 	// The unit to activate when any of the configured paths changes. The argument is a unit name, whose
 	// suffix is not .path. If not specified, this value defaults to a service that has the same name as
 	// the path unit, except for the suffix. (See above.) It is recommended that the unit name that is
@@ -11,30 +11,30 @@ import (
 //
 // Scope units are not configured via unit configuration files, but are only created programmatically
 // using the bus interfaces of systemd. They are named similar to filenames. A unit whose name ends in
-// refers to a scope unit. Scopes units manage a set of system processes. Unlike service units, scope
-// units manage externally created processes, and do not fork off processes on its own. The main
+// .scope refers to a scope unit. Scopes units manage a set of system processes. Unlike service units,
+// scope units manage externally created processes, and do not fork off processes on its own. The main
 // purpose of scope units is grouping worker processes of a system service for organization and for
-// managing resources. may be used to easily launch a command in a new scope unit from the command
-// line. See the for an introduction on how to make use of scope units from programs. Note that, unlike
-// service units, scope units have no "main" process: all processes in the scope are equivalent. The
-// lifecycle of the scope unit is thus not bound to the lifetime of one specific process, but to the
-// existence of at least one process in the scope. This also means that the exit statuses of these
-// processes are not relevant for the scope unit failure state. Scope units may still enter a failure
-// state, for example due to resource exhaustion or stop timeouts being reached, but not due to
-// programs inside of them terminating uncleanly. Since processes managed as scope units generally
-// remain children of the original process that forked them off, it is also the job of that process to
-// collect their exit statuses and act on them as needed.
+// managing resources. systemd-run --scope may be used to easily launch a command in a new scope unit
+// from the command line. See the New Control Group Interfaces for an introduction on how to make use
+// of scope units from programs. Note that, unlike service units, scope units have no "main" process:
+// all processes in the scope are equivalent. The lifecycle of the scope unit is thus not bound to the
+// lifetime of one specific process, but to the existence of at least one process in the scope. This
+// also means that the exit statuses of these processes are not relevant for the scope unit failure
+// state. Scope units may still enter a failure state, for example due to resource exhaustion or stop
+// timeouts being reached, but not due to programs inside of them terminating uncleanly. Since
+// processes managed as scope units generally remain children of the original process that forked them
+// off, it is also the job of that process to collect their exit statuses and act on them as needed.
 type ScopeBlock struct {
 	AllowedCPUs             string   `hcl:"allowed_cp_us,optional" systemd:"AllowedCPUs"`
 	AllowedMemoryNodes      string   `hcl:"allowed_memory_nodes,optional" systemd:"AllowedMemoryNodes"`
 	BPFProgram              []string `hcl:"bpf_program,optional" systemd:"BPFProgram"`
 	BindNetworkInterface    []string `hcl:"bind_network_interface,optional" systemd:"BindNetworkInterface"`
-	CPUPressureThresholdSec int      `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
+	CPUPressureThresholdSec *int     `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
 	CPUPressureWatch        string   `hcl:"cpu_pressure_watch,optional" systemd:"CPUPressureWatch"`
 	CPUQuota                string   `hcl:"cpu_quota,optional" systemd:"CPUQuota"`
-	CPUQuotaPeriodSec       int      `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
-	CPUWeight               uint64   `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
-	CoredumpReceive         bool     `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
+	CPUQuotaPeriodSec       *int     `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
+	CPUWeight               *uint64  `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
+	CoredumpReceive         *bool    `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
 	Delegate                string   `hcl:"delegate,optional" systemd:"Delegate"`
 	DelegateSubgroup        string   `hcl:"delegate_subgroup,optional" systemd:"DelegateSubgroup"`
 	DeviceAllow             []string `hcl:"device_allow,optional" systemd:"DeviceAllow"`
@@ -47,17 +47,17 @@ type ScopeBlock struct {
 	// achieved by configuring LimitCORE= and setting FinalKillSignal= to either SIGQUIT or SIGABRT.
 	// Defaults to SIGKILL.
 	FinalKillSignal          syscall.Signal `unitd:"final_kill_signal,optional" systemd:"FinalKillSignal"`
-	IOAccounting             bool           `hcl:"io_accounting,optional" systemd:"IOAccounting"`
+	IOAccounting             *bool          `hcl:"io_accounting,optional" systemd:"IOAccounting"`
 	IODeviceLatencyTargetSec []string       `hcl:"io_device_latency_target_sec,optional" systemd:"IODeviceLatencyTargetSec"`
 	IODeviceWeight           []string       `hcl:"io_device_weight,optional" systemd:"IODeviceWeight"`
-	IOPressureThresholdSec   int            `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
+	IOPressureThresholdSec   *int           `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
 	IOPressureWatch          string         `hcl:"io_pressure_watch,optional" systemd:"IOPressureWatch"`
 	IOReadBandwidthMax       []string       `hcl:"io_read_bandwidth_max,optional" systemd:"IOReadBandwidthMax"`
 	IOReadIOPSMax            []string       `hcl:"io_read_iops_max,optional" systemd:"IOReadIOPSMax"`
-	IOWeight                 uint64         `hcl:"io_weight,optional" systemd:"IOWeight"`
+	IOWeight                 *uint64        `hcl:"io_weight,optional" systemd:"IOWeight"`
 	IOWriteBandwidthMax      []string       `hcl:"io_write_bandwidth_max,optional" systemd:"IOWriteBandwidthMax"`
 	IOWriteIOPSMax           []string       `hcl:"io_write_iops_max,optional" systemd:"IOWriteIOPSMax"`
-	IPAccounting             bool           `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
+	IPAccounting             *bool          `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
 	IPAddressAllow           []string       `hcl:"ip_address_allow,optional" systemd:"IPAddressAllow"`
 	IPAddressDeny            []string       `hcl:"ip_address_deny,optional" systemd:"IPAddressDeny"`
 	IPEgressFilterPath       []string       `hcl:"ip_egress_filter_path,optional" systemd:"IPEgressFilterPath"`
@@ -102,20 +102,20 @@ type ScopeBlock struct {
 	//
 	KillSignal                          syscall.Signal `unitd:"kill_signal,optional" systemd:"KillSignal"`
 	ManagedOOMMemoryPressure            string         `hcl:"managed_oom_memory_pressure,optional" systemd:"ManagedOOMMemoryPressure"`
-	ManagedOOMMemoryPressureDurationSec int            `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
+	ManagedOOMMemoryPressureDurationSec *int           `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
 	ManagedOOMMemoryPressureLimit       string         `hcl:"managed_oom_memory_pressure_limit,optional" systemd:"ManagedOOMMemoryPressureLimit"`
 	ManagedOOMPreference                string         `hcl:"managed_oom_preference,optional" systemd:"ManagedOOMPreference"`
 	ManagedOOMSwap                      string         `hcl:"managed_oom_swap,optional" systemd:"ManagedOOMSwap"`
-	MemoryAccounting                    bool           `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
+	MemoryAccounting                    *bool          `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
 	MemoryHigh                          string         `hcl:"memory_high,optional" systemd:"MemoryHigh"`
 	MemoryLow                           string         `hcl:"memory_low,optional" systemd:"MemoryLow"`
 	MemoryMax                           string         `hcl:"memory_max,optional" systemd:"MemoryMax"`
 	MemoryMin                           string         `hcl:"memory_min,optional" systemd:"MemoryMin"`
-	MemoryPressureThresholdSec          int            `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
+	MemoryPressureThresholdSec          *int           `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
 	MemoryPressureWatch                 string         `hcl:"memory_pressure_watch,optional" systemd:"MemoryPressureWatch"`
 	MemorySwapMax                       string         `hcl:"memory_swap_max,optional" systemd:"MemorySwapMax"`
 	MemoryZSwapMax                      string         `hcl:"memory_z_swap_max,optional" systemd:"MemoryZSwapMax"`
-	MemoryZSwapWriteback                bool           `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
+	MemoryZSwapWriteback                *bool          `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
 	NFTSet                              []string       `hcl:"nft_set,optional" systemd:"NFTSet"`
 	OOMPolicy                           string         `hcl:"oom_policy,optional" systemd:"OOMPolicy"`
 	// Specifies which signal to use when restarting a service. The same as KillSignal= described above,
@@ -126,35 +126,35 @@ type ScopeBlock struct {
 	// Configures a maximum time for the scope to run. If this is used and the scope has been active for
 	// longer than the specified time it is terminated and put into a failure state. Pass infinity (the
 	// default) to configure no runtime limit.
-	RuntimeMaxSec int `hcl:"runtime_max_sec,optional" systemd:"RuntimeMaxSec"`
+	RuntimeMaxSec *int `hcl:"runtime_max_sec,optional" systemd:"RuntimeMaxSec"`
 	// This option modifies RuntimeMaxSec= by increasing the maximum runtime by an evenly distributed
 	// duration between 0 and the specified value (in seconds). If RuntimeMaxSec= is unspecified, then this
 	// feature will be disabled.
-	RuntimeRandomizedExtraSec int `hcl:"runtime_randomized_extra_sec,optional" systemd:"RuntimeRandomizedExtraSec"`
+	RuntimeRandomizedExtraSec *int `hcl:"runtime_randomized_extra_sec,optional" systemd:"RuntimeRandomizedExtraSec"`
 	// Specifies whether to send SIGHUP to remaining processes immediately after sending the signal
 	// configured with KillSignal=. This is useful to indicate to shells and shell-like programs that their
 	// connection has been severed. Takes a boolean value. Defaults to no.
-	SendSIGHUP bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
+	SendSIGHUP *bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
 	// Specifies whether to send SIGKILL (or the signal specified by FinalKillSignal=) to remaining
 	// processes after a timeout, if the normal shutdown procedure left processes of the service around.
 	// When disabled, a KillMode= of control-group or mixed service will not restart if processes from
 	// prior services exist within the control group. Takes a boolean value. Defaults to yes.
-	SendSIGKILL               bool     `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
+	SendSIGKILL               *bool    `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
 	Slice                     string   `hcl:"slice,optional" systemd:"Slice"`
 	SocketBindAllow           []string `hcl:"socket_bind_allow,optional" systemd:"SocketBindAllow"`
 	SocketBindDeny            []string `hcl:"socket_bind_deny,optional" systemd:"SocketBindDeny"`
 	StartupAllowedCPUs        string   `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
 	StartupAllowedMemoryNodes string   `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
-	StartupCPUWeight          uint64   `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
-	StartupIOWeight           uint64   `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
+	StartupCPUWeight          *uint64  `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
+	StartupIOWeight           *uint64  `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
 	StartupMemoryHigh         string   `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
 	StartupMemoryLow          string   `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
 	StartupMemoryMax          string   `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
 	StartupMemorySwapMax      string   `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
 	StartupMemoryZSwapMax     string   `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
-	TasksAccounting           bool     `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
+	TasksAccounting           *bool    `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
 	TasksMax                  string   `hcl:"tasks_max,optional" systemd:"TasksMax"`
-	TimeoutStopSec            int      `hcl:"timeout_stop_sec,optional" systemd:"TimeoutStopSec"`
+	TimeoutStopSec            *int     `hcl:"timeout_stop_sec,optional" systemd:"TimeoutStopSec"`
 	// Specifies which signal to use to terminate the service when the watchdog timeout expires (enabled
 	// through WatchdogSec=). Defaults to SIGABRT.
 	WatchdogSignal syscall.Signal `unitd:"watchdog_signal,optional" systemd:"WatchdogSignal"`
@@ -10,15 +10,16 @@ import (
 
 // ServiceBlock is for [Service] systemd unit block
 //
-// A unit configuration file whose name ends in encodes information about a process controlled and
-// supervised by systemd. This man page lists the configuration options specific to this unit type. See
-// for the common options of all unit configuration files. The common configuration items are
-// configured in the generic [Unit] and [Install] sections. The service specific configuration options
-// are configured in the [Service] section. Additional options are listed in , which define the
-// execution environment the commands are executed in, and in , which define the way the processes of
-// the service are terminated, and in , which configure resource control settings for the processes of
-// the service. The command allows creating and units dynamically and transiently from the command
-// line.
+// A unit configuration file whose name ends in .service encodes information about a process controlled
+// and supervised by systemd. This man page lists the configuration options specific to this unit type.
+// See systemd.unit(5) for the common options of all unit configuration files. The common configuration
+// items are configured in the generic [Unit] and [Install] sections. The service specific
+// configuration options are configured in the [Service] section. Additional options are listed in
+// systemd.exec(5), which define the execution environment the commands are executed in, and in
+// systemd.kill(5), which define the way the processes of the service are terminated, and in
+// systemd.resource-control(5), which configure resource control settings for the processes of the
+// service. The systemd-run(1) command allows creating .service and .scope units dynamically and
+// transiently from the command line.
 type ServiceBlock struct {
 	AllowedCPUs        string `hcl:"allowed_cp_us,optional" systemd:"AllowedCPUs"`
 	AllowedMemoryNodes string `hcl:"allowed_memory_nodes,optional" systemd:"AllowedMemoryNodes"`
@@ -82,7 +83,7 @@ type ServiceBlock struct {
 	// This option is implied when LogNamespace= is used, when MountAPIVFS=yes, or when PrivateDevices=yes
 	// is used in conjunction with either RootDirectory= or RootImage=.
 	//
-	BindLogSockets       bool     `hcl:"bind_log_sockets,optional" systemd:"BindLogSockets"`
+	BindLogSockets       *bool    `hcl:"bind_log_sockets,optional" systemd:"BindLogSockets"`
 	BindNetworkInterface []string `hcl:"bind_network_interface,optional" systemd:"BindNetworkInterface"`
 	// Configures unit-specific bind mounts. A bind mount makes a particular file or directory available at
 	// an additional place in the unit's view of the file system. Any bind mounts created with this option
@@ -160,10 +161,10 @@ type ServiceBlock struct {
 	// project="man-pages"><refentrytitle>sched_setaffinity</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details.
 	CPUAffinity             string `hcl:"cpu_affinity,optional" systemd:"CPUAffinity"`
-	CPUPressureThresholdSec int    `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
+	CPUPressureThresholdSec *int   `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
 	CPUPressureWatch        string `hcl:"cpu_pressure_watch,optional" systemd:"CPUPressureWatch"`
 	CPUQuota                string `hcl:"cpu_quota,optional" systemd:"CPUQuota"`
-	CPUQuotaPeriodSec       int    `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
+	CPUQuotaPeriodSec       *int   `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
 	// Sets the CPU scheduling policy for executed processes. Takes one of other, batch, idle, fifo, rr or
 	// ext. See <citerefentry
 	// project="man-pages"><refentrytitle>sched_setscheduler</refentrytitle><manvolnum>2</manvolnum></citerefentry>
@@ -183,8 +184,8 @@ type ServiceBlock struct {
 	// can hence not leak into child processes. See <citerefentry
 	// project="man-pages"><refentrytitle>sched_setscheduler</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details. Defaults to false.
-	CPUSchedulingResetOnFork bool   `hcl:"cpu_scheduling_reset_on_fork,optional" systemd:"CPUSchedulingResetOnFork"`
-	CPUWeight                uint64 `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
+	CPUSchedulingResetOnFork *bool   `hcl:"cpu_scheduling_reset_on_fork,optional" systemd:"CPUSchedulingResetOnFork"`
+	CPUWeight                *uint64 `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
 	// /var/cache/
 	CacheDirectory []string `hcl:"cache_directory,optional" systemd:"CacheDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -195,7 +196,7 @@ type ServiceBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	CacheDirectoryAccounting bool `hcl:"cache_directory_accounting,optional" systemd:"CacheDirectoryAccounting"`
+	CacheDirectoryAccounting *bool `hcl:"cache_directory_accounting,optional" systemd:"CacheDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -265,7 +266,7 @@ type ServiceBlock struct {
 	// the meaning of the mapping types. When specified multiple times, all specified masks are ORed. When
 	// not set, or if the empty value is assigned, the inherited value is not changed.
 	CoredumpFilter  string `hcl:"coredump_filter,optional" systemd:"CoredumpFilter"`
-	CoredumpReceive bool   `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
+	CoredumpReceive *bool  `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
 	Delegate        string `hcl:"delegate,optional" systemd:"Delegate"`
 	// Delegates ownership of the given namespace types to the user namespace of the processes of this
 	// unit. For details about Linux namespaces, see <citerefentry
@@ -347,7 +348,7 @@ type ServiceBlock struct {
 	// and access guarantees of the service. Note that this option is currently incompatible with D-Bus
 	// policies, thus a service using this option may currently not allocate a D-Bus service name (note
 	// that this does not affect calling into other D-Bus services). Defaults to off.
-	DynamicUser bool `hcl:"dynamic_user,optional" systemd:"DynamicUser"`
+	DynamicUser *bool `hcl:"dynamic_user,optional" systemd:"DynamicUser"`
 	// Sets environment variables for executed processes. Each line is unquoted using the rules described
 	// in "Quoting" section in
 	// <citerefentry><refentrytitle>systemd.syntax</refentrytitle><manvolnum>7</manvolnum></citerefentry>
@@ -381,7 +382,7 @@ type ServiceBlock struct {
 	// have access to the secret data. Use LoadCredential=, LoadCredentialEncrypted= or
 	// SetCredentialEncrypted= (see below) to pass data to unit processes securely.
 	//
-	Environment []string `hcl:"environment,optional" systemd:"Environment"`
+	Environment map[string]string `hcl:"environment,optional" systemd:"Environment"`
 	// Similar to Environment=, but reads the environment variables from a text file. The text file should
 	// contain newline-separated variable assignments. Empty lines, lines without an = separator, or lines
 	// starting with ; or # will be ignored, which may be used for commenting. The file must be encoded
@@ -431,7 +432,7 @@ type ServiceBlock struct {
 	// twice from these files, the files will be read in the order they are specified and the later setting
 	// will override the earlier setting.
 	//
-	EnvironmentFile string `hcl:"environment_file,optional" systemd:"EnvironmentFile"`
+	EnvironmentFile []string `hcl:"environment_file,optional" systemd:"EnvironmentFile"`
 	// Optional commands that are executed before the commands in ExecStartPre=. Syntax is the same as for
 	// ExecStart=. Multiple command lines are allowed, regardless of the service type (i.e. Type=), and the
 	// commands are executed one after the other, serially.
@@ -447,7 +448,7 @@ type ServiceBlock struct {
 	// ExecCondition=. ExecCondition= will also run the commands in ExecStopPost=, as part of stopping the
 	// service, in the case of any non-zero or abnormal exits, like the ones described above.
 	//
-	ExecCondition string `hcl:"exec_condition,optional" systemd:"ExecCondition"`
+	ExecCondition []string `hcl:"exec_condition,optional" systemd:"ExecCondition"`
 	// Sets up a new file system namespace for executed processes. These options may be used to limit
 	// access a process has to the file system. Each setting takes a space-separated list of paths relative
 	// to the host's root directory (i.e. the system running the service manager). Note that if paths
@@ -530,10 +531,10 @@ type ServiceBlock struct {
 	// is received before ExecReload= completes, the signaling is skipped and the service manager
 	// immediately starts listening for READY=1.
 	//
-	ExecReload string `hcl:"exec_reload,optional" systemd:"ExecReload"`
+	ExecReload []string `hcl:"exec_reload,optional" systemd:"ExecReload"`
 	// Commands to execute after a successful reload operation. Syntax for this setting is exactly the same
 	// as ExecReload=.
-	ExecReloadPost string `hcl:"exec_reload_post,optional" systemd:"ExecReloadPost"`
+	ExecReloadPost []string `hcl:"exec_reload_post,optional" systemd:"ExecReloadPost"`
 	// Takes a colon separated list of absolute paths relative to which the executable used by the Exec*=
 	// (e.g. ExecStart=, ExecStop=, etc.) properties can be found. ExecSearchPath= overrides $PATH if $PATH
 	// is not supplied by the user through Environment=, EnvironmentFile= or PassEnvironment=. Assigning an
@@ -555,7 +556,7 @@ type ServiceBlock struct {
 	// Unless Type=forking is set, the process started via this command line will be considered the main
 	// process of the daemon.
 	//
-	ExecStart string `hcl:"exec_start,optional" systemd:"ExecStart"`
+	ExecStart []string `hcl:"exec_start,optional" systemd:"ExecStart"`
 	// Additional commands that are executed before or after the command in ExecStart=, respectively.
 	// Syntax is the same as for ExecStart=. Multiple command lines are allowed, regardless of the service
 	// type (i.e. Type=), and the commands are executed one after the other, serially.
@@ -582,7 +583,7 @@ type ServiceBlock struct {
 	// Note that the execution of ExecStartPost= is taken into account for the purpose of Before=/After=
 	// ordering constraints.
 	//
-	ExecStartPost string `hcl:"exec_start_post,optional" systemd:"ExecStartPost"`
+	ExecStartPost []string `hcl:"exec_start_post,optional" systemd:"ExecStartPost"`
 	// Additional commands that are executed before or after the command in ExecStart=, respectively.
 	// Syntax is the same as for ExecStart=. Multiple command lines are allowed, regardless of the service
 	// type (i.e. Type=), and the commands are executed one after the other, serially.
@@ -609,7 +610,7 @@ type ServiceBlock struct {
 	// Note that the execution of ExecStartPost= is taken into account for the purpose of Before=/After=
 	// ordering constraints.
 	//
-	ExecStartPre string `hcl:"exec_start_pre,optional" systemd:"ExecStartPre"`
+	ExecStartPre []string `hcl:"exec_start_pre,optional" systemd:"ExecStartPre"`
 	// Commands to execute to stop the service started via ExecStart=. This argument takes multiple command
 	// lines, following the same scheme as described for ExecStart= above. Use of this setting is optional.
 	// After the commands configured in this option are run, it is implied that the service is stopped, and
@@ -642,7 +643,7 @@ type ServiceBlock struct {
 	// It is recommended to use this setting for commands that communicate with the service requesting
 	// clean termination. For post-mortem clean-up steps use ExecStopPost= instead.
 	//
-	ExecStop string `hcl:"exec_stop,optional" systemd:"ExecStop"`
+	ExecStop []string `hcl:"exec_stop,optional" systemd:"ExecStop"`
 	// Additional commands that are executed after the service is stopped. This includes cases where the
 	// commands configured in ExecStop= were used, where the service does not have any ExecStop= defined,
 	// or where the service exited unexpectedly. This argument takes multiple command lines, following the
@@ -665,7 +666,7 @@ type ServiceBlock struct {
 	// Note that the execution of ExecStopPost= is taken into account for the purpose of Before=/After=
 	// ordering constraints.
 	//
-	ExecStopPost string `hcl:"exec_stop_post,optional" systemd:"ExecStopPost"`
+	ExecStopPost []string `hcl:"exec_stop_post,optional" systemd:"ExecStopPost"`
 	// Specifies when the manager should consider the service to be finished. One of main or cgroup:
 	//
 	// It is generally recommended to use ExitType=main when a service has a known forking model and a main
@@ -674,7 +675,7 @@ type ServiceBlock struct {
 	// transient or automatically generated services, such as graphical applications inside of a desktop
 	// environment.
 	//
-	ExitType int `hcl:"exit_type,optional" systemd:"ExitType"`
+	ExitType ServiceExitType `hcl:"exit_type,optional" systemd:"ExitType"`
 	// This setting is similar to BindReadOnlyPaths= in that it mounts a file system hierarchy from a
 	// directory, but instead of providing a destination path, an overlay will be set up. This option
 	// expects a whitespace separated list of source directories.
@@ -768,8 +769,8 @@ type ServiceBlock struct {
 	// for the details about DevicePolicy= or DeviceAllow=. Also, see PrivateDevices= below, as it may
 	// change the setting of DevicePolicy=.
 	//
-	ExtensionImages []string `hcl:"extension_images,optional" systemd:"ExtensionImages"`
-	FailureAction   string   `hcl:"failure_action,optional" systemd:"FailureAction"`
+	ExtensionImages []string        `hcl:"extension_images,optional" systemd:"ExtensionImages"`
+	FailureAction   EmergencyAction `hcl:"failure_action,optional" systemd:"FailureAction"`
 	// Configure how many file descriptors may be stored in the service manager for the service using
 	// <citerefentry><refentrytitle>sd_pid_notify_with_fds</refentrytitle><manvolnum>3</manvolnum></citerefentry>'s
 	// FDSTORE=1 messages. This is useful for implementing services that can restart after an explicit
@@ -806,7 +807,7 @@ type ServiceBlock struct {
 	// For further information on the file descriptor store see the <ulink
 	// url="https://systemd.io/FILE_DESCRIPTOR_STORE">File Descriptor Store</ulink> overview.
 	//
-	FileDescriptorStoreMax uint64 `hcl:"file_descriptor_store_max,optional" systemd:"FileDescriptorStoreMax"`
+	FileDescriptorStoreMax *uint64 `hcl:"file_descriptor_store_max,optional" systemd:"FileDescriptorStoreMax"`
 	// Takes one of no, yes, restart and controls when to release the service's file descriptor store (i.e.
 	// when to close the contained file descriptors, if any). If set to no the file descriptor store is
 	// automatically released when the service is stopped; if restart (the default) it is kept around as
@@ -864,11 +865,11 @@ type ServiceBlock struct {
 	// always known. The guessing algorithm might come to incorrect conclusions if a daemon consists of
 	// more than one process. If the main PID cannot be determined, failure detection and automatic
 	// restarting of a service will not work reliably. Defaults to yes.
-	GuessMainPID             bool     `hcl:"guess_main_pid,optional" systemd:"GuessMainPID"`
-	IOAccounting             bool     `hcl:"io_accounting,optional" systemd:"IOAccounting"`
+	GuessMainPID             *bool    `hcl:"guess_main_pid,optional" systemd:"GuessMainPID"`
+	IOAccounting             *bool    `hcl:"io_accounting,optional" systemd:"IOAccounting"`
 	IODeviceLatencyTargetSec []string `hcl:"io_device_latency_target_sec,optional" systemd:"IODeviceLatencyTargetSec"`
 	IODeviceWeight           []string `hcl:"io_device_weight,optional" systemd:"IODeviceWeight"`
-	IOPressureThresholdSec   int      `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
+	IOPressureThresholdSec   *int     `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
 	IOPressureWatch          string   `hcl:"io_pressure_watch,optional" systemd:"IOPressureWatch"`
 	IOReadBandwidthMax       []string `hcl:"io_read_bandwidth_max,optional" systemd:"IOReadBandwidthMax"`
 	IOReadIOPSMax            []string `hcl:"io_read_iops_max,optional" systemd:"IOReadIOPSMax"`
@@ -878,7 +879,7 @@ type ServiceBlock struct {
 	// IOSchedulingPriority= have no effect. See
 	// <citerefentry><refentrytitle>ioprio_set</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details.
-	IOSchedulingClass string `hcl:"io_scheduling_class,optional" systemd:"IOSchedulingClass"`
+	IOSchedulingClass IOClass `hcl:"io_scheduling_class,optional" systemd:"IOSchedulingClass"`
 	// Sets the I/O scheduling priority for executed processes. Takes an integer between 0 (highest
 	// priority) and 7 (lowest priority). In case of I/O contention, smaller values mean more I/O bandwidth
 	// is made available to the unit's processes, larger values mean less bandwidth. The available
@@ -887,11 +888,11 @@ type ServiceBlock struct {
 	// effect. For the kernel's default scheduling class (best-effort) this defaults to 4. See
 	// <citerefentry><refentrytitle>ioprio_set</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details.
-	IOSchedulingPriority int      `hcl:"io_scheduling_priority,optional" systemd:"IOSchedulingPriority"`
-	IOWeight             uint64   `hcl:"io_weight,optional" systemd:"IOWeight"`
+	IOSchedulingPriority *int     `hcl:"io_scheduling_priority,optional" systemd:"IOSchedulingPriority"`
+	IOWeight             *uint64  `hcl:"io_weight,optional" systemd:"IOWeight"`
 	IOWriteBandwidthMax  []string `hcl:"io_write_bandwidth_max,optional" systemd:"IOWriteBandwidthMax"`
 	IOWriteIOPSMax       []string `hcl:"io_write_iops_max,optional" systemd:"IOWriteIOPSMax"`
-	IPAccounting         bool     `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
+	IPAccounting         *bool    `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
 	IPAddressAllow       []string `hcl:"ip_address_allow,optional" systemd:"IPAddressAllow"`
 	IPAddressDeny        []string `hcl:"ip_address_deny,optional" systemd:"IPAddressDeny"`
 	// Takes an absolute file system path referring to a Linux IPC namespace pseudo-file (i.e. a file like
@@ -906,7 +907,7 @@ type ServiceBlock struct {
 	IPIngressFilterPath []string `hcl:"ip_ingress_filter_path,optional" systemd:"IPIngressFilterPath"`
 	// Takes a boolean argument. If true, SIGPIPE is ignored in the executed process. Defaults to true
 	// since SIGPIPE is generally only useful in shell pipelines.
-	IgnoreSIGPIPE bool `hcl:"ignore_sigpipe,optional" systemd:"IgnoreSIGPIPE"`
+	IgnoreSIGPIPE *bool `hcl:"ignore_sigpipe,optional" systemd:"IgnoreSIGPIPE"`
 	// Pass one or more credentials to the unit. Takes a credential name for which we will attempt to find
 	// a credential that the service manager itself received under the specified name — which may be used
 	// to propagate credentials from an invoking environment (e.g. a container manager that invoked the
@@ -1340,7 +1341,7 @@ type ServiceBlock struct {
 	// system call so that the kernel execution domain may not be changed from the default or the
 	// personality selected with Personality= directive. This may be useful to improve security, because
 	// odd personality emulations may be poorly tested and source of vulnerabilities.
-	LockPersonality bool `hcl:"lock_personality,optional" systemd:"LockPersonality"`
+	LockPersonality *bool `hcl:"lock_personality,optional" systemd:"LockPersonality"`
 	// Configures additional log metadata fields to include in all log records generated by processes
 	// associated with this unit, including systemd. This setting takes one or more journal field
 	// assignments in the format FIELD=VALUE separated by whitespace. See
@@ -1404,7 +1405,7 @@ type ServiceBlock struct {
 	// might prohibit messages of higher log levels to be stored on disk, even though the per-unit
 	// LogLevelMax= permitted it to be processed.
 	//
-	LogLevelMax int `hcl:"log_level_max,optional" systemd:"LogLevelMax"`
+	LogLevelMax *int `hcl:"log_level_max,optional" systemd:"LogLevelMax"`
 	// Run the unit's processes in the specified journal namespace. Expects a short user-defined string
 	// identifying the namespace. If not used the processes of the service are run in the default journal
 	// namespace, i.e. their log stream is collected and processed by systemd-journald.service. If this
@@ -1446,7 +1447,7 @@ type ServiceBlock struct {
 	// enforced for messages generated via <citerefentry
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> and
 	// similar functions).
-	LogRateLimitBurst uint64 `hcl:"log_rate_limit_burst,optional" systemd:"LogRateLimitBurst"`
+	LogRateLimitBurst *uint64 `hcl:"log_rate_limit_burst,optional" systemd:"LogRateLimitBurst"`
 	// Configures the rate limiting that is applied to log messages generated by this unit. If, in the time
 	// interval defined by LogRateLimitIntervalSec=, more messages than specified in LogRateLimitBurst= are
 	// logged by a service, all further messages within the interval are dropped until the interval is
@@ -1462,7 +1463,7 @@ type ServiceBlock struct {
 	// enforced for messages generated via <citerefentry
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> and
 	// similar functions).
-	LogRateLimitIntervalSec int `hcl:"log_rate_limit_interval_sec,optional" systemd:"LogRateLimitIntervalSec"`
+	LogRateLimitIntervalSec *int `hcl:"log_rate_limit_interval_sec,optional" systemd:"LogRateLimitIntervalSec"`
 	// /var/log/
 	LogsDirectory []string `hcl:"logs_directory,optional" systemd:"LogsDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -1473,7 +1474,7 @@ type ServiceBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	LogsDirectoryAccounting bool `hcl:"logs_directory_accounting,optional" systemd:"LogsDirectoryAccounting"`
+	LogsDirectoryAccounting *bool `hcl:"logs_directory_accounting,optional" systemd:"LogsDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -1499,11 +1500,11 @@ type ServiceBlock struct {
 	//
 	LogsDirectoryQuota                  string `hcl:"logs_directory_quota,optional" systemd:"LogsDirectoryQuota"`
 	ManagedOOMMemoryPressure            string `hcl:"managed_oom_memory_pressure,optional" systemd:"ManagedOOMMemoryPressure"`
-	ManagedOOMMemoryPressureDurationSec int    `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
+	ManagedOOMMemoryPressureDurationSec *int   `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
 	ManagedOOMMemoryPressureLimit       string `hcl:"managed_oom_memory_pressure_limit,optional" systemd:"ManagedOOMMemoryPressureLimit"`
 	ManagedOOMPreference                string `hcl:"managed_oom_preference,optional" systemd:"ManagedOOMPreference"`
 	ManagedOOMSwap                      string `hcl:"managed_oom_swap,optional" systemd:"ManagedOOMSwap"`
-	MemoryAccounting                    bool   `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
+	MemoryAccounting                    *bool  `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
 	// Takes a boolean argument. If set, attempts to create memory mappings that are writable and
 	// executable at the same time, or to change existing memory mappings to become executable, or mapping
 	// shared memory segments as executable, are prohibited. Specifically, a system call filter is added
@@ -1528,7 +1529,7 @@ type ServiceBlock struct {
 	// recommended to turn off alternative ABIs for services, so that they cannot be used to circumvent the
 	// restrictions of this option. Specifically, it is recommended to combine this option with
 	// SystemCallArchitectures=native or similar.
-	MemoryDenyWriteExecute bool   `hcl:"memory_deny_write_execute,optional" systemd:"MemoryDenyWriteExecute"`
+	MemoryDenyWriteExecute *bool  `hcl:"memory_deny_write_execute,optional" systemd:"MemoryDenyWriteExecute"`
 	MemoryHigh             string `hcl:"memory_high,optional" systemd:"MemoryHigh"`
 	// Takes a boolean argument. When set, it enables KSM (kernel samepage merging) for the processes. KSM
 	// is a memory-saving de-duplication feature. Anonymous memory pages with identical content can be
@@ -1541,11 +1542,11 @@ type ServiceBlock struct {
 	// or the kernel does not support controlling KSM at the process level through
 	// <citerefentry><refentrytitle>prctl</refentrytitle><manvolnum>2</manvolnum></citerefentry>.
 	//
-	MemoryKSM                  bool   `hcl:"memory_ksm,optional" systemd:"MemoryKSM"`
+	MemoryKSM                  *bool  `hcl:"memory_ksm,optional" systemd:"MemoryKSM"`
 	MemoryLow                  string `hcl:"memory_low,optional" systemd:"MemoryLow"`
 	MemoryMax                  string `hcl:"memory_max,optional" systemd:"MemoryMax"`
 	MemoryMin                  string `hcl:"memory_min,optional" systemd:"MemoryMin"`
-	MemoryPressureThresholdSec int    `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
+	MemoryPressureThresholdSec *int   `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
 	MemoryPressureWatch        string `hcl:"memory_pressure_watch,optional" systemd:"MemoryPressureWatch"`
 	MemorySwapMax              string `hcl:"memory_swap_max,optional" systemd:"MemorySwapMax"`
 	// Transparent Hugepages (THPs) is a Linux kernel feature that manages memory using larger pages (2MB
@@ -1576,7 +1577,7 @@ type ServiceBlock struct {
 	//
 	MemoryTHP            string `hcl:"memory_thp,optional" systemd:"MemoryTHP"`
 	MemoryZSwapMax       string `hcl:"memory_z_swap_max,optional" systemd:"MemoryZSwapMax"`
-	MemoryZSwapWriteback bool   `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
+	MemoryZSwapWriteback *bool  `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
 	// Takes a boolean argument. If on, a private mount namespace for the unit's processes is created and
 	// the API file systems /proc/, /sys/, /dev/ and /run/ (as an empty tmpfs) are mounted inside of it,
 	// unless they are already mounted. Note that this option has no effect unless used in conjunction with
@@ -1590,7 +1591,7 @@ type ServiceBlock struct {
 	// host will be used to set up new mounts, and /run/host/incoming/ in the private namespace will be
 	// used as an intermediate step to store them before being moved to the final mount point.
 	//
-	MountAPIVFS bool `hcl:"mount_apivfs,optional" systemd:"MountAPIVFS"`
+	MountAPIVFS *bool `hcl:"mount_apivfs,optional" systemd:"MountAPIVFS"`
 	// Takes a mount propagation setting: shared, slave or private, which controls whether file system
 	// mount points in the file system namespaces set up for this unit's processes will receive or
 	// propagate mounts and unmounts from other file system namespaces. See <citerefentry
@@ -1770,7 +1771,7 @@ type ServiceBlock struct {
 	// <citerefentry><refentrytitle>systemd-run</refentrytitle><manvolnum>1</manvolnum></citerefentry>, or
 	// arbitrary IPC services.
 	//
-	NoNewPrivileges bool `hcl:"no_new_privileges,optional" systemd:"NoNewPrivileges"`
+	NoNewPrivileges *bool `hcl:"no_new_privileges,optional" systemd:"NoNewPrivileges"`
 	// Set the O_NONBLOCK flag for all file descriptors passed via socket-based activation. If true, all
 	// file descriptors &gt;= 3 (i.e. all except stdin, stdout, stderr), excluding those passed in via the
 	// file descriptor storage logic (see FileDescriptorStoreMax= for details), will have the O_NONBLOCK
@@ -1788,7 +1789,7 @@ type ServiceBlock struct {
 	// is essential that all services sharing the same socket use the same NonBlocking= configuration, and
 	// do not change the flag in service code either.
 	//
-	NonBlocking bool `hcl:"non_blocking,optional" systemd:"NonBlocking"`
+	NonBlocking *bool `hcl:"non_blocking,optional" systemd:"NonBlocking"`
 	// Controls access to the service status notification socket, as accessible via the
 	// <citerefentry><refentrytitle>sd_notify</refentrytitle><manvolnum>3</manvolnum></citerefentry> call.
 	// Takes one of none (the default), main, exec or all. If none, no daemon status updates are accepted
@@ -1951,7 +1952,7 @@ type ServiceBlock struct {
 	// for details about environment variables.
 	//
 	PassEnvironment      []string `hcl:"pass_environment,optional" systemd:"PassEnvironment"`
-	PermissionsStartOnly bool     `hcl:"permissions_start_only,optional" systemd:"PermissionsStartOnly"`
+	PermissionsStartOnly *bool    `hcl:"permissions_start_only,optional" systemd:"PermissionsStartOnly"`
 	// Controls which kernel architecture <citerefentry
 	// project="man-pages"><refentrytitle>uname</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// shall report, when invoked by unit processes. Takes one of the architecture identifiers arm64,
@@ -2005,7 +2006,7 @@ type ServiceBlock struct {
 	// instead. See
 	// <citerefentry><refentrytitle>systemd.resource-control</refentrytitle><manvolnum>5</manvolnum></citerefentry>.
 	//
-	PrivateDevices bool `hcl:"private_devices,optional" systemd:"PrivateDevices"`
+	PrivateDevices *bool `hcl:"private_devices,optional" systemd:"PrivateDevices"`
 	// Takes a boolean argument. If true, sets up a new IPC namespace for the executed processes. Each IPC
 	// namespace has its own set of System V IPC identifiers and its own POSIX message queue file system.
 	// This is useful to avoid name clash of IPC identifiers. Defaults to false. It is possible to run two
@@ -2026,7 +2027,7 @@ type ServiceBlock struct {
 	// not available), and the unit should be written in a way that does not solely rely on this setting
 	// for security.
 	//
-	PrivateIPC bool `hcl:"private_ipc,optional" systemd:"PrivateIPC"`
+	PrivateIPC *bool `hcl:"private_ipc,optional" systemd:"PrivateIPC"`
 	// Takes a boolean parameter. If set, the processes of this unit will be run in their own private file
 	// system (mount) namespace with all mount propagation from the processes towards the host's main file
 	// system namespace turned off. This means any file system mount points established or removed by the
@@ -2054,7 +2055,7 @@ type ServiceBlock struct {
 	// — also enable file system namespacing in a fashion equivalent to this option. Hence it is primarily
 	// useful to explicitly request this behaviour if none of the other settings are used.
 	//
-	PrivateMounts bool `hcl:"private_mounts,optional" systemd:"PrivateMounts"`
+	PrivateMounts *bool `hcl:"private_mounts,optional" systemd:"PrivateMounts"`
 	// Takes a boolean argument. If true, sets up a new network namespace for the executed processes and
 	// configures only the loopback network device lo inside it. No other network devices will be available
 	// to the executed process. This is useful to turn off network access by the executed process. Defaults
@@ -2080,7 +2081,7 @@ type ServiceBlock struct {
 	// within a private network namespace. This may be combined with JoinsNamespaceOf= to listen on sockets
 	// inside of network namespaces of other services.
 	//
-	PrivateNetwork bool `hcl:"private_network,optional" systemd:"PrivateNetwork"`
+	PrivateNetwork *bool `hcl:"private_network,optional" systemd:"PrivateNetwork"`
 	// Takes a boolean argument. Defaults to false. If enabled, sets up a new PID namespace for the
 	// executed processes. Each executed process is now PID 1 - the init process - in the new namespace.
 	// /proc/ is mounted such that only processes in the PID namespace are visible. If PrivatePIDs= is set,
@@ -2171,7 +2172,7 @@ type ServiceBlock struct {
 	// It is recommended to turn this on for most services that do not need modify the clock or check its
 	// state.
 	//
-	ProtectClock bool `hcl:"protect_clock,optional" systemd:"ProtectClock"`
+	ProtectClock *bool `hcl:"protect_clock,optional" systemd:"ProtectClock"`
 	// Takes a boolean argument or the special values private or strict. If true, the Linux Control Groups
 	// (<citerefentry project="man-pages">
 	// <refentrytitle>cgroups</refentrytitle><manvolnum>7</manvolnum></citerefentry>) hierarchies
@@ -2240,7 +2241,7 @@ type ServiceBlock struct {
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> for
 	// userspace logging). The kernel exposes its log buffer to userspace via /dev/kmsg and /proc/kmsg. If
 	// enabled, these are made inaccessible to all the processes in the unit.
-	ProtectKernelLogs bool `hcl:"protect_kernel_logs,optional" systemd:"ProtectKernelLogs"`
+	ProtectKernelLogs *bool `hcl:"protect_kernel_logs,optional" systemd:"ProtectKernelLogs"`
 	// Takes a boolean argument. If true, explicit module loading will be denied. This allows module load
 	// and unload operations to be turned off on modular kernels. It is recommended to turn this on for
 	// most services that do not need special file systems or extra kernel modules to work. Defaults to
@@ -2252,7 +2253,7 @@ type ServiceBlock struct {
 	// user operations, both privileged and unprivileged. To disable module auto-load feature please see
 	// <citerefentry><refentrytitle>sysctl.d</refentrytitle><manvolnum>5</manvolnum></citerefentry>
 	// kernel.modules_disabled mechanism and /proc/sys/kernel/modules_disabled documentation.
-	ProtectKernelModules bool `hcl:"protect_kernel_modules,optional" systemd:"ProtectKernelModules"`
+	ProtectKernelModules *bool `hcl:"protect_kernel_modules,optional" systemd:"ProtectKernelModules"`
 	// Takes a boolean argument. If true, kernel variables accessible through /proc/sys/, /sys/,
 	// /proc/sysrq-trigger, /proc/latency_stats, /proc/acpi, /proc/timer_stats, /proc/fs and /proc/irq will
 	// be made read-only and /proc/kallsyms as well as /proc/kcore will be inaccessible to all processes of
@@ -2265,7 +2266,7 @@ type ServiceBlock struct {
 	// does not prevent indirect changes to kernel tunables affected by IPC calls to other processes.
 	// However, InaccessiblePaths= may be used to make relevant IPC file system objects inaccessible. If
 	// ProtectKernelTunables= is set, MountAPIVFS=yes is implied.
-	ProtectKernelTunables bool `hcl:"protect_kernel_tunables,optional" systemd:"ProtectKernelTunables"`
+	ProtectKernelTunables *bool `hcl:"protect_kernel_tunables,optional" systemd:"ProtectKernelTunables"`
 	// Takes one of noaccess, invisible, ptraceable or default (which it defaults to). When set, this
 	// controls the hidepid= mount option of the procfs instance for the unit that controls which
 	// directories with process metainformation (/proc/PID) are visible and accessible: when set to
@@ -2450,7 +2451,7 @@ type ServiceBlock struct {
 	ReloadSignal syscall.Signal `unitd:"reload_signal,optional" systemd:"ReloadSignal"`
 	// Takes a boolean value that specifies whether the service shall be considered active even when all
 	// its processes exited. Defaults to no.
-	RemainAfterExit bool `hcl:"remain_after_exit,optional" systemd:"RemainAfterExit"`
+	RemainAfterExit *bool `hcl:"remain_after_exit,optional" systemd:"RemainAfterExit"`
 	// Takes a boolean parameter. If set, all System V and POSIX IPC objects owned by the user and group
 	// the processes of this unit are run as are removed when the unit is stopped. This setting only has an
 	// effect if at least one of User=, Group= and DynamicUser= are used. It has no effect on IPC objects
@@ -2458,8 +2459,8 @@ type ServiceBlock struct {
 	// POSIX shared memory segments and message queues. If multiple units use the same user or group the
 	// IPC objects are removed when the last of these units is stopped. This setting is implied if
 	// DynamicUser= is set.
-	RemoveIPC bool   `hcl:"remove_ipc,optional" systemd:"RemoveIPC"`
-	Restart   string `hcl:"restart,optional" systemd:"Restart"`
+	RemoveIPC *bool          `hcl:"remove_ipc,optional" systemd:"RemoveIPC"`
+	Restart   ServiceRestart `hcl:"restart,optional" systemd:"Restart"`
 	// Takes a list of exit status definitions that, when returned by the main service process, will force
 	// automatic service restarts, regardless of the restart setting configured with Restart=. The argument
 	// format is similar to RestartPreventExitStatus=.
@@ -2478,7 +2479,7 @@ type ServiceBlock struct {
 	//
 	// This setting is effective only if RestartSteps= is also set and RestartSec= is not zero.
 	//
-	RestartMaxDelaySec int `hcl:"restart_max_delay_sec,optional" systemd:"RestartMaxDelaySec"`
+	RestartMaxDelaySec *int `hcl:"restart_max_delay_sec,optional" systemd:"RestartMaxDelaySec"`
 	// Takes a string value that specifies how a service should restart: <itemizedlist> <listitem> <para>If
 	// set to normal (the default), the service restarts by going through a failed/inactive state.</para>
 	// <ns0:include href="version-info.xml" xpointer="v254" /> </listitem> <listitem> <para>If set to
@@ -2494,7 +2495,7 @@ type ServiceBlock struct {
 	// needing to proactively or permanently enable debug level logging in systemd, which is very verbose.
 	// This is otherwise equivalent to normal mode.</para> <ns0:include href="version-info.xml"
 	// xpointer="v257" /> </listitem> </itemizedlist>
-	RestartMode string `hcl:"restart_mode,optional" systemd:"RestartMode"`
+	RestartMode ServiceRestartMode `hcl:"restart_mode,optional" systemd:"RestartMode"`
 	// Takes a list of exit status definitions that, when returned by the main service process, will
 	// prevent automatic service restarts, regardless of the restart setting configured with Restart=. Exit
 	// status definitions can be numeric termination statuses, termination status names, or termination
@@ -2514,7 +2515,7 @@ type ServiceBlock struct {
 	RestartPreventExitStatus string `hcl:"restart_prevent_exit_status,optional" systemd:"RestartPreventExitStatus"`
 	// Configures the time to sleep before restarting a service (as configured with Restart=). Takes a
 	// unit-less value in seconds, or a time span value such as "5min 20s". Defaults to 100ms.
-	RestartSec int `hcl:"restart_sec,optional" systemd:"RestartSec"`
+	RestartSec *int `hcl:"restart_sec,optional" systemd:"RestartSec"`
 	// Configures the number of exponential steps to take to increase the interval of auto-restarts from
 	// RestartSec= to RestartMaxDelaySec=. Takes a positive integer or 0 to disable it. Defaults to 0.
 	// Hint: values between 3 and 5 are good choices when exponential backoff is desired.
@@ -2530,7 +2531,7 @@ type ServiceBlock struct {
 	//
 	// This setting is effective only if RestartMaxDelaySec= is also set and RestartSec= is not zero.
 	//
-	RestartSteps uint64 `hcl:"restart_steps,optional" systemd:"RestartSteps"`
+	RestartSteps *uint64 `hcl:"restart_steps,optional" systemd:"RestartSteps"`
 	// Restricts the set of socket address families accessible to the processes of this unit. Takes none,
 	// or a space-separated list of address family names to allow-list, such as AF_UNIX, AF_INET or
 	// AF_INET6, see <citerefentry
@@ -2606,7 +2607,7 @@ type ServiceBlock struct {
 	// time for longer periods of time, and may hence be used to lock up or otherwise trigger
 	// Denial-of-Service situations on the system. It is hence recommended to restrict access to realtime
 	// scheduling to the few programs that actually require them. Defaults to off.
-	RestrictRealtime bool `hcl:"restrict_realtime,optional" systemd:"RestrictRealtime"`
+	RestrictRealtime *bool `hcl:"restrict_realtime,optional" systemd:"RestrictRealtime"`
 	// Takes a boolean argument. If set, any attempts to set the set-user-ID (SUID) or set-group-ID (SGID)
 	// bits on files or directories will be denied (for details on these bits see <citerefentry
 	// project="man-pages"><refentrytitle>inode</refentrytitle><manvolnum>7</manvolnum></citerefentry>). As
@@ -2620,7 +2621,7 @@ type ServiceBlock struct {
 	// <citerefentry><refentrytitle>systemd-system.conf</refentrytitle><manvolnum>5</manvolnum></citerefentry>,
 	// which defaults to off.
 	//
-	RestrictSUIDSGID bool `hcl:"restrict_suidsgid,optional" systemd:"RestrictSUIDSGID"`
+	RestrictSUIDSGID *bool `hcl:"restrict_suidsgid,optional" systemd:"RestrictSUIDSGID"`
 	// Takes a directory path relative to the host's root directory (i.e. the root of the system running
 	// the service manager). Sets the root directory for executed processes, with the <citerefentry
 	// project="man-pages"><refentrytitle>pivot_root</refentrytitle><manvolnum>2</manvolnum></citerefentry>
@@ -2656,7 +2657,7 @@ type ServiceBlock struct {
 	// other ExecStartPre=, ExecStartPost=, ExecReload=, ExecReloadPost=, ExecStop=, and ExecStopPost=
 	// commands. If false, the setting is applied to all configured commands the same way. Defaults to
 	// false.
-	RootDirectoryStartOnly bool `hcl:"root_directory_start_only,optional" systemd:"RootDirectoryStartOnly"`
+	RootDirectoryStartOnly *bool `hcl:"root_directory_start_only,optional" systemd:"RootDirectoryStartOnly"`
 	// Takes a boolean argument. If enabled, executed processes will run in an ephemeral copy of the root
 	// directory or root image. The ephemeral copy is placed in /var/lib/systemd/ephemeral-trees/ while the
 	// service is active and is cleaned up when the service is stopped or restarted. If RootDirectory= is
@@ -2671,7 +2672,7 @@ type ServiceBlock struct {
 	// can snapshot to make the ephemeral copy. For root images, a filesystem with support for reflinks
 	// should be used to ensure an efficient ephemeral copy.
 	//
-	RootEphemeral bool `hcl:"root_ephemeral,optional" systemd:"RootEphemeral"`
+	RootEphemeral *bool `hcl:"root_ephemeral,optional" systemd:"RootEphemeral"`
 	// Takes a data integrity (dm-verity) root hash specified in hexadecimal, or the path to a file
 	// containing a root hash in ASCII hexadecimal format. This option enables data integrity checks using
 	// dm-verity, if the used image contains the appropriate integrity data (see above) or if RootVerity=
@@ -2815,11 +2816,11 @@ type ServiceBlock struct {
 	// STOPPING=1 (or termination). (see
 	// <citerefentry><refentrytitle>sd_notify</refentrytitle><manvolnum>3</manvolnum></citerefentry>).
 	//
-	RuntimeMaxSec int `hcl:"runtime_max_sec,optional" systemd:"RuntimeMaxSec"`
+	RuntimeMaxSec *int `hcl:"runtime_max_sec,optional" systemd:"RuntimeMaxSec"`
 	// This option modifies RuntimeMaxSec= by increasing the maximum runtime by an evenly distributed
 	// duration between 0 and the specified value (in seconds). If RuntimeMaxSec= is unspecified, then this
 	// feature will be disabled.
-	RuntimeRandomizedExtraSec int `hcl:"runtime_randomized_extra_sec,optional" systemd:"RuntimeRandomizedExtraSec"`
+	RuntimeRandomizedExtraSec *int `hcl:"runtime_randomized_extra_sec,optional" systemd:"RuntimeRandomizedExtraSec"`
 	// Set the SELinux security context of the executed process. If set, this will override the automated
 	// domain transition. However, the policy still needs to authorize the transition. This directive is
 	// ignored if SELinux is disabled. If prefixed by -, failing to set the SELinux security context will
@@ -2840,12 +2841,12 @@ type ServiceBlock struct {
 	// Specifies whether to send SIGHUP to remaining processes immediately after sending the signal
 	// configured with KillSignal=. This is useful to indicate to shells and shell-like programs that their
 	// connection has been severed. Takes a boolean value. Defaults to no.
-	SendSIGHUP bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
+	SendSIGHUP *bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
 	// Specifies whether to send SIGKILL (or the signal specified by FinalKillSignal=) to remaining
 	// processes after a timeout, if the normal shutdown procedure left processes of the service around.
 	// When disabled, a KillMode= of control-group or mixed service will not restart if processes from
 	// prior services exist within the control group. Takes a boolean value. Defaults to yes.
-	SendSIGKILL bool `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
+	SendSIGKILL *bool `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
 	// The SetCredential= setting is similar to LoadCredential= but accepts a literal value to use as data
 	// for the credential, instead of a file system path to read the data from. Do not use this option for
 	// data that is supposed to be secret, as it is accessible to unprivileged processes via IPC. It's only
@@ -2895,7 +2896,7 @@ type ServiceBlock struct {
 	// manager, no matter whether User=, DynamicUser=, or PAMName= are used or not. This option normally
 	// has no effect on services of the per-user service manager, since in that case these variables are
 	// typically inherited from user manager's own environment anyway.
-	SetLoginEnvironment bool   `hcl:"set_login_environment,optional" systemd:"SetLoginEnvironment"`
+	SetLoginEnvironment *bool  `hcl:"set_login_environment,optional" systemd:"SetLoginEnvironment"`
 	Slice               string `hcl:"slice,optional" systemd:"Slice"`
 	// Takes a SMACK64 security label as argument. The process executed by the unit will be started under
 	// this label and SMACK will decide whether the process is allowed to run or not, based on it. The
@@ -3124,19 +3125,19 @@ type ServiceBlock struct {
 	// which defaults to journal. Note that setting this parameter might result in additional dependencies
 	// to be added to the unit (see above).
 	//
-	StandardOutput            string `hcl:"standard_output,optional" systemd:"StandardOutput"`
-	StartLimitAction          string `hcl:"start_limit_action,optional" systemd:"StartLimitAction"`
-	StartLimitBurst           uint64 `hcl:"start_limit_burst,optional" systemd:"StartLimitBurst"`
-	StartLimitInterval        int    `hcl:"start_limit_interval,optional" systemd:"StartLimitInterval"`
-	StartupAllowedCPUs        string `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
-	StartupAllowedMemoryNodes string `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
-	StartupCPUWeight          uint64 `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
-	StartupIOWeight           uint64 `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
-	StartupMemoryHigh         string `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
-	StartupMemoryLow          string `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
-	StartupMemoryMax          string `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
-	StartupMemorySwapMax      string `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
-	StartupMemoryZSwapMax     string `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
+	StandardOutput            string          `hcl:"standard_output,optional" systemd:"StandardOutput"`
+	StartLimitAction          EmergencyAction `hcl:"start_limit_action,optional" systemd:"StartLimitAction"`
+	StartLimitBurst           *uint64         `hcl:"start_limit_burst,optional" systemd:"StartLimitBurst"`
+	StartLimitInterval        *int            `hcl:"start_limit_interval,optional" systemd:"StartLimitInterval"`
+	StartupAllowedCPUs        string          `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
+	StartupAllowedMemoryNodes string          `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
+	StartupCPUWeight          *uint64         `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
+	StartupIOWeight           *uint64         `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
+	StartupMemoryHigh         string          `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
+	StartupMemoryLow          string          `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
+	StartupMemoryMax          string          `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
+	StartupMemorySwapMax      string          `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
+	StartupMemoryZSwapMax     string          `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
 	// /var/lib/
 	StateDirectory []string `hcl:"state_directory,optional" systemd:"StateDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -3147,7 +3148,7 @@ type ServiceBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	StateDirectoryAccounting bool `hcl:"state_directory_accounting,optional" systemd:"StateDirectoryAccounting"`
+	StateDirectoryAccounting *bool `hcl:"state_directory_accounting,optional" systemd:"StateDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -3227,7 +3228,7 @@ type ServiceBlock struct {
 	// interpretation of these prefixes may be disabled with SyslogLevelPrefix=, see below. For details,
 	// see <citerefentry><refentrytitle>sd-daemon</refentrytitle><manvolnum>3</manvolnum></citerefentry>.
 	// Defaults to info.
-	SyslogLevel int `hcl:"syslog_level,optional" systemd:"SyslogLevel"`
+	SyslogLevel *int `hcl:"syslog_level,optional" systemd:"SyslogLevel"`
 	// Takes a boolean argument. If true and StandardOutput= or StandardError= are set to journal or kmsg
 	// (or to the same settings in combination with +console), log lines written by the executed process
 	// that are prefixed with a log level will be processed with this log level set but the prefix removed.
@@ -3236,7 +3237,7 @@ type ServiceBlock struct {
 	// prefixing see
 	// <citerefentry><refentrytitle>sd-daemon</refentrytitle><manvolnum>3</manvolnum></citerefentry>.
 	// Defaults to true.
-	SyslogLevelPrefix bool `hcl:"syslog_level_prefix,optional" systemd:"SyslogLevelPrefix"`
+	SyslogLevelPrefix *bool `hcl:"syslog_level_prefix,optional" systemd:"SyslogLevelPrefix"`
 	// Takes a space-separated list of architecture identifiers to include in the system call filter. The
 	// known architecture identifiers are the same as for ConditionArchitecture= described in
 	// <citerefentry><refentrytitle>systemd.unit</refentrytitle><manvolnum>5</manvolnum></citerefentry>, as
@@ -3493,26 +3494,26 @@ type ServiceBlock struct {
 	// Configure the size of the TTY specified with TTYPath=. If unset or set to the empty string, it is
 	// attempted to retrieve the dimensions of the terminal screen via ANSI sequences, and if that fails
 	// the kernel defaults (typically 80x24) are used.
-	TTYColumns uint64 `hcl:"tty_columns,optional" systemd:"TTYColumns"`
+	TTYColumns *uint64 `hcl:"tty_columns,optional" systemd:"TTYColumns"`
 	// Sets the terminal device node to use if standard input, output, or error are connected to a TTY (see
 	// above). Defaults to /dev/console.
 	TTYPath string `hcl:"tty_path,optional" systemd:"TTYPath"`
 	// Reset the terminal device specified with TTYPath= before and after execution. This does not erase
 	// the screen (see TTYVTDisallocate= below for that). Defaults to no.
-	TTYReset bool `hcl:"tty_reset,optional" systemd:"TTYReset"`
+	TTYReset *bool `hcl:"tty_reset,optional" systemd:"TTYReset"`
 	// Configure the size of the TTY specified with TTYPath=. If unset or set to the empty string, it is
 	// attempted to retrieve the dimensions of the terminal screen via ANSI sequences, and if that fails
 	// the kernel defaults (typically 80x24) are used.
-	TTYRows uint64 `hcl:"tty_rows,optional" systemd:"TTYRows"`
+	TTYRows *uint64 `hcl:"tty_rows,optional" systemd:"TTYRows"`
 	// Disconnect all clients which have opened the terminal device specified with TTYPath= before and
 	// after execution. Defaults to no.
-	TTYVHangup bool `hcl:"ttyv_hangup,optional" systemd:"TTYVHangup"`
+	TTYVHangup *bool `hcl:"ttyv_hangup,optional" systemd:"TTYVHangup"`
 	// If the terminal device specified with TTYPath= is a virtual console terminal, try to deallocate the
 	// TTY before and after execution. This ensures that the screen and scrollback buffer is cleared. If
 	// the terminal device is of any other type of TTY an attempt is made to clear the screen via ANSI
 	// sequences. Defaults to no.
-	TTYVTDisallocate bool   `hcl:"ttyvt_disallocate,optional" systemd:"TTYVTDisallocate"`
-	TasksAccounting  bool   `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
+	TTYVTDisallocate *bool  `hcl:"ttyvt_disallocate,optional" systemd:"TTYVTDisallocate"`
+	TasksAccounting  *bool  `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
 	TasksMax         string `hcl:"tasks_max,optional" systemd:"TasksMax"`
 	// Takes a space-separated list of mount points for temporary file systems (tmpfs). If set, a new file
 	// system namespace is set up for executed processes, and a temporary file system is mounted on each
@@ -3555,15 +3556,15 @@ type ServiceBlock struct {
 	// specified, or terminates itself (see
 	// <citerefentry><refentrytitle>sd_notify</refentrytitle><manvolnum>3</manvolnum></citerefentry>).
 	//
-	TimeoutAbortSec int `hcl:"timeout_abort_sec,optional" systemd:"TimeoutAbortSec"`
+	TimeoutAbortSec *int `hcl:"timeout_abort_sec,optional" systemd:"TimeoutAbortSec"`
 	// Configures a timeout on the clean-up operation requested through systemctl clean …, see
 	// <citerefentry><refentrytitle>systemctl</refentrytitle><manvolnum>1</manvolnum></citerefentry> for
 	// details. Takes the usual time values and defaults to infinity, i.e. by default no timeout is
 	// applied. If a timeout is configured the clean operation will be aborted forcibly when the timeout is
 	// reached, potentially leaving resources on disk.
-	TimeoutCleanSec int `hcl:"timeout_clean_sec,optional" systemd:"TimeoutCleanSec"`
+	TimeoutCleanSec *int `hcl:"timeout_clean_sec,optional" systemd:"TimeoutCleanSec"`
 	// A shorthand for configuring both TimeoutStartSec= and TimeoutStopSec= to the specified value.
-	TimeoutSec int `hcl:"timeout_sec,optional" systemd:"TimeoutSec"`
+	TimeoutSec *int `hcl:"timeout_sec,optional" systemd:"TimeoutSec"`
 	// These options configure the action that is taken in case a daemon service does not signal start-up
 	// within its configured TimeoutStartSec=, respectively if it does not stop within TimeoutStopSec=.
 	// Takes one of terminate, abort and kill. Both options default to terminate.
@@ -3577,7 +3578,7 @@ type ServiceBlock struct {
 	// using kill the service is immediately terminated by sending FinalKillSignal= without any further
 	// timeout. This setting can be used to expedite the shutdown of failing services.
 	//
-	TimeoutStartFailureMode int `hcl:"timeout_start_failure_mode,optional" systemd:"TimeoutStartFailureMode"`
+	TimeoutStartFailureMode TimeoutFailureMode `hcl:"timeout_start_failure_mode,optional" systemd:"TimeoutStartFailureMode"`
 	// Configures the time to wait for start-up. If a daemon service does not signal start-up completion
 	// within the configured time, the service will be considered failed and will be shut down again. The
 	// precise action depends on the TimeoutStartFailureMode= option. Takes a unit-less value in seconds,
@@ -3600,7 +3601,7 @@ type ServiceBlock struct {
 	// continue running with the old configuration. This will not affect the running service, but will be
 	// logged and will cause e.g. systemctl reload to fail.
 	//
-	TimeoutStartSec int `hcl:"timeout_start_sec,optional" systemd:"TimeoutStartSec"`
+	TimeoutStartSec *int `hcl:"timeout_start_sec,optional" systemd:"TimeoutStartSec"`
 	// These options configure the action that is taken in case a daemon service does not signal start-up
 	// within its configured TimeoutStartSec=, respectively if it does not stop within TimeoutStopSec=.
 	// Takes one of terminate, abort and kill. Both options default to terminate.
@@ -3614,7 +3615,7 @@ type ServiceBlock struct {
 	// using kill the service is immediately terminated by sending FinalKillSignal= without any further
 	// timeout. This setting can be used to expedite the shutdown of failing services.
 	//
-	TimeoutStopFailureMode int `hcl:"timeout_stop_failure_mode,optional" systemd:"TimeoutStopFailureMode"`
+	TimeoutStopFailureMode TimeoutFailureMode `hcl:"timeout_stop_failure_mode,optional" systemd:"TimeoutStopFailureMode"`
 	// This option serves two purposes. First, it configures the time to wait for each ExecStop= command.
 	// If any of them times out, subsequent ExecStop= commands are skipped and the service will be
 	// terminated by SIGTERM. If no ExecStop= commands are specified, the service gets the SIGTERM
@@ -3634,13 +3635,13 @@ type ServiceBlock struct {
 	// EXTEND_TIMEOUT_USEC=… within the interval specified, or terminates itself (see
 	// <citerefentry><refentrytitle>sd_notify</refentrytitle><manvolnum>3</manvolnum></citerefentry>).
 	//
-	TimeoutStopSec int `hcl:"timeout_stop_sec,optional" systemd:"TimeoutStopSec"`
+	TimeoutStopSec *int `hcl:"timeout_stop_sec,optional" systemd:"TimeoutStopSec"`
 	// Sets the timer slack in nanoseconds for the executed processes. The timer slack controls the
 	// accuracy of wake-ups triggered by timers. See
 	// <citerefentry><refentrytitle>prctl</refentrytitle><manvolnum>2</manvolnum></citerefentry> for more
 	// information. Note that in contrast to most other time span definitions this parameter takes an
 	// integer value in nano-seconds if no unit is specified. The usual time units are understood too.
-	TimerSlackNSec int `hcl:"timer_slack_n_sec,optional" systemd:"TimerSlackNSec"`
+	TimerSlackNSec *int `hcl:"timer_slack_n_sec,optional" systemd:"TimerSlackNSec"`
 	// Configures the mechanism via which the service notifies the manager that the service start-up has
 	// finished. One of simple, exec, forking, oneshot, dbus, notify, notify-reload, or idle:
 	//
@@ -3670,7 +3671,7 @@ type ServiceBlock struct {
 	// service manager will not wait for such service execution setup operations to complete before
 	// proceeding.
 	//
-	Type string `hcl:"type,optional" systemd:"Type"`
+	Type ServiceType `hcl:"type,optional" systemd:"Type"`
 	// Controls the file mode creation mask. Takes an access mode in octal notation. See
 	// <citerefentry><refentrytitle>umask</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details. Defaults to 0022 for system units. For user units the default value is inherited from the
@@ -3793,7 +3794,7 @@ type ServiceBlock struct {
 	// for details.
 	// <citerefentry><refentrytitle>sd_event_set_watchdog</refentrytitle><manvolnum>3</manvolnum></citerefentry>
 	// may be used to enable automatic watchdog notification support.
-	WatchdogSec int `hcl:"watchdog_sec,optional" systemd:"WatchdogSec"`
+	WatchdogSec *int `hcl:"watchdog_sec,optional" systemd:"WatchdogSec"`
 	// Specifies which signal to use to terminate the service when the watchdog timeout expires (enabled
 	// through WatchdogSec=). Defaults to SIGABRT.
 	WatchdogSignal syscall.Signal `unitd:"watchdog_signal,optional" systemd:"WatchdogSignal"`
@@ -3811,8 +3812,9 @@ type ServiceBlock struct {
 type Service struct {
 	Name string `hcl:"name,label"`
 
-	Template bool              `hcl:"template,optional"`
-	ForEach  map[string]string `hcl:"for_each,optional"`
+	Template      bool              `hcl:"template,optional"`
+	ForEach       map[string]string `hcl:"for_each,optional"`
+	RestartPolicy string            `hcl:"restart_policy,optional"`
 
 	Unit    UnitBlock    `hcl:"unit,block"`
 	Service ServiceBlock `hcl:"service,block"`
@@ -10,39 +10,43 @@ import (
 
 // SocketBlock is for [Socket] systemd unit block
 //
-// A unit configuration file whose name ends in encodes information about an IPC or network socket or a
-// file system FIFO controlled and supervised by systemd, for socket-based activation. This man page
-// lists the configuration options specific to this unit type. See for the common options of all unit
-// configuration files. The common configuration items are configured in the generic [Unit] and
-// [Install] sections. The socket specific configuration options are configured in the [Socket]
-// section. Additional options are listed in , which define the execution environment the , , and
-// commands are executed in, and in , which define the way the processes are terminated, and in , which
-// configure resource control settings for the processes of the socket. For each socket unit, a
-// matching service unit must exist, describing the service to start on incoming traffic on the socket
-// (see for more information about .service units). The name of the .service unit is by default the
-// same as the name of the .socket unit, but can be altered with the option described below. Depending
-// on the setting of the option described below, this .service unit must either be named like the
-// .socket unit, but with the suffix replaced, unless overridden with ; or it must be a template unit
-// named the same way. Example: a socket file needs a matching service if is set. If is set, a service
-// template must exist from which services are instantiated for each incoming connection. No implicit
-// or dependency from the socket to the service is added. This means that the service may be started
-// without the socket, in which case it must be able to open sockets by itself. To prevent this, an
-// explicit dependency may be added. Socket units may be used to implement on-demand starting of
-// services, as well as parallelized starting of services. See the blog stories linked at the end for
-// an introduction. Note that the daemon software configured for socket activation with socket units
-// needs to be able to accept sockets from systemd, either via systemd's native socket passing
-// interface (see for details about the precise protocol used and the order in which the file
-// descriptors are passed) or via traditional -style socket passing (i.e. sockets passed in via
-// standard input and output, using in the service file). By default, network sockets allocated through
-// units are allocated in the host's network namespace (see ). This does not mean however that the
-// service activated by a configured socket unit has to be part of the host's network namespace as
-// well. It is supported and even good practice to run services in their own network namespace (for
-// example through , see ), receiving only the sockets configured through socket-activation from the
-// host's namespace. In such a set-up communication within the host's network namespace is only
-// permitted through the activation sockets passed in while all sockets allocated from the service code
-// itself will be associated with the service's own namespace, and thus possibly subject to a
-// restrictive configuration. Alternatively, it is possible to run a unit in another network namespace
-// by setting in combination with , see and for details.
+// A unit configuration file whose name ends in .socket encodes information about an IPC or network
+// socket or a file system FIFO controlled and supervised by systemd, for socket-based activation. This
+// man page lists the configuration options specific to this unit type. See systemd.unit(5) for the
+// common options of all unit configuration files. The common configuration items are configured in the
+// generic [Unit] and [Install] sections. The socket specific configuration options are configured in
+// the [Socket] section. Additional options are listed in systemd.exec(5), which define the execution
+// environment the ExecStartPre=, ExecStartPost=, ExecStopPre= and ExecStopPost= commands are executed
+// in, and in systemd.kill(5), which define the way the processes are terminated, and in
+// systemd.resource-control(5), which configure resource control settings for the processes of the
+// socket. For each socket unit, a matching service unit must exist, describing the service to start on
+// incoming traffic on the socket (see systemd.service(5) for more information about .service units).
+// The name of the .service unit is by default the same as the name of the .socket unit, but can be
+// altered with the Service= option described below. Depending on the setting of the Accept= option
+// described below, this .service unit must either be named like the .socket unit, but with the suffix
+// replaced, unless overridden with Service=; or it must be a template unit named the same way.
+// Example: a socket file foo.socket needs a matching service foo.service if Accept=no is set. If
+// Accept=yes is set, a service template foo@.service must exist from which services are instantiated
+// for each incoming connection. No implicit WantedBy= or RequiredBy= dependency from the socket to the
+// service is added. This means that the service may be started without the socket, in which case it
+// must be able to open sockets by itself. To prevent this, an explicit Requires= dependency may be
+// added. Socket units may be used to implement on-demand starting of services, as well as parallelized
+// starting of services. See the blog stories linked at the end for an introduction. Note that the
+// daemon software configured for socket activation with socket units needs to be able to accept
+// sockets from systemd, either via systemd's native socket passing interface (see sd_listen_fds(3) for
+// details about the precise protocol used and the order in which the file descriptors are passed) or
+// via traditional inetd(8)-style socket passing (i.e. sockets passed in via standard input and output,
+// using StandardInput=socket in the service file). By default, network sockets allocated through
+// .socket units are allocated in the host's network namespace (see network_namespaces(7)). This does
+// not mean however that the service activated by a configured socket unit has to be part of the host's
+// network namespace as well. It is supported and even good practice to run services in their own
+// network namespace (for example through PrivateNetwork=, see systemd.exec(5)), receiving only the
+// sockets configured through socket-activation from the host's namespace. In such a set-up
+// communication within the host's network namespace is only permitted through the activation sockets
+// passed in while all sockets allocated from the service code itself will be associated with the
+// service's own namespace, and thus possibly subject to a restrictive configuration. Alternatively, it
+// is possible to run a .socket unit in another network namespace by setting PrivateNetwork=yes in
+// combination with JoinsNamespaceOf=, see systemd.exec(5) and systemd.unit(5) for details.
 type SocketBlock struct {
 	// Takes a boolean argument. If yes, a service instance is spawned for each incoming connection and
 	// only the connection socket is passed to it. If no, all listening sockets themselves are passed to
@@ -91,11 +95,11 @@ type SocketBlock struct {
 	// Accept=yes, to ensure that failed connection services are cleaned up and released from memory, and
 	// do not accumulate.
 	//
-	Accept bool `hcl:"accept,optional" systemd:"Accept"`
+	Accept *bool `hcl:"accept,optional" systemd:"Accept"`
 	// Takes a boolean value. This controls the SO_PASSRIGHTS socket option, which when disabled prohibits
 	// the peer from sending SCM_RIGHTS ancillary messages (aka file descriptors) via AF_UNIX sockets.
 	// Defaults to true.
-	AcceptFileDescriptors bool   `hcl:"accept_file_descriptors,optional" systemd:"AcceptFileDescriptors"`
+	AcceptFileDescriptors *bool  `hcl:"accept_file_descriptors,optional" systemd:"AcceptFileDescriptors"`
 	AllowedCPUs           string `hcl:"allowed_cp_us,optional" systemd:"AllowedCPUs"`
 	AllowedMemoryNodes    string `hcl:"allowed_memory_nodes,optional" systemd:"AllowedMemoryNodes"`
 	// Controls which capabilities to include in the ambient capability set for the executed process. Takes
@@ -155,7 +159,7 @@ type SocketBlock struct {
 	// details. Defaults to 4294967295. Note that this value is silently capped by the net.core.somaxconn
 	// sysctl, which typically defaults to 4096, so typically the sysctl is the setting that actually
 	// matters.
-	Backlog uint64 `hcl:"backlog,optional" systemd:"Backlog"`
+	Backlog *uint64 `hcl:"backlog,optional" systemd:"Backlog"`
 	// Takes one of default, both or ipv6-only. Controls the IPV6_V6ONLY socket option (see <citerefentry
 	// project="die-net"><refentrytitle>ipv6</refentrytitle><manvolnum>7</manvolnum></citerefentry> for
 	// details). If both, IPv6 sockets bound will be accessible via both IPv4 and IPv6. If ipv6-only, they
@@ -172,7 +176,7 @@ type SocketBlock struct {
 	// This option is implied when LogNamespace= is used, when MountAPIVFS=yes, or when PrivateDevices=yes
 	// is used in conjunction with either RootDirectory= or RootImage=.
 	//
-	BindLogSockets       bool     `hcl:"bind_log_sockets,optional" systemd:"BindLogSockets"`
+	BindLogSockets       *bool    `hcl:"bind_log_sockets,optional" systemd:"BindLogSockets"`
 	BindNetworkInterface []string `hcl:"bind_network_interface,optional" systemd:"BindNetworkInterface"`
 	// Configures unit-specific bind mounts. A bind mount makes a particular file or directory available at
 	// an additional place in the unit's view of the file system. Any bind mounts created with this option
@@ -248,7 +252,7 @@ type SocketBlock struct {
 	BindToDevice []string `hcl:"bind_to_device,optional" systemd:"BindToDevice"`
 	// Takes a boolean value. This controls the SO_BROADCAST socket option, which allows broadcast
 	// datagrams to be sent from this socket. Defaults to false.
-	Broadcast bool `hcl:"broadcast,optional" systemd:"Broadcast"`
+	Broadcast *bool `hcl:"broadcast,optional" systemd:"Broadcast"`
 	// Controls the CPU affinity of the executed processes. Takes a list of CPU indices or ranges separated
 	// by either whitespace or commas. Alternatively, takes a special "numa" value in which case systemd
 	// automatically derives allowed CPU range based on the value of NUMAMask= option. CPU ranges are
@@ -258,10 +262,10 @@ type SocketBlock struct {
 	// project="man-pages"><refentrytitle>sched_setaffinity</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details.
 	CPUAffinity             string `hcl:"cpu_affinity,optional" systemd:"CPUAffinity"`
-	CPUPressureThresholdSec int    `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
+	CPUPressureThresholdSec *int   `hcl:"cpu_pressure_threshold_sec,optional" systemd:"CPUPressureThresholdSec"`
 	CPUPressureWatch        string `hcl:"cpu_pressure_watch,optional" systemd:"CPUPressureWatch"`
 	CPUQuota                string `hcl:"cpu_quota,optional" systemd:"CPUQuota"`
-	CPUQuotaPeriodSec       int    `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
+	CPUQuotaPeriodSec       *int   `hcl:"cpu_quota_period_sec,optional" systemd:"CPUQuotaPeriodSec"`
 	// Sets the CPU scheduling policy for executed processes. Takes one of other, batch, idle, fifo, rr or
 	// ext. See <citerefentry
 	// project="man-pages"><refentrytitle>sched_setscheduler</refentrytitle><manvolnum>2</manvolnum></citerefentry>
@@ -281,8 +285,8 @@ type SocketBlock struct {
 	// can hence not leak into child processes. See <citerefentry
 	// project="man-pages"><refentrytitle>sched_setscheduler</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details. Defaults to false.
-	CPUSchedulingResetOnFork bool   `hcl:"cpu_scheduling_reset_on_fork,optional" systemd:"CPUSchedulingResetOnFork"`
-	CPUWeight                uint64 `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
+	CPUSchedulingResetOnFork *bool   `hcl:"cpu_scheduling_reset_on_fork,optional" systemd:"CPUSchedulingResetOnFork"`
+	CPUWeight                *uint64 `hcl:"cpu_weight,optional" systemd:"CPUWeight"`
 	// /var/cache/
 	CacheDirectory []string `hcl:"cache_directory,optional" systemd:"CacheDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -293,7 +297,7 @@ type SocketBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	CacheDirectoryAccounting bool `hcl:"cache_directory_accounting,optional" systemd:"CacheDirectoryAccounting"`
+	CacheDirectoryAccounting *bool `hcl:"cache_directory_accounting,optional" systemd:"CacheDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -363,7 +367,7 @@ type SocketBlock struct {
 	// the meaning of the mapping types. When specified multiple times, all specified masks are ORed. When
 	// not set, or if the empty value is assigned, the inherited value is not changed.
 	CoredumpFilter  string `hcl:"coredump_filter,optional" systemd:"CoredumpFilter"`
-	CoredumpReceive bool   `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
+	CoredumpReceive *bool  `hcl:"coredump_receive,optional" systemd:"CoredumpReceive"`
 	// Takes time (in seconds) as argument. If set, the listening process will be awakened only when data
 	// arrives on the socket, and not immediately when connection is established. When this option is set,
 	// the TCP_DEFER_ACCEPT socket option will be used (see <citerefentry
@@ -380,7 +384,7 @@ type SocketBlock struct {
 	//
 	// Disabled by default.
 	//
-	DeferAcceptSec int `hcl:"defer_accept_sec,optional" systemd:"DeferAcceptSec"`
+	DeferAcceptSec *int `hcl:"defer_accept_sec,optional" systemd:"DeferAcceptSec"`
 	// Takes a boolean argument, or patient. May only be used when Accept=no. If enabled, job mode lenient
 	// instead of replace is used when triggering the service, which means currently activating/running
 	// units that conflict with the service won't be disturbed/brought down. Furthermore, if a conflict
@@ -397,7 +401,7 @@ type SocketBlock struct {
 	// cannot be activated within the specified time, the socket will be considered failed and get
 	// terminated. Takes a unit-less value in seconds, or a time span value such as "5min 20s". Pass 0 or
 	// infinity to disable the timeout logic (the default).
-	DeferTriggerMaxSec int    `hcl:"defer_trigger_max_sec,optional" systemd:"DeferTriggerMaxSec"`
+	DeferTriggerMaxSec *int   `hcl:"defer_trigger_max_sec,optional" systemd:"DeferTriggerMaxSec"`
 	Delegate           string `hcl:"delegate,optional" systemd:"Delegate"`
 	// Delegates ownership of the given namespace types to the user namespace of the processes of this
 	// unit. For details about Linux namespaces, see <citerefentry
@@ -483,7 +487,7 @@ type SocketBlock struct {
 	// and access guarantees of the service. Note that this option is currently incompatible with D-Bus
 	// policies, thus a service using this option may currently not allocate a D-Bus service name (note
 	// that this does not affect calling into other D-Bus services). Defaults to off.
-	DynamicUser bool `hcl:"dynamic_user,optional" systemd:"DynamicUser"`
+	DynamicUser *bool `hcl:"dynamic_user,optional" systemd:"DynamicUser"`
 	// Sets environment variables for executed processes. Each line is unquoted using the rules described
 	// in "Quoting" section in
 	// <citerefentry><refentrytitle>systemd.syntax</refentrytitle><manvolnum>7</manvolnum></citerefentry>
@@ -517,7 +521,7 @@ type SocketBlock struct {
 	// have access to the secret data. Use LoadCredential=, LoadCredentialEncrypted= or
 	// SetCredentialEncrypted= (see below) to pass data to unit processes securely.
 	//
-	Environment []string `hcl:"environment,optional" systemd:"Environment"`
+	Environment map[string]string `hcl:"environment,optional" systemd:"Environment"`
 	// Similar to Environment=, but reads the environment variables from a text file. The text file should
 	// contain newline-separated variable assignments. Empty lines, lines without an = separator, or lines
 	// starting with ; or # will be ignored, which may be used for commenting. The file must be encoded
@@ -567,7 +571,7 @@ type SocketBlock struct {
 	// twice from these files, the files will be read in the order they are specified and the later setting
 	// will override the earlier setting.
 	//
-	EnvironmentFile string `hcl:"environment_file,optional" systemd:"EnvironmentFile"`
+	EnvironmentFile []string `hcl:"environment_file,optional" systemd:"EnvironmentFile"`
 	// Sets up a new file system namespace for executed processes. These options may be used to limit
 	// access a process has to the file system. Each setting takes a space-separated list of paths relative
 	// to the host's root directory (i.e. the system running the service manager). Note that if paths
@@ -638,20 +642,20 @@ type SocketBlock struct {
 	// created and bound, respectively. The first token of the command line must be an absolute filename,
 	// then followed by arguments for the process. Multiple command lines may be specified following the
 	// same scheme as used for ExecStartPre= of service unit files.
-	ExecStartPost string `hcl:"exec_start_post,optional" systemd:"ExecStartPost"`
+	ExecStartPost []string `hcl:"exec_start_post,optional" systemd:"ExecStartPost"`
 	// Takes one or more command lines, which are executed before or after the listening sockets/FIFOs are
 	// created and bound, respectively. The first token of the command line must be an absolute filename,
 	// then followed by arguments for the process. Multiple command lines may be specified following the
 	// same scheme as used for ExecStartPre= of service unit files.
-	ExecStartPre string `hcl:"exec_start_pre,optional" systemd:"ExecStartPre"`
+	ExecStartPre []string `hcl:"exec_start_pre,optional" systemd:"ExecStartPre"`
 	// Additional commands that are executed before or after the listening sockets/FIFOs are closed and
 	// removed, respectively. Multiple command lines may be specified following the same scheme as used for
 	// ExecStartPre= of service unit files.
-	ExecStopPost string `hcl:"exec_stop_post,optional" systemd:"ExecStopPost"`
+	ExecStopPost []string `hcl:"exec_stop_post,optional" systemd:"ExecStopPost"`
 	// Additional commands that are executed before or after the listening sockets/FIFOs are closed and
 	// removed, respectively. Multiple command lines may be specified following the same scheme as used for
 	// ExecStartPre= of service unit files.
-	ExecStopPre string `hcl:"exec_stop_pre,optional" systemd:"ExecStopPre"`
+	ExecStopPre []string `hcl:"exec_stop_pre,optional" systemd:"ExecStopPre"`
 	// This setting is similar to BindReadOnlyPaths= in that it mounts a file system hierarchy from a
 	// directory, but instead of providing a destination path, an overlay will be set up. This option
 	// expects a whitespace separated list of source directories.
@@ -767,13 +771,13 @@ type SocketBlock struct {
 	// incoming connections to be rejected. If no, the socket's buffers will not be cleared, permitting the
 	// service to handle any pending connections after restart, which is the usually expected behaviour.
 	// Defaults to no.
-	FlushPending bool `hcl:"flush_pending,optional" systemd:"FlushPending"`
+	FlushPending *bool `hcl:"flush_pending,optional" systemd:"FlushPending"`
 	// Takes a boolean value. Controls whether the socket can be bound to non-local IP addresses. This is
 	// useful to configure sockets listening on specific IP addresses before those IP addresses are
 	// successfully configured on a network interface. This sets the IP_FREEBIND/IPV6_FREEBIND socket
 	// option. For robustness reasons it is recommended to use this option whenever you bind a socket to a
 	// specific IP address. Defaults to false.
-	FreeBind bool `hcl:"free_bind,optional" systemd:"FreeBind"`
+	FreeBind *bool `hcl:"free_bind,optional" systemd:"FreeBind"`
 	// Set the UNIX user or group that the processes are executed as, respectively. Takes a single user or
 	// group name, or a numeric ID as argument. For system services (services run by the system service
 	// manager, i.e. managed by PID 1) and for user services of the root user (services managed by root's
@@ -806,10 +810,10 @@ type SocketBlock struct {
 	// configured through the SupplementaryGroups= setting (see below).
 	//
 	Group                    string   `hcl:"group,optional" systemd:"Group"`
-	IOAccounting             bool     `hcl:"io_accounting,optional" systemd:"IOAccounting"`
+	IOAccounting             *bool    `hcl:"io_accounting,optional" systemd:"IOAccounting"`
 	IODeviceLatencyTargetSec []string `hcl:"io_device_latency_target_sec,optional" systemd:"IODeviceLatencyTargetSec"`
 	IODeviceWeight           []string `hcl:"io_device_weight,optional" systemd:"IODeviceWeight"`
-	IOPressureThresholdSec   int      `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
+	IOPressureThresholdSec   *int     `hcl:"io_pressure_threshold_sec,optional" systemd:"IOPressureThresholdSec"`
 	IOPressureWatch          string   `hcl:"io_pressure_watch,optional" systemd:"IOPressureWatch"`
 	IOReadBandwidthMax       []string `hcl:"io_read_bandwidth_max,optional" systemd:"IOReadBandwidthMax"`
 	IOReadIOPSMax            []string `hcl:"io_read_iops_max,optional" systemd:"IOReadIOPSMax"`
@@ -819,7 +823,7 @@ type SocketBlock struct {
 	// IOSchedulingPriority= have no effect. See
 	// <citerefentry><refentrytitle>ioprio_set</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details.
-	IOSchedulingClass string `hcl:"io_scheduling_class,optional" systemd:"IOSchedulingClass"`
+	IOSchedulingClass IOClass `hcl:"io_scheduling_class,optional" systemd:"IOSchedulingClass"`
 	// Sets the I/O scheduling priority for executed processes. Takes an integer between 0 (highest
 	// priority) and 7 (lowest priority). In case of I/O contention, smaller values mean more I/O bandwidth
 	// is made available to the unit's processes, larger values mean less bandwidth. The available
@@ -828,11 +832,11 @@ type SocketBlock struct {
 	// effect. For the kernel's default scheduling class (best-effort) this defaults to 4. See
 	// <citerefentry><refentrytitle>ioprio_set</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details.
-	IOSchedulingPriority int      `hcl:"io_scheduling_priority,optional" systemd:"IOSchedulingPriority"`
-	IOWeight             uint64   `hcl:"io_weight,optional" systemd:"IOWeight"`
+	IOSchedulingPriority *int     `hcl:"io_scheduling_priority,optional" systemd:"IOSchedulingPriority"`
+	IOWeight             *uint64  `hcl:"io_weight,optional" systemd:"IOWeight"`
 	IOWriteBandwidthMax  []string `hcl:"io_write_bandwidth_max,optional" systemd:"IOWriteBandwidthMax"`
 	IOWriteIOPSMax       []string `hcl:"io_write_iops_max,optional" systemd:"IOWriteIOPSMax"`
-	IPAccounting         bool     `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
+	IPAccounting         *bool    `hcl:"ip_accounting,optional" systemd:"IPAccounting"`
 	IPAddressAllow       []string `hcl:"ip_address_allow,optional" systemd:"IPAddressAllow"`
 	IPAddressDeny        []string `hcl:"ip_address_deny,optional" systemd:"IPAddressDeny"`
 	// Takes an absolute file system path referring to a Linux IPC namespace pseudo-file (i.e. a file like
@@ -847,7 +851,7 @@ type SocketBlock struct {
 	IPIngressFilterPath []string `hcl:"ip_ingress_filter_path,optional" systemd:"IPIngressFilterPath"`
 	// Takes a boolean argument. If true, SIGPIPE is ignored in the executed process. Defaults to true
 	// since SIGPIPE is generally only useful in shell pipelines.
-	IgnoreSIGPIPE bool `hcl:"ignore_sigpipe,optional" systemd:"IgnoreSIGPIPE"`
+	IgnoreSIGPIPE *bool `hcl:"ignore_sigpipe,optional" systemd:"IgnoreSIGPIPE"`
 	// Pass one or more credentials to the unit. Takes a credential name for which we will attempt to find
 	// a credential that the service manager itself received under the specified name — which may be used
 	// to propagate credentials from an invoking environment (e.g. a container manager that invoked the
@@ -956,34 +960,34 @@ type SocketBlock struct {
 	// <citerefentry
 	// project="die-net"><refentrytitle>ipv6</refentrytitle><manvolnum>7</manvolnum></citerefentry> for
 	// details.)
-	Ipttl int `hcl:"ipttl,optional" systemd:"IPTTL"`
+	Ipttl *int `hcl:"ipttl,optional" systemd:"IPTTL"`
 	// Takes a boolean argument. If true, the TCP/IP stack will send a keep alive message after 2h
 	// (depending on the configuration of /proc/sys/net/ipv4/tcp_keepalive_time) for all TCP streams
 	// accepted on this socket. This controls the SO_KEEPALIVE socket option (see <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> and
 	// the <ulink url="http://www.tldp.org/HOWTO/html_single/TCP-Keepalive-HOWTO/">TCP Keepalive
 	// HOWTO</ulink> for details.) Defaults to false.
-	KeepAlive bool `hcl:"keep_alive,optional" systemd:"KeepAlive"`
+	KeepAlive *bool `hcl:"keep_alive,optional" systemd:"KeepAlive"`
 	// Takes time (in seconds) as argument between individual keepalive probes, if the socket option
 	// SO_KEEPALIVE has been set on this socket. This controls the TCP_KEEPINTVL socket option (see
 	// <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> and
 	// the <ulink url="http://www.tldp.org/HOWTO/html_single/TCP-Keepalive-HOWTO/">TCP Keepalive
 	// HOWTO</ulink> for details.) Default value is 75 seconds.
-	KeepAliveIntervalSec int `hcl:"keep_alive_interval_sec,optional" systemd:"KeepAliveIntervalSec"`
+	KeepAliveIntervalSec *int `hcl:"keep_alive_interval_sec,optional" systemd:"KeepAliveIntervalSec"`
 	// Takes an integer as argument. It is the number of unacknowledged probes to send before considering
 	// the connection dead and notifying the application layer. This controls the TCP_KEEPCNT socket option
 	// (see <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> and
 	// the <ulink url="http://www.tldp.org/HOWTO/html_single/TCP-Keepalive-HOWTO/">TCP Keepalive
 	// HOWTO</ulink> for details.) Default value is 9.
-	KeepAliveProbes uint64 `hcl:"keep_alive_probes,optional" systemd:"KeepAliveProbes"`
+	KeepAliveProbes *uint64 `hcl:"keep_alive_probes,optional" systemd:"KeepAliveProbes"`
 	// Takes time (in seconds) as argument. The connection needs to remain idle before TCP starts sending
 	// keepalive probes. This controls the TCP_KEEPIDLE socket option (see <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> and
 	// the <ulink url="http://www.tldp.org/HOWTO/html_single/TCP-Keepalive-HOWTO/">TCP Keepalive
 	// HOWTO</ulink> for details.) Default value is 7200 seconds (2 hours).
-	KeepAliveTimeSec int `hcl:"keep_alive_time_sec,optional" systemd:"KeepAliveTimeSec"`
+	KeepAliveTimeSec *int `hcl:"keep_alive_time_sec,optional" systemd:"KeepAliveTimeSec"`
 	// Controls how the kernel session keyring is set up for the service (see <citerefentry
 	// project="man-pages"><refentrytitle>session-keyring</refentrytitle><manvolnum>7</manvolnum></citerefentry>
 	// for details on the session keyring). Takes one of inherit, private, shared. If set to inherit no
@@ -1492,7 +1496,7 @@ type SocketBlock struct {
 	// system call so that the kernel execution domain may not be changed from the default or the
 	// personality selected with Personality= directive. This may be useful to improve security, because
 	// odd personality emulations may be poorly tested and source of vulnerabilities.
-	LockPersonality bool `hcl:"lock_personality,optional" systemd:"LockPersonality"`
+	LockPersonality *bool `hcl:"lock_personality,optional" systemd:"LockPersonality"`
 	// Configures additional log metadata fields to include in all log records generated by processes
 	// associated with this unit, including systemd. This setting takes one or more journal field
 	// assignments in the format FIELD=VALUE separated by whitespace. See
@@ -1556,7 +1560,7 @@ type SocketBlock struct {
 	// might prohibit messages of higher log levels to be stored on disk, even though the per-unit
 	// LogLevelMax= permitted it to be processed.
 	//
-	LogLevelMax int `hcl:"log_level_max,optional" systemd:"LogLevelMax"`
+	LogLevelMax *int `hcl:"log_level_max,optional" systemd:"LogLevelMax"`
 	// Run the unit's processes in the specified journal namespace. Expects a short user-defined string
 	// identifying the namespace. If not used the processes of the service are run in the default journal
 	// namespace, i.e. their log stream is collected and processed by systemd-journald.service. If this
@@ -1598,7 +1602,7 @@ type SocketBlock struct {
 	// enforced for messages generated via <citerefentry
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> and
 	// similar functions).
-	LogRateLimitBurst uint64 `hcl:"log_rate_limit_burst,optional" systemd:"LogRateLimitBurst"`
+	LogRateLimitBurst *uint64 `hcl:"log_rate_limit_burst,optional" systemd:"LogRateLimitBurst"`
 	// Configures the rate limiting that is applied to log messages generated by this unit. If, in the time
 	// interval defined by LogRateLimitIntervalSec=, more messages than specified in LogRateLimitBurst= are
 	// logged by a service, all further messages within the interval are dropped until the interval is
@@ -1614,7 +1618,7 @@ type SocketBlock struct {
 	// enforced for messages generated via <citerefentry
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> and
 	// similar functions).
-	LogRateLimitIntervalSec int `hcl:"log_rate_limit_interval_sec,optional" systemd:"LogRateLimitIntervalSec"`
+	LogRateLimitIntervalSec *int `hcl:"log_rate_limit_interval_sec,optional" systemd:"LogRateLimitIntervalSec"`
 	// /var/log/
 	LogsDirectory []string `hcl:"logs_directory,optional" systemd:"LogsDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -1625,7 +1629,7 @@ type SocketBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	LogsDirectoryAccounting bool `hcl:"logs_directory_accounting,optional" systemd:"LogsDirectoryAccounting"`
+	LogsDirectoryAccounting *bool `hcl:"logs_directory_accounting,optional" systemd:"LogsDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -1651,7 +1655,7 @@ type SocketBlock struct {
 	//
 	LogsDirectoryQuota                  string `hcl:"logs_directory_quota,optional" systemd:"LogsDirectoryQuota"`
 	ManagedOOMMemoryPressure            string `hcl:"managed_oom_memory_pressure,optional" systemd:"ManagedOOMMemoryPressure"`
-	ManagedOOMMemoryPressureDurationSec int    `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
+	ManagedOOMMemoryPressureDurationSec *int   `hcl:"managed_oom_memory_pressure_duration_sec,optional" systemd:"ManagedOOMMemoryPressureDurationSec"`
 	ManagedOOMMemoryPressureLimit       string `hcl:"managed_oom_memory_pressure_limit,optional" systemd:"ManagedOOMMemoryPressureLimit"`
 	ManagedOOMPreference                string `hcl:"managed_oom_preference,optional" systemd:"ManagedOOMPreference"`
 	ManagedOOMSwap                      string `hcl:"managed_oom_swap,optional" systemd:"ManagedOOMSwap"`
@@ -1660,17 +1664,17 @@ type SocketBlock struct {
 	// See <citerefentry
 	// project="die-net"><refentrytitle>iptables</refentrytitle><manvolnum>8</manvolnum></citerefentry> for
 	// details.
-	Mark int `hcl:"mark,optional" systemd:"Mark"`
+	Mark *int `hcl:"mark,optional" systemd:"Mark"`
 	// The maximum number of connections to simultaneously run services instances for, when Accept=yes is
 	// set. If more concurrent connections are coming in, they will be refused until at least one existing
 	// connection is terminated. This setting has no effect on sockets configured with Accept=no or
 	// datagram sockets. Defaults to 64.
-	MaxConnections uint64 `hcl:"max_connections,optional" systemd:"MaxConnections"`
+	MaxConnections *uint64 `hcl:"max_connections,optional" systemd:"MaxConnections"`
 	// The maximum number of connections for a service per source IP address (in case of IPv4/IPv6), per
 	// source CID (in case of AF_VSOCK), or source UID (in case of AF_UNIX). This is very similar to the
 	// MaxConnections= directive above. Defaults to 0, i.e. disabled.
-	MaxConnectionsPerSource uint64 `hcl:"max_connections_per_source,optional" systemd:"MaxConnectionsPerSource"`
-	MemoryAccounting        bool   `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
+	MaxConnectionsPerSource *uint64 `hcl:"max_connections_per_source,optional" systemd:"MaxConnectionsPerSource"`
+	MemoryAccounting        *bool   `hcl:"memory_accounting,optional" systemd:"MemoryAccounting"`
 	// Takes a boolean argument. If set, attempts to create memory mappings that are writable and
 	// executable at the same time, or to change existing memory mappings to become executable, or mapping
 	// shared memory segments as executable, are prohibited. Specifically, a system call filter is added
@@ -1695,7 +1699,7 @@ type SocketBlock struct {
 	// recommended to turn off alternative ABIs for services, so that they cannot be used to circumvent the
 	// restrictions of this option. Specifically, it is recommended to combine this option with
 	// SystemCallArchitectures=native or similar.
-	MemoryDenyWriteExecute bool   `hcl:"memory_deny_write_execute,optional" systemd:"MemoryDenyWriteExecute"`
+	MemoryDenyWriteExecute *bool  `hcl:"memory_deny_write_execute,optional" systemd:"MemoryDenyWriteExecute"`
 	MemoryHigh             string `hcl:"memory_high,optional" systemd:"MemoryHigh"`
 	// Takes a boolean argument. When set, it enables KSM (kernel samepage merging) for the processes. KSM
 	// is a memory-saving de-duplication feature. Anonymous memory pages with identical content can be
@@ -1708,11 +1712,11 @@ type SocketBlock struct {
 	// or the kernel does not support controlling KSM at the process level through
 	// <citerefentry><refentrytitle>prctl</refentrytitle><manvolnum>2</manvolnum></citerefentry>.
 	//
-	MemoryKSM                  bool   `hcl:"memory_ksm,optional" systemd:"MemoryKSM"`
+	MemoryKSM                  *bool  `hcl:"memory_ksm,optional" systemd:"MemoryKSM"`
 	MemoryLow                  string `hcl:"memory_low,optional" systemd:"MemoryLow"`
 	MemoryMax                  string `hcl:"memory_max,optional" systemd:"MemoryMax"`
 	MemoryMin                  string `hcl:"memory_min,optional" systemd:"MemoryMin"`
-	MemoryPressureThresholdSec int    `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
+	MemoryPressureThresholdSec *int   `hcl:"memory_pressure_threshold_sec,optional" systemd:"MemoryPressureThresholdSec"`
 	MemoryPressureWatch        string `hcl:"memory_pressure_watch,optional" systemd:"MemoryPressureWatch"`
 	MemorySwapMax              string `hcl:"memory_swap_max,optional" systemd:"MemorySwapMax"`
 	// Transparent Hugepages (THPs) is a Linux kernel feature that manages memory using larger pages (2MB
@@ -1743,19 +1747,19 @@ type SocketBlock struct {
 	//
 	MemoryTHP            string `hcl:"memory_thp,optional" systemd:"MemoryTHP"`
 	MemoryZSwapMax       string `hcl:"memory_z_swap_max,optional" systemd:"MemoryZSwapMax"`
-	MemoryZSwapWriteback bool   `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
+	MemoryZSwapWriteback *bool  `hcl:"memory_z_swap_writeback,optional" systemd:"MemoryZSwapWriteback"`
 	// These two settings take integer values and control the mq_maxmsg field or the mq_msgsize field,
 	// respectively, when creating the message queue. Note that either none or both of these variables need
 	// to be set. See <citerefentry
 	// project="die-net"><refentrytitle>mq_setattr</refentrytitle><manvolnum>3</manvolnum></citerefentry>
 	// for details.
-	MessageQueueMaxMessages int64 `hcl:"message_queue_max_messages,optional" systemd:"MessageQueueMaxMessages"`
+	MessageQueueMaxMessages *int64 `hcl:"message_queue_max_messages,optional" systemd:"MessageQueueMaxMessages"`
 	// These two settings take integer values and control the mq_maxmsg field or the mq_msgsize field,
 	// respectively, when creating the message queue. Note that either none or both of these variables need
 	// to be set. See <citerefentry
 	// project="die-net"><refentrytitle>mq_setattr</refentrytitle><manvolnum>3</manvolnum></citerefentry>
 	// for details.
-	MessageQueueMessageSize int64 `hcl:"message_queue_message_size,optional" systemd:"MessageQueueMessageSize"`
+	MessageQueueMessageSize *int64 `hcl:"message_queue_message_size,optional" systemd:"MessageQueueMessageSize"`
 	// Takes a boolean argument. If on, a private mount namespace for the unit's processes is created and
 	// the API file systems /proc/, /sys/, /dev/ and /run/ (as an empty tmpfs) are mounted inside of it,
 	// unless they are already mounted. Note that this option has no effect unless used in conjunction with
@@ -1769,7 +1773,7 @@ type SocketBlock struct {
 	// host will be used to set up new mounts, and /run/host/incoming/ in the private namespace will be
 	// used as an intermediate step to store them before being moved to the final mount point.
 	//
-	MountAPIVFS bool `hcl:"mount_apivfs,optional" systemd:"MountAPIVFS"`
+	MountAPIVFS *bool `hcl:"mount_apivfs,optional" systemd:"MountAPIVFS"`
 	// Takes a mount propagation setting: shared, slave or private, which controls whether file system
 	// mount points in the file system namespaces set up for this unit's processes will receive or
 	// propagate mounts and unmounts from other file system namespaces. See <citerefentry
@@ -1877,7 +1881,7 @@ type SocketBlock struct {
 	// <citerefentry
 	// project="die-net"><refentrytitle>tcp</refentrytitle><manvolnum>7</manvolnum></citerefentry>).
 	// Defaults to false.
-	NoDelay bool `hcl:"no_delay,optional" systemd:"NoDelay"`
+	NoDelay *bool `hcl:"no_delay,optional" systemd:"NoDelay"`
 	// Sets up a new file system namespace for executed processes. These options may be used to limit
 	// access a process has to the file system. Each setting takes a space-separated list of paths relative
 	// to the host's root directory (i.e. the system running the service manager). Note that if paths
@@ -1955,7 +1959,7 @@ type SocketBlock struct {
 	// <citerefentry><refentrytitle>systemd-run</refentrytitle><manvolnum>1</manvolnum></citerefentry>, or
 	// arbitrary IPC services.
 	//
-	NoNewPrivileges bool `hcl:"no_new_privileges,optional" systemd:"NoNewPrivileges"`
+	NoNewPrivileges *bool `hcl:"no_new_privileges,optional" systemd:"NoNewPrivileges"`
 	// Sets the adjustment value for the Linux kernel's Out-Of-Memory (OOM) killer score for executed
 	// processes. Takes an integer between -1000 (to disable OOM killing of processes of this unit) and
 	// 1000 (to make killing of processes of this unit under memory pressure very likely). See <ulink
@@ -2001,7 +2005,7 @@ type SocketBlock struct {
 	PAMName string `hcl:"pam_name,optional" systemd:"PAMName"`
 	// Takes a boolean value. This controls the SO_PASSCRED socket option, which allows AF_UNIX sockets to
 	// receive the credentials of the sending process in an ancillary message. Defaults to false.
-	PassCredentials bool `hcl:"pass_credentials,optional" systemd:"PassCredentials"`
+	PassCredentials *bool `hcl:"pass_credentials,optional" systemd:"PassCredentials"`
 	// Pass environment variables set for the system service manager to executed processes. Takes a
 	// space-separated list of variable names. This option may be specified more than once, in which case
 	// all listed variables will be passed. If the empty string is assigned to this option, the list of
@@ -2029,17 +2033,17 @@ type SocketBlock struct {
 	// <citerefentry><refentrytitle>sd_listen_fds</refentrytitle><manvolnum>3</manvolnum></citerefentry> as
 	// if the commands were invoked from the associated service units. Note that ExecStartPre= command
 	// cannot access socket file descriptors.
-	PassFileDescriptorsToExec bool `hcl:"pass_file_descriptors_to_exec,optional" systemd:"PassFileDescriptorsToExec"`
+	PassFileDescriptorsToExec *bool `hcl:"pass_file_descriptors_to_exec,optional" systemd:"PassFileDescriptorsToExec"`
 	// Takes a boolean value. This controls the SO_PASSPIDFD socket option, which allows AF_UNIX sockets to
 	// receive the pidfd of the sending process in an ancillary message. Defaults to false.
-	PassPIDFD bool `hcl:"pass_pidfd,optional" systemd:"PassPIDFD"`
+	PassPIDFD *bool `hcl:"pass_pidfd,optional" systemd:"PassPIDFD"`
 	// Takes a boolean value. This controls the IP_PKTINFO, IPV6_RECVPKTINFO, NETLINK_PKTINFO or
 	// PACKET_AUXDATA socket options, which enable reception of additional per-packet metadata as ancillary
 	// message, on AF_INET, AF_INET6, AF_UNIX and AF_PACKET sockets. Defaults to false.
-	PassPacketInfo bool `hcl:"pass_packet_info,optional" systemd:"PassPacketInfo"`
+	PassPacketInfo *bool `hcl:"pass_packet_info,optional" systemd:"PassPacketInfo"`
 	// Takes a boolean value. This controls the SO_PASSSEC socket option, which allows AF_UNIX sockets to
 	// receive the security context of the sending process in an ancillary message. Defaults to false.
-	PassSecurity bool `hcl:"pass_security,optional" systemd:"PassSecurity"`
+	PassSecurity *bool `hcl:"pass_security,optional" systemd:"PassSecurity"`
 	// Controls which kernel architecture <citerefentry
 	// project="man-pages"><refentrytitle>uname</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// shall report, when invoked by unit processes. Takes one of the architecture identifiers arm64,
@@ -2055,7 +2059,7 @@ type SocketBlock struct {
 	// Takes a size in bytes. Controls the pipe buffer size of FIFOs configured in this socket unit. See
 	// <citerefentry><refentrytitle>fcntl</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details. The usual suffixes K, M, G are supported and are understood to the base of 1024.
-	PipeSize int64 `hcl:"pipe_size,optional" systemd:"PipeSize"`
+	PipeSize *int64 `hcl:"pipe_size,optional" systemd:"PipeSize"`
 	// Configures a limit on how often polling events on the file descriptors backing this socket unit will
 	// be considered. This pair of settings is similar to TriggerLimitIntervalSec=/TriggerLimitBurst= but
 	// instead of putting a (fatal) limit on the activation frequency puts a (transient) limit on the
@@ -2076,7 +2080,7 @@ type SocketBlock struct {
 	// polling limit should typically ensure the trigger limit is never hit, unless one of them is
 	// reconfigured or disabled.
 	//
-	PollLimitBurst uint64 `hcl:"poll_limit_burst,optional" systemd:"PollLimitBurst"`
+	PollLimitBurst *uint64 `hcl:"poll_limit_burst,optional" systemd:"PollLimitBurst"`
 	// Configures a limit on how often polling events on the file descriptors backing this socket unit will
 	// be considered. This pair of settings is similar to TriggerLimitIntervalSec=/TriggerLimitBurst= but
 	// instead of putting a (fatal) limit on the activation frequency puts a (transient) limit on the
@@ -2097,12 +2101,12 @@ type SocketBlock struct {
 	// polling limit should typically ensure the trigger limit is never hit, unless one of them is
 	// reconfigured or disabled.
 	//
-	PollLimitIntervalSec int `hcl:"poll_limit_interval_sec,optional" systemd:"PollLimitIntervalSec"`
+	PollLimitIntervalSec *int `hcl:"poll_limit_interval_sec,optional" systemd:"PollLimitIntervalSec"`
 	// Takes an integer argument controlling the priority for all traffic sent from this socket. This
 	// controls the SO_PRIORITY socket option (see <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> for
 	// details.).
-	Priority int `hcl:"priority,optional" systemd:"Priority"`
+	Priority *int `hcl:"priority,optional" systemd:"Priority"`
 	// Takes a boolean argument. If set, mount a private instance of the BPF filesystem on /sys/fs/bpf/,
 	// effectively hiding the host bpffs which contains information about loaded programs and maps.
 	// Otherwise, if ProtectKernelTunables= is set, the instance from the host is inherited but mounted
@@ -2144,7 +2148,7 @@ type SocketBlock struct {
 	// instead. See
 	// <citerefentry><refentrytitle>systemd.resource-control</refentrytitle><manvolnum>5</manvolnum></citerefentry>.
 	//
-	PrivateDevices bool `hcl:"private_devices,optional" systemd:"PrivateDevices"`
+	PrivateDevices *bool `hcl:"private_devices,optional" systemd:"PrivateDevices"`
 	// Takes a boolean argument. If true, sets up a new IPC namespace for the executed processes. Each IPC
 	// namespace has its own set of System V IPC identifiers and its own POSIX message queue file system.
 	// This is useful to avoid name clash of IPC identifiers. Defaults to false. It is possible to run two
@@ -2165,7 +2169,7 @@ type SocketBlock struct {
 	// not available), and the unit should be written in a way that does not solely rely on this setting
 	// for security.
 	//
-	PrivateIPC bool `hcl:"private_ipc,optional" systemd:"PrivateIPC"`
+	PrivateIPC *bool `hcl:"private_ipc,optional" systemd:"PrivateIPC"`
 	// Takes a boolean parameter. If set, the processes of this unit will be run in their own private file
 	// system (mount) namespace with all mount propagation from the processes towards the host's main file
 	// system namespace turned off. This means any file system mount points established or removed by the
@@ -2193,7 +2197,7 @@ type SocketBlock struct {
 	// — also enable file system namespacing in a fashion equivalent to this option. Hence it is primarily
 	// useful to explicitly request this behaviour if none of the other settings are used.
 	//
-	PrivateMounts bool `hcl:"private_mounts,optional" systemd:"PrivateMounts"`
+	PrivateMounts *bool `hcl:"private_mounts,optional" systemd:"PrivateMounts"`
 	// Takes a boolean argument. If true, sets up a new network namespace for the executed processes and
 	// configures only the loopback network device lo inside it. No other network devices will be available
 	// to the executed process. This is useful to turn off network access by the executed process. Defaults
@@ -2219,7 +2223,7 @@ type SocketBlock struct {
 	// within a private network namespace. This may be combined with JoinsNamespaceOf= to listen on sockets
 	// inside of network namespaces of other services.
 	//
-	PrivateNetwork bool `hcl:"private_network,optional" systemd:"PrivateNetwork"`
+	PrivateNetwork *bool `hcl:"private_network,optional" systemd:"PrivateNetwork"`
 	// Takes a boolean argument. Defaults to false. If enabled, sets up a new PID namespace for the
 	// executed processes. Each executed process is now PID 1 - the init process - in the new namespace.
 	// /proc/ is mounted such that only processes in the PID namespace are visible. If PrivatePIDs= is set,
@@ -2310,7 +2314,7 @@ type SocketBlock struct {
 	// It is recommended to turn this on for most services that do not need modify the clock or check its
 	// state.
 	//
-	ProtectClock bool `hcl:"protect_clock,optional" systemd:"ProtectClock"`
+	ProtectClock *bool `hcl:"protect_clock,optional" systemd:"ProtectClock"`
 	// Takes a boolean argument or the special values private or strict. If true, the Linux Control Groups
 	// (<citerefentry project="man-pages">
 	// <refentrytitle>cgroups</refentrytitle><manvolnum>7</manvolnum></citerefentry>) hierarchies
@@ -2379,7 +2383,7 @@ type SocketBlock struct {
 	// project="man-pages"><refentrytitle>syslog</refentrytitle><manvolnum>3</manvolnum></citerefentry> for
 	// userspace logging). The kernel exposes its log buffer to userspace via /dev/kmsg and /proc/kmsg. If
 	// enabled, these are made inaccessible to all the processes in the unit.
-	ProtectKernelLogs bool `hcl:"protect_kernel_logs,optional" systemd:"ProtectKernelLogs"`
+	ProtectKernelLogs *bool `hcl:"protect_kernel_logs,optional" systemd:"ProtectKernelLogs"`
 	// Takes a boolean argument. If true, explicit module loading will be denied. This allows module load
 	// and unload operations to be turned off on modular kernels. It is recommended to turn this on for
 	// most services that do not need special file systems or extra kernel modules to work. Defaults to
@@ -2391,7 +2395,7 @@ type SocketBlock struct {
 	// user operations, both privileged and unprivileged. To disable module auto-load feature please see
 	// <citerefentry><refentrytitle>sysctl.d</refentrytitle><manvolnum>5</manvolnum></citerefentry>
 	// kernel.modules_disabled mechanism and /proc/sys/kernel/modules_disabled documentation.
-	ProtectKernelModules bool `hcl:"protect_kernel_modules,optional" systemd:"ProtectKernelModules"`
+	ProtectKernelModules *bool `hcl:"protect_kernel_modules,optional" systemd:"ProtectKernelModules"`
 	// Takes a boolean argument. If true, kernel variables accessible through /proc/sys/, /sys/,
 	// /proc/sysrq-trigger, /proc/latency_stats, /proc/acpi, /proc/timer_stats, /proc/fs and /proc/irq will
 	// be made read-only and /proc/kallsyms as well as /proc/kcore will be inaccessible to all processes of
@@ -2404,7 +2408,7 @@ type SocketBlock struct {
 	// does not prevent indirect changes to kernel tunables affected by IPC calls to other processes.
 	// However, InaccessiblePaths= may be used to make relevant IPC file system objects inaccessible. If
 	// ProtectKernelTunables= is set, MountAPIVFS=yes is implied.
-	ProtectKernelTunables bool `hcl:"protect_kernel_tunables,optional" systemd:"ProtectKernelTunables"`
+	ProtectKernelTunables *bool `hcl:"protect_kernel_tunables,optional" systemd:"ProtectKernelTunables"`
 	// Takes one of noaccess, invisible, ptraceable or default (which it defaults to). When set, this
 	// controls the hidepid= mount option of the procfs instance for the unit that controls which
 	// directories with process metainformation (/proc/PID) are visible and accessible: when set to
@@ -2571,7 +2575,7 @@ type SocketBlock struct {
 	// This controls the SO_RCVBUF and SO_SNDBUF socket options (see <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> for
 	// details.). The usual suffixes K, M, G are supported and are understood to the base of 1024.
-	ReceiveBuffer int64 `hcl:"receive_buffer,optional" systemd:"ReceiveBuffer"`
+	ReceiveBuffer *int64 `hcl:"receive_buffer,optional" systemd:"ReceiveBuffer"`
 	// Takes a boolean parameter. If set, all System V and POSIX IPC objects owned by the user and group
 	// the processes of this unit are run as are removed when the unit is stopped. This setting only has an
 	// effect if at least one of User=, Group= and DynamicUser= are used. It has no effect on IPC objects
@@ -2579,14 +2583,14 @@ type SocketBlock struct {
 	// POSIX shared memory segments and message queues. If multiple units use the same user or group the
 	// IPC objects are removed when the last of these units is stopped. This setting is implied if
 	// DynamicUser= is set.
-	RemoveIPC bool `hcl:"remove_ipc,optional" systemd:"RemoveIPC"`
+	RemoveIPC *bool `hcl:"remove_ipc,optional" systemd:"RemoveIPC"`
 	// Takes a boolean argument. If enabled, any file nodes created by this socket unit are removed when it
 	// is stopped. This applies to AF_UNIX sockets in the file system, POSIX message queues, FIFOs, as well
 	// as any symlinks to them configured with Symlinks=. Normally, it should not be necessary to use this
 	// option, and is not recommended as services might continue to run after the socket unit has been
 	// terminated and it should still be possible to communicate with them via their file system node.
 	// Defaults to off.
-	RemoveOnStop bool `hcl:"remove_on_stop,optional" systemd:"RemoveOnStop"`
+	RemoveOnStop *bool `hcl:"remove_on_stop,optional" systemd:"RemoveOnStop"`
 	// Specifies which signal to use when restarting a service. The same as KillSignal= described above,
 	// with the exception that this setting is used in a restart job. Not set by default, and the value of
 	// KillSignal= is used.
@@ -2666,7 +2670,7 @@ type SocketBlock struct {
 	// time for longer periods of time, and may hence be used to lock up or otherwise trigger
 	// Denial-of-Service situations on the system. It is hence recommended to restrict access to realtime
 	// scheduling to the few programs that actually require them. Defaults to off.
-	RestrictRealtime bool `hcl:"restrict_realtime,optional" systemd:"RestrictRealtime"`
+	RestrictRealtime *bool `hcl:"restrict_realtime,optional" systemd:"RestrictRealtime"`
 	// Takes a boolean argument. If set, any attempts to set the set-user-ID (SUID) or set-group-ID (SGID)
 	// bits on files or directories will be denied (for details on these bits see <citerefentry
 	// project="man-pages"><refentrytitle>inode</refentrytitle><manvolnum>7</manvolnum></citerefentry>). As
@@ -2680,13 +2684,13 @@ type SocketBlock struct {
 	// <citerefentry><refentrytitle>systemd-system.conf</refentrytitle><manvolnum>5</manvolnum></citerefentry>,
 	// which defaults to off.
 	//
-	RestrictSUIDSGID bool `hcl:"restrict_suidsgid,optional" systemd:"RestrictSUIDSGID"`
+	RestrictSUIDSGID *bool `hcl:"restrict_suidsgid,optional" systemd:"RestrictSUIDSGID"`
 	// Takes a boolean value. If true, allows multiple
 	// <citerefentry><refentrytitle>bind</refentrytitle><manvolnum>2</manvolnum></citerefentry>s to this
 	// TCP or UDP port. This controls the SO_REUSEPORT socket option. See <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> for
 	// details.
-	ReusePort bool `hcl:"reuse_port,optional" systemd:"ReusePort"`
+	ReusePort *bool `hcl:"reuse_port,optional" systemd:"ReusePort"`
 	// Takes a directory path relative to the host's root directory (i.e. the root of the system running
 	// the service manager). Sets the root directory for executed processes, with the <citerefentry
 	// project="man-pages"><refentrytitle>pivot_root</refentrytitle><manvolnum>2</manvolnum></citerefentry>
@@ -2729,7 +2733,7 @@ type SocketBlock struct {
 	// can snapshot to make the ephemeral copy. For root images, a filesystem with support for reflinks
 	// should be used to ensure an efficient ephemeral copy.
 	//
-	RootEphemeral bool `hcl:"root_ephemeral,optional" systemd:"RootEphemeral"`
+	RootEphemeral *bool `hcl:"root_ephemeral,optional" systemd:"RootEphemeral"`
 	// Takes a data integrity (dm-verity) root hash specified in hexadecimal, or the path to a file
 	// containing a root hash in ASCII hexadecimal format. This option enables data integrity checks using
 	// dm-verity, if the used image contains the appropriate integrity data (see above) or if RootVerity=
@@ -2876,7 +2880,7 @@ type SocketBlock struct {
 	// activated service is passed in single socket file descriptor, i.e. service instances that have
 	// standard input connected to a socket or services triggered by exactly one socket unit. Also note
 	// that this option is useful only when MLS/MCS SELinux policy is deployed. Defaults to false.
-	SELinuxContextFromNet bool `hcl:"se_linux_context_from_net,optional" systemd:"SELinuxContextFromNet"`
+	SELinuxContextFromNet *bool `hcl:"se_linux_context_from_net,optional" systemd:"SELinuxContextFromNet"`
 	// Controls the secure bits set for the executed process. Takes a space-separated combination of
 	// options from the following list: keep-caps, keep-caps-locked, no-setuid-fixup,
 	// no-setuid-fixup-locked, noroot, and noroot-locked. This option may appear more than once, in which
@@ -2889,16 +2893,16 @@ type SocketBlock struct {
 	// This controls the SO_RCVBUF and SO_SNDBUF socket options (see <citerefentry
 	// project="man-pages"><refentrytitle>socket</refentrytitle><manvolnum>7</manvolnum></citerefentry> for
 	// details.). The usual suffixes K, M, G are supported and are understood to the base of 1024.
-	SendBuffer int64 `hcl:"send_buffer,optional" systemd:"SendBuffer"`
+	SendBuffer *int64 `hcl:"send_buffer,optional" systemd:"SendBuffer"`
 	// Specifies whether to send SIGHUP to remaining processes immediately after sending the signal
 	// configured with KillSignal=. This is useful to indicate to shells and shell-like programs that their
 	// connection has been severed. Takes a boolean value. Defaults to no.
-	SendSIGHUP bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
+	SendSIGHUP *bool `hcl:"send_sighup,optional" systemd:"SendSIGHUP"`
 	// Specifies whether to send SIGKILL (or the signal specified by FinalKillSignal=) to remaining
 	// processes after a timeout, if the normal shutdown procedure left processes of the service around.
 	// When disabled, a KillMode= of control-group or mixed service will not restart if processes from
 	// prior services exist within the control group. Takes a boolean value. Defaults to yes.
-	SendSIGKILL bool `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
+	SendSIGKILL *bool `hcl:"send_sigkill,optional" systemd:"SendSIGKILL"`
 	// Specifies the service unit name to activate on incoming traffic. This setting is only allowed for
 	// sockets with Accept=no. It defaults to the service that bears the same name as the socket (with the
 	// suffix replaced). In most cases, it should not be necessary to use this option. Note that setting
@@ -2953,7 +2957,7 @@ type SocketBlock struct {
 	// manager, no matter whether User=, DynamicUser=, or PAMName= are used or not. This option normally
 	// has no effect on services of the per-user service manager, since in that case these variables are
 	// typically inherited from user manager's own environment anyway.
-	SetLoginEnvironment bool   `hcl:"set_login_environment,optional" systemd:"SetLoginEnvironment"`
+	SetLoginEnvironment *bool  `hcl:"set_login_environment,optional" systemd:"SetLoginEnvironment"`
 	Slice               string `hcl:"slice,optional" systemd:"Slice"`
 	// Takes a string value. Controls the extended attributes security.SMACK64, security.SMACK64IPIN and
 	// security.SMACK64IPOUT, respectively, i.e. the security label of the FIFO, or the security label for
@@ -3198,16 +3202,16 @@ type SocketBlock struct {
 	// which defaults to journal. Note that setting this parameter might result in additional dependencies
 	// to be added to the unit (see above).
 	//
-	StandardOutput            string `hcl:"standard_output,optional" systemd:"StandardOutput"`
-	StartupAllowedCPUs        string `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
-	StartupAllowedMemoryNodes string `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
-	StartupCPUWeight          uint64 `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
-	StartupIOWeight           uint64 `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
-	StartupMemoryHigh         string `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
-	StartupMemoryLow          string `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
-	StartupMemoryMax          string `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
-	StartupMemorySwapMax      string `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
-	StartupMemoryZSwapMax     string `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
+	StandardOutput            string  `hcl:"standard_output,optional" systemd:"StandardOutput"`
+	StartupAllowedCPUs        string  `hcl:"startup_allowed_cp_us,optional" systemd:"StartupAllowedCPUs"`
+	StartupAllowedMemoryNodes string  `hcl:"startup_allowed_memory_nodes,optional" systemd:"StartupAllowedMemoryNodes"`
+	StartupCPUWeight          *uint64 `hcl:"startup_cpu_weight,optional" systemd:"StartupCPUWeight"`
+	StartupIOWeight           *uint64 `hcl:"startup_io_weight,optional" systemd:"StartupIOWeight"`
+	StartupMemoryHigh         string  `hcl:"startup_memory_high,optional" systemd:"StartupMemoryHigh"`
+	StartupMemoryLow          string  `hcl:"startup_memory_low,optional" systemd:"StartupMemoryLow"`
+	StartupMemoryMax          string  `hcl:"startup_memory_max,optional" systemd:"StartupMemoryMax"`
+	StartupMemorySwapMax      string  `hcl:"startup_memory_swap_max,optional" systemd:"StartupMemorySwapMax"`
+	StartupMemoryZSwapMax     string  `hcl:"startup_memory_z_swap_max,optional" systemd:"StartupMemoryZSwapMax"`
 	// /var/lib/
 	StateDirectory []string `hcl:"state_directory,optional" systemd:"StateDirectory"`
 	// Takes a boolean argument. If true, a project ID is assigned to the directories specified in
@@ -3218,7 +3222,7 @@ type SocketBlock struct {
 	// To set and enforce disk quotas, StateDirectoryQuota=, CacheDirectoryQuota=, or LogsDirectoryQuota=
 	// must be specified.
 	//
-	StateDirectoryAccounting bool `hcl:"state_directory_accounting,optional" systemd:"StateDirectoryAccounting"`
+	StateDirectoryAccounting *bool `hcl:"state_directory_accounting,optional" systemd:"StateDirectoryAccounting"`
 	// Specifies the access mode of the directories specified in RuntimeDirectory=, StateDirectory=,
 	// CacheDirectory=, LogsDirectory=, or ConfigurationDirectory=, respectively, as an octal number.
 	// Defaults to 0755. See "Permissions" in <citerefentry
@@ -3280,7 +3284,7 @@ type SocketBlock struct {
 	// interpretation of these prefixes may be disabled with SyslogLevelPrefix=, see below. For details,
 	// see <citerefentry><refentrytitle>sd-daemon</refentrytitle><manvolnum>3</manvolnum></citerefentry>.
 	// Defaults to info.
-	SyslogLevel int `hcl:"syslog_level,optional" systemd:"SyslogLevel"`
+	SyslogLevel *int `hcl:"syslog_level,optional" systemd:"SyslogLevel"`
 	// Takes a boolean argument. If true and StandardOutput= or StandardError= are set to journal or kmsg
 	// (or to the same settings in combination with +console), log lines written by the executed process
 	// that are prefixed with a log level will be processed with this log level set but the prefix removed.
@@ -3289,7 +3293,7 @@ type SocketBlock struct {
 	// prefixing see
 	// <citerefentry><refentrytitle>sd-daemon</refentrytitle><manvolnum>3</manvolnum></citerefentry>.
 	// Defaults to true.
-	SyslogLevelPrefix bool `hcl:"syslog_level_prefix,optional" systemd:"SyslogLevelPrefix"`
+	SyslogLevelPrefix *bool `hcl:"syslog_level_prefix,optional" systemd:"SyslogLevelPrefix"`
 	// Takes a space-separated list of architecture identifiers to include in the system call filter. The
 	// known architecture identifiers are the same as for ConditionArchitecture= described in
 	// <citerefentry><refentrytitle>systemd.unit</refentrytitle><manvolnum>5</manvolnum></citerefentry>, as
@@ -3550,26 +3554,26 @@ type SocketBlock struct {
 	// Configure the size of the TTY specified with TTYPath=. If unset or set to the empty string, it is
 	// attempted to retrieve the dimensions of the terminal screen via ANSI sequences, and if that fails
 	// the kernel defaults (typically 80x24) are used.
-	TTYColumns uint64 `hcl:"tty_columns,optional" systemd:"TTYColumns"`
+	TTYColumns *uint64 `hcl:"tty_columns,optional" systemd:"TTYColumns"`
 	// Sets the terminal device node to use if standard input, output, or error are connected to a TTY (see
 	// above). Defaults to /dev/console.
 	TTYPath string `hcl:"tty_path,optional" systemd:"TTYPath"`
 	// Reset the terminal device specified with TTYPath= before and after execution. This does not erase
 	// the screen (see TTYVTDisallocate= below for that). Defaults to no.
-	TTYReset bool `hcl:"tty_reset,optional" systemd:"TTYReset"`
+	TTYReset *bool `hcl:"tty_reset,optional" systemd:"TTYReset"`
 	// Configure the size of the TTY specified with TTYPath=. If unset or set to the empty string, it is
 	// attempted to retrieve the dimensions of the terminal screen via ANSI sequences, and if that fails
 	// the kernel defaults (typically 80x24) are used.
-	TTYRows uint64 `hcl:"tty_rows,optional" systemd:"TTYRows"`
+	TTYRows *uint64 `hcl:"tty_rows,optional" systemd:"TTYRows"`
 	// Disconnect all clients which have opened the terminal device specified with TTYPath= before and
 	// after execution. Defaults to no.
-	TTYVHangup bool `hcl:"ttyv_hangup,optional" systemd:"TTYVHangup"`
+	TTYVHangup *bool `hcl:"ttyv_hangup,optional" systemd:"TTYVHangup"`
 	// If the terminal device specified with TTYPath= is a virtual console terminal, try to deallocate the
 	// TTY before and after execution. This ensures that the screen and scrollback buffer is cleared. If
 	// the terminal device is of any other type of TTY an attempt is made to clear the screen via ANSI
 	// sequences. Defaults to no.
-	TTYVTDisallocate bool   `hcl:"ttyvt_disallocate,optional" systemd:"TTYVTDisallocate"`
-	TasksAccounting  bool   `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
+	TTYVTDisallocate *bool  `hcl:"ttyvt_disallocate,optional" systemd:"TTYVTDisallocate"`
+	TasksAccounting  *bool  `hcl:"tasks_accounting,optional" systemd:"TasksAccounting"`
 	TasksMax         string `hcl:"tasks_max,optional" systemd:"TasksMax"`
 	// Takes a space-separated list of mount points for temporary file systems (tmpfs). If set, a new file
 	// system namespace is set up for executed processes, and a temporary file system is mounted on each
@@ -3594,7 +3598,7 @@ type SocketBlock struct {
 	// details. Takes the usual time values and defaults to infinity, i.e. by default no timeout is
 	// applied. If a timeout is configured the clean operation will be aborted forcibly when the timeout is
 	// reached, potentially leaving resources on disk.
-	TimeoutCleanSec int `hcl:"timeout_clean_sec,optional" systemd:"TimeoutCleanSec"`
+	TimeoutCleanSec *int `hcl:"timeout_clean_sec,optional" systemd:"TimeoutCleanSec"`
 	// Configures the time to wait for the commands specified in ExecStartPre=, ExecStartPost=,
 	// ExecStopPre= and ExecStopPost= to finish. If a command does not exit within the configured time, the
 	// socket will be considered failed and be shut down again. All commands still running will be
@@ -3604,20 +3608,20 @@ type SocketBlock struct {
 	// Takes a unit-less value in seconds, or a time span value such as "5min 20s". Pass 0 to disable the
 	// timeout logic. Defaults to DefaultTimeoutStartSec= from the manager configuration file (see
 	// <citerefentry><refentrytitle>systemd-system.conf</refentrytitle><manvolnum>5</manvolnum></citerefentry>).
-	TimeoutSec int `hcl:"timeout_sec,optional" systemd:"TimeoutSec"`
+	TimeoutSec *int `hcl:"timeout_sec,optional" systemd:"TimeoutSec"`
 	// Sets the timer slack in nanoseconds for the executed processes. The timer slack controls the
 	// accuracy of wake-ups triggered by timers. See
 	// <citerefentry><refentrytitle>prctl</refentrytitle><manvolnum>2</manvolnum></citerefentry> for more
 	// information. Note that in contrast to most other time span definitions this parameter takes an
 	// integer value in nano-seconds if no unit is specified. The usual time units are understood too.
-	TimerSlackNSec int `hcl:"timer_slack_n_sec,optional" systemd:"TimerSlackNSec"`
+	TimerSlackNSec *int `hcl:"timer_slack_n_sec,optional" systemd:"TimerSlackNSec"`
 	// Takes one of off, us (alias: usec, μs) or ns (alias: nsec). This controls the SO_TIMESTAMP or
 	// SO_TIMESTAMPNS socket options, and enables whether ingress network traffic shall carry timestamping
 	// metadata. Defaults to off.
 	Timestamping string `hcl:"timestamping,optional" systemd:"Timestamping"`
 	// Takes a boolean value. Controls the IP_TRANSPARENT/IPV6_TRANSPARENT socket option. Defaults to
 	// false.
-	Transparent bool `hcl:"transparent,optional" systemd:"Transparent"`
+	Transparent *bool `hcl:"transparent,optional" systemd:"Transparent"`
 	// Configures a limit on how often this socket unit may be activated within a specific time interval.
 	// The TriggerLimitIntervalSec= setting may be used to configure the length of the time interval in the
 	// usual time units us, ms, s, min, h, … and defaults to 2s (See
@@ -3634,7 +3638,7 @@ type SocketBlock struct {
 	// slowdown if a socket unit is flooded with incoming traffic, as opposed to the permanent failure
 	// state TriggerLimitIntervalSec=/TriggerLimitBurst= results in.
 	//
-	TriggerLimitBurst uint64 `hcl:"trigger_limit_burst,optional" systemd:"TriggerLimitBurst"`
+	TriggerLimitBurst *uint64 `hcl:"trigger_limit_burst,optional" systemd:"TriggerLimitBurst"`
 	// Configures a limit on how often this socket unit may be activated within a specific time interval.
 	// The TriggerLimitIntervalSec= setting may be used to configure the length of the time interval in the
 	// usual time units us, ms, s, min, h, … and defaults to 2s (See
@@ -3651,7 +3655,7 @@ type SocketBlock struct {
 	// slowdown if a socket unit is flooded with incoming traffic, as opposed to the permanent failure
 	// state TriggerLimitIntervalSec=/TriggerLimitBurst= results in.
 	//
-	TriggerLimitIntervalSec int `hcl:"trigger_limit_interval_sec,optional" systemd:"TriggerLimitIntervalSec"`
+	TriggerLimitIntervalSec *int `hcl:"trigger_limit_interval_sec,optional" systemd:"TriggerLimitIntervalSec"`
 	// Controls the file mode creation mask. Takes an access mode in octal notation. See
 	// <citerefentry><refentrytitle>umask</refentrytitle><manvolnum>2</manvolnum></citerefentry> for
 	// details. Defaults to 0022 for system units. For user units the default value is inherited from the
@@ -3762,7 +3766,7 @@ type SocketBlock struct {
 	WorkingDirectory string `hcl:"working_directory,optional" systemd:"WorkingDirectory"`
 	// Takes a boolean argument. May only be used in conjunction with ListenSpecial=. If true, the
 	// specified special file is opened in read-write mode, if false, in read-only mode. Defaults to false.
-	Writable bool `hcl:"writable,optional" systemd:"Writable"`
+	Writable *bool `hcl:"writable,optional" systemd:"Writable"`
 }
 
 type Socket struct {
@@ -9,21 +9,22 @@ import (
 
 // TimerBlock is for [Timer] systemd unit block
 //
-// A unit configuration file whose name ends in encodes information about a timer controlled and
+// A unit configuration file whose name ends in .timer encodes information about a timer controlled and
 // supervised by systemd, for timer-based activation. This man page lists the configuration options
-// specific to this unit type. See for the common options of all unit configuration files. The common
-// configuration items are configured in the generic [Unit] and [Install] sections. The timer specific
-// configuration options are configured in the [Timer] section. For each timer file, a matching unit
-// file must exist, describing the unit to activate when the timer elapses. By default, a service by
-// the same name as the timer (except for the suffix) is activated. Example: a timer file activates a
-// matching service . The unit to activate may be controlled by (see below). Note that in case the unit
-// to activate is already active at the time the timer elapses it is not restarted, but simply left
-// running. There is no concept of spawning new service instances in this case. Due to this, services
-// with set (which stay around continuously even after the service's main process exited) are usually
-// not suitable for activation via repetitive timers, as they will only be activated once, and then
-// stay around forever. Target units, which by default do not deactivate on their own, can be activated
-// repeatedly by timers by setting on them. This will cause a target unit to be stopped immediately
-// after its activation, if it is not a dependency of another running unit.
+// specific to this unit type. See systemd.unit(5) for the common options of all unit configuration
+// files. The common configuration items are configured in the generic [Unit] and [Install] sections.
+// The timer specific configuration options are configured in the [Timer] section. For each timer file,
+// a matching unit file must exist, describing the unit to activate when the timer elapses. By default,
+// a service by the same name as the timer (except for the suffix) is activated. Example: a timer file
+// foo.timer activates a matching service foo.service. The unit to activate may be controlled by Unit=
+// (see below). Note that in case the unit to activate is already active at the time the timer elapses
+// it is not restarted, but simply left running. There is no concept of spawning new service instances
+// in this case. Due to this, services with RemainAfterExit= set (which stay around continuously even
+// after the service's main process exited) are usually not suitable for activation via repetitive
+// timers, as they will only be activated once, and then stay around forever. Target units, which by
+// default do not deactivate on their own, can be activated repeatedly by timers by setting
+// StopWhenUnneeded=yes on them. This will cause a target unit to be stopped immediately after its
+// activation, if it is not a dependency of another running unit.
 type TimerBlock struct {
 	// Specify the accuracy the timer shall elapse with. Defaults to 1min. The timer is scheduled to elapse
 	// within a time window starting with the time specified in OnCalendar=, OnActiveSec=, OnBootSec=,
@@ -44,7 +45,7 @@ type TimerBlock struct {
 	// over a longer period to reduce workload spikes. For further details and explanations and how both
 	// settings play together, see below.
 	//
-	AccuracySec int `hcl:"accuracy_sec,optional" systemd:"AccuracySec"`
+	AccuracySec *int `hcl:"accuracy_sec,optional" systemd:"AccuracySec"`
 	// Takes a boolean argument. When enabled, the timer schedules the next elapse based on the trigger
 	// unit entering inactivity, instead of the last trigger time. This is most apparent in the case where
 	// the service unit takes longer to run than the timer interval. With this setting enabled, the timer
@@ -58,7 +59,7 @@ type TimerBlock struct {
 	// This setting has an effect only if a realtime timer has been specified with OnCalendar=. Defaults to
 	// false.
 	//
-	DeferReactivation bool `hcl:"defer_reactivation,optional" systemd:"DeferReactivation"`
+	DeferReactivation *bool `hcl:"defer_reactivation,optional" systemd:"DeferReactivation"`
 	// Takes a boolean argument. When enabled, the randomized delay specified by RandomizedDelaySec= is
 	// chosen deterministically, and remains stable between all firings of the same timer, even if the
 	// manager is restarted. The delay is derived from the machine ID, the manager's user identifier, and
@@ -68,7 +69,7 @@ type TimerBlock struct {
 	//
 	// This setting has an effect only if RandomizedDelaySec= is not 0. Defaults to false.
 	//
-	FixedRandomDelay bool `hcl:"fixed_random_delay,optional" systemd:"FixedRandomDelay"`
+	FixedRandomDelay *bool `hcl:"fixed_random_delay,optional" systemd:"FixedRandomDelay"`
 	// Defines a timer relative to the moment the timer unit itself is activated.
 	OnActiveSec time.Duration `unitd:"on_active_sec,optional" systemd:"OnActiveSec"`
 	// Defines a timer relative to when the machine was booted up. In containers, for the system manager
@@ -110,7 +111,7 @@ type TimerBlock struct {
 	// clock (CLOCK_REALTIME) jumps relative to the monotonic clock (CLOCK_MONOTONIC), or when the local
 	// system timezone is modified. These options can be used alone or in combination with other timer
 	// expressions (see above) within the same timer unit. These options default to false.
-	OnClockChange bool `hcl:"on_clock_change,optional" systemd:"OnClockChange"`
+	OnClockChange *bool `hcl:"on_clock_change,optional" systemd:"OnClockChange"`
 	// Defines a timer relative to when the service manager was first started. For system timer units this
 	// is very similar to OnBootSec= as the system service manager is generally started very early at boot.
 	// It's primarily useful when configured in units running in the per-user service manager, as the user
@@ -120,7 +121,7 @@ type TimerBlock struct {
 	// clock (CLOCK_REALTIME) jumps relative to the monotonic clock (CLOCK_MONOTONIC), or when the local
 	// system timezone is modified. These options can be used alone or in combination with other timer
 	// expressions (see above) within the same timer unit. These options default to false.
-	OnTimezoneChange bool `hcl:"on_timezone_change,optional" systemd:"OnTimezoneChange"`
+	OnTimezoneChange *bool `hcl:"on_timezone_change,optional" systemd:"OnTimezoneChange"`
 	// Defines a timer relative to when the unit the timer unit is activating was last activated.
 	OnUnitActiveSec time.Duration `unitd:"on_unit_active_sec,optional" systemd:"OnUnitActiveSec"`
 	// Defines a timer relative to when the unit the timer unit is activating was last deactivated.
@@ -137,7 +138,7 @@ type TimerBlock struct {
 	// <citerefentry><refentrytitle>systemctl</refentrytitle><manvolnum>1</manvolnum></citerefentry> for
 	// details.
 	//
-	Persistent bool `hcl:"persistent,optional" systemd:"Persistent"`
+	Persistent *bool `hcl:"persistent,optional" systemd:"Persistent"`
 	// Delay the timer by a randomly selected, evenly distributed amount of time between 0 and the
 	// specified time value. Defaults to 0, indicating that no randomized delay shall be applied. Each
 	// timer unit will determine this delay randomly before each iteration, unless modified with
@@ -158,7 +159,7 @@ type TimerBlock struct {
 	// timer events over a certain range of time, set AccuracySec=1us and RandomizedDelaySec= to some
 	// higher value.
 	//
-	RandomizedDelaySec int `hcl:"randomized_delay_sec,optional" systemd:"RandomizedDelaySec"`
+	RandomizedDelaySec *int `hcl:"randomized_delay_sec,optional" systemd:"RandomizedDelaySec"`
 	// Offsets the timer by a stable, randomly-selected, and evenly distributed amount of time between 0
 	// and the specified time value. Defaults to 0, indicating that no such offset shall be applied. The
 	// offset is chosen deterministically, and is derived the same way as FixedRandomDelay=, see above. The
@@ -180,7 +181,7 @@ type TimerBlock struct {
 	// the next shutdown. Instead, you should use RandomizedOffsetSec=, which will maintain the configured
 	// weekly cadence of timer events, even across reboots.
 	//
-	RandomizedOffsetSec int `hcl:"randomized_offset_sec,optional" systemd:"RandomizedOffsetSec"`
+	RandomizedOffsetSec *int `hcl:"randomized_offset_sec,optional" systemd:"RandomizedOffsetSec"`
 	// Takes a boolean argument. If true, a timer will stay loaded, and its state remains queryable even
 	// after it elapsed and the associated unit (as configured with Unit=, see above) deactivated again. If
 	// false, an elapsed timer unit that cannot elapse anymore is unloaded once its associated unit
@@ -189,7 +190,7 @@ type TimerBlock struct {
 	// the timer a second time has no effect. However, if RemainAfterElapse= is off and the timer unit was
 	// already unloaded, it can be started again, and thus the service can be triggered multiple times.
 	// Defaults to true.
-	RemainAfterElapse bool `hcl:"remain_after_elapse,optional" systemd:"RemainAfterElapse"`
+	RemainAfterElapse *bool `hcl:"remain_after_elapse,optional" systemd:"RemainAfterElapse"`
 	// The unit to activate when this timer elapses. The argument is a unit name, whose suffix is not
 	// .timer. If not specified, this value defaults to a service that has the same name as the timer unit,
 	// except for the suffix. (See above.) It is recommended that the unit name that is activated and the
@@ -210,7 +211,7 @@ type TimerBlock struct {
 	// see <citerefentry><refentrytitle>clock_getres</refentrytitle><manvolnum>2</manvolnum></citerefentry>
 	// for details.
 	//
-	WakeSystem bool `hcl:"wake_system,optional" systemd:"WakeSystem"`
+	WakeSystem *bool `hcl:"wake_system,optional" systemd:"WakeSystem"`
 }
 
 type Timer struct {
@@ -3,17 +3,41 @@ package configs
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/hashicorp/hcl/v2"
 )
 
+// resetValue is bound to the HCL `reset` variable. Assigning it to a string
+// directive, using it as a list element or as the value of any variable of
+// a map directive emits an empty assignment (`ExecStart=`), which makes
+// systemd discard every earlier value of that directive — typically the ones
+// from a vendor unit overridden by a drop-in. In a map, the reset comes
+// first and the variable holding it is not written.
+const resetValue = string(resetMark) + "reset"
+
+// resetMark starts resetValue. It cannot appear in any other value.
+const resetMark = '\uE000'
+
+// IsReset reports whether a decoded value is the HCL `reset` variable.
+func IsReset(value string) bool {
+	return value == resetValue
+}
+
 type UnitCodec[T any] interface {
 	Encode(T) (*SystemdUnit, error)
 	Decode(*SystemdUnit) (T, error)
 }
 
+// Entry is one "Key=Value" line of a unit file section. Value is the escaped
+// text as written to the file (see EscapeValue). Range is set for entries
+// read by ParseUnitFile.
 type Entry struct {
 	Key   string
 	Value string
+	Range hcl.Range
 }
 
 type SystemdUnit struct {
@@ -35,6 +59,9 @@ func EncodeSystemdSection(v any) ([]Entry, error) {
 	if rv.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("EncodeSystemdSection: expected struct, got %s", rv.Kind())
 	}
+	if err := ValidateSectionEnvironment(rv.Interface()); err != nil {
+		return nil, err
+	}
 
 	for i := 0; i < rt.NumField(); i++ {
 		field := rt.Field(i)
@@ -52,9 +79,13 @@ func EncodeSystemdSection(v any) ([]Entry, error) {
 		switch value.Kind() {
 
 		case reflect.String:
+			escaped, err := EscapeValue(key, resolveReset(value.String()))
+			if err != nil {
+				return nil, err
+			}
 			entries = append(entries, Entry{
 				Key:   key,
-				Value: value.String(),
+				Value: escaped,
 			})
 
 		case reflect.Bool:
@@ -63,20 +94,54 @@ func EncodeSystemdSection(v any) ([]Entry, error) {
 				Value: "yes",
 			})
 
+		case reflect.Pointer:
+			// Tri-state scalars: nil is unset (skipped above), anything
+			// else is written out, including false and zero.
+			scalar, err := formatScalar(key, value.Elem())
+			if err != nil {
+				return nil, fmt.Errorf("EncodeSystemdSection: field %s: %w", field.Name, err)
+			}
+			entries = append(entries, Entry{
+				Key:   key,
+				Value: scalar,
+			})
+
 		case reflect.Slice:
 			for j := 0; j < value.Len(); j++ {
+				escaped, err := EscapeValue(key, resolveReset(fmt.Sprint(value.Index(j).Interface())))
+				if err != nil {
+					return nil, err
+				}
 				entries = append(entries, Entry{
 					Key:   key,
-					Value: fmt.Sprint(value.Index(j).Interface()),
+					Value: escaped,
 				})
 			}
 
 		case reflect.Map:
-			iter := value.MapRange()
-			for iter.Next() {
+			// Map-typed directives (Environment=) are emitted one
+			// assignment per line, sorted by variable name.
+			keys := value.MapKeys()
+			sort.Slice(keys, func(a, b int) bool {
+				return keys[a].String() < keys[b].String()
+			})
+			for _, k := range keys {
+				if IsReset(value.MapIndex(k).String()) {
+					entries = append(entries, Entry{Key: key})
+					break
+				}
+			}
+			for _, k := range keys {
+				v := value.MapIndex(k).String()
+				if IsReset(v) {
+					continue
+				}
+				if strings.ContainsRune(v, resetMark) {
+					return nil, fmt.Errorf("%s= variable %s contains reset, which can only be used on its own", key, k.String())
+				}
 				entries = append(entries, Entry{
 					Key:   key,
-					Value: fmt.Sprintf("%v=%v", iter.Key(), iter.Value()),
+					Value: QuoteEnvAssignment(k.String(), v),
 				})
 			}
 
@@ -92,16 +157,74 @@ func EncodeSystemdSection(v any) ([]Entry, error) {
 	return entries, nil
 }
 
+// resolveReset maps the reset marker to the empty assignment value.
+func resolveReset(v string) string {
+	if v == resetValue {
+		return ""
+	}
+	return v
+}
+
+// formatScalar renders a tri-state scalar value of the directive key.
+func formatScalar(key string, v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return "yes", nil
+		}
+		return "no", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if strings.HasSuffix(key, "Sec") && v.Int() == TimespanInfinity {
+			return "infinity", nil
+		}
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.String:
+		return resolveReset(v.String()), nil
+	default:
+		return "", fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (su *SystemdUnit) ToString() string {
 	var b strings.Builder
 
-	for section, entries := range su.Sections {
-		writeSection(&b, section, entries)
+	for _, section := range su.SectionNames() {
+		writeSection(&b, section, su.Sections[section])
 	}
 
 	return strings.TrimSpace(b.String()) + "\n"
 }
 
+// SectionNames returns the unit's sections in canonical order: [Unit] first,
+// [Install] last and the type-specific sections sorted in between.
+func (su *SystemdUnit) SectionNames() []string {
+	names := make([]string, 0, len(su.Sections))
+	for _, name := range sortedKeys(su.Sections) {
+		if name != "Unit" && name != "Install" {
+			names = append(names, name)
+		}
+	}
+	if _, ok := su.Sections["Unit"]; ok {
+		names = append([]string{"Unit"}, names...)
+	}
+	if _, ok := su.Sections["Install"]; ok {
+		names = append(names, "Install")
+	}
+	return names
+}
+
 func writeSection(b *strings.Builder, section string, entries []Entry) {
 	b.WriteString("[")
 	b.WriteString(section)
@@ -0,0 +1,112 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func renderSource(t *testing.T, src string) map[string]string {
+	t.Helper()
+	config, diags := Decode("test.hcl", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	files, err := config.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string]string, len(files))
+	for _, f := range files {
+		out[f.Name] = f.Content
+	}
+	return out
+}
+
+func TestEncodeTriState(t *testing.T) {
+	got := renderSource(t, `
+service "unset" {
+  unit {}
+
+  service {
+    exec_start = ["/usr/bin/unset"]
+  }
+
+  install {}
+}
+
+service "zero" {
+  unit {}
+
+  service {
+    dynamic_user = false
+    exec_start   = ["/usr/bin/zero"]
+    restart_sec  = 0
+  }
+
+  install {}
+}
+
+service "set" {
+  unit {}
+
+  service {
+    dynamic_user      = true
+    exec_start        = ["/usr/bin/set"]
+    restart_sec       = 5
+    timeout_start_sec = infinity
+  }
+
+  install {}
+}
+`)
+	want := map[string]string{
+		// Unset scalars are left to systemd's defaults.
+		"unset.service": "[Service]\nExecStart=/usr/bin/unset\n",
+		// An explicit false or zero is written out.
+		"zero.service": "[Service]\nDynamicUser=no\nExecStart=/usr/bin/zero\nRestartSec=0\n",
+		"set.service":  "[Service]\nDynamicUser=yes\nExecStart=/usr/bin/set\nRestartSec=5\nTimeoutStartSec=infinity\n",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("rendered units (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncodeReset(t *testing.T) {
+	got := renderSource(t, `
+service "web" {
+  unit {
+    description = reset
+  }
+
+  service {
+    environment    = { reset = reset, PORT = "8080" }
+    exec_start     = [reset, "/usr/bin/web --fast"]
+    exec_start_pre = ["/usr/bin/check", "/usr/bin/migrate"]
+  }
+
+  install {
+    wanted_by = [reset]
+  }
+}
+`)
+	want := map[string]string{
+		"web.service": `[Unit]
+Description=
+
+[Service]
+Environment=
+Environment=PORT=8080
+ExecStart=
+ExecStart=/usr/bin/web --fast
+ExecStartPre=/usr/bin/check
+ExecStartPre=/usr/bin/migrate
+
+[Install]
+WantedBy=
+`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("rendered units (-want +got):\n%s", diff)
+	}
+}
@@ -47,3 +47,15 @@ func InstanceUnitName(templateName, instance string) string {
 	}
 	return templateName[:at+1] + instance + templateName[dot:]
 }
+
+// SplitInstanceName splits an instance unit filename into its template and
+// instance name; ok is false for other names.
+// "worker-queue@q1.service" → ("worker-queue@.service", "q1")
+func SplitInstanceName(name string) (template, instance string, ok bool) {
+	at := strings.Index(name, "@")
+	dot := strings.LastIndex(name, ".")
+	if at < 0 || dot <= at+1 {
+		return "", "", false
+	}
+	return name[:at+1] + name[dot:], name[at+1 : dot], true
+}
@@ -0,0 +1,224 @@
+package configs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// ParseUnitFile parses a unit file in the systemd INI dialect (see
+// systemd.syntax(7)) into a SystemdUnit.
+//
+// Comments ("#" or ";"), line continuations (trailing "\"), repeated keys and
+// empty assignments are preserved as entries; values are kept escaped, exactly
+// as written, and each entry carries the range of the line it came from.
+func ParseUnitFile(filename string, src []byte) (*SystemdUnit, hcl.Diagnostics) {
+	unit := &SystemdUnit{
+		Filename: filepath.Base(filename),
+		Sections: make(map[string][]Entry),
+	}
+	return unit, parseUnitInto(unit, filename, src)
+}
+
+// LoadUnitFile reads a unit file together with its drop-ins (see
+// DropInFiles), so their entries follow the ones of the main file within
+// each section.
+func LoadUnitFile(path string) (*SystemdUnit, hcl.Diagnostics) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read unit file",
+			Detail:   err.Error(),
+		}}
+	}
+
+	unit, diags := ParseUnitFile(path, src)
+
+	dropIns, err := DropInFiles(path)
+	if err != nil {
+		return unit, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read drop-in directory",
+			Detail:   err.Error(),
+		})
+	}
+	for _, dropIn := range dropIns {
+		src, err := os.ReadFile(dropIn)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read drop-in",
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		diags = append(diags, parseUnitInto(unit, dropIn, src)...)
+	}
+
+	return unit, diags
+}
+
+// DropInFiles returns the "*.conf" drop-ins of the unit file at path, in the
+// order systemd applies them. As systemd does, it looks in the directory of
+// the unit type ("service.d/"), of each dash prefix of the name
+// ("foo-.service.d/" for foo-bar.service), of the template
+// ("foo@.service.d/" for an instance) and of the unit itself, all next to
+// path. A file in a more specific directory replaces one of the same name in
+// a less specific one; the files are then sorted by name. Missing
+// directories are not an error.
+func DropInFiles(path string) ([]string, error) {
+	dir, name := filepath.Split(path)
+	byName := make(map[string]string)
+	for _, d := range dropInDirs(name) {
+		entries, err := os.ReadDir(filepath.Join(dir, d))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".conf") {
+				continue
+			}
+			byName[e.Name()] = filepath.Join(dir, d, e.Name())
+		}
+	}
+
+	files := make([]string, 0, len(byName))
+	for _, conf := range sortedKeys(byName) {
+		files = append(files, byName[conf])
+	}
+	return files, nil
+}
+
+// dropInDirs returns the drop-in directories of the unit name, from the
+// least to the most specific:
+//
+//	foo-bar@baz.service  →  service.d foo-.service.d foo-bar@.service.d foo-bar@baz.service.d
+func dropInDirs(name string) []string {
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return []string{name + ".d"}
+	}
+	stem, suffix := name[:dot], name[dot:]
+
+	dirs := []string{suffix[1:] + ".d"}
+	prefix := stem
+	if at := strings.Index(stem, "@"); at >= 0 {
+		prefix = stem[:at]
+	}
+	for i := 0; i < len(prefix); i++ {
+		if prefix[i] == '-' && i > 0 {
+			dirs = append(dirs, prefix[:i+1]+suffix+".d")
+		}
+	}
+	if tmpl, _, ok := SplitInstanceName(name); ok {
+		dirs = append(dirs, tmpl+".d")
+	}
+	return append(dirs, name+".d")
+}
+
+func parseUnitInto(unit *SystemdUnit, filename string, src []byte) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	src = bytes.TrimPrefix(src, []byte("\xef\xbb\xbf"))
+	lines := strings.Split(string(src), "\n")
+
+	section := ""
+	offset := 0
+
+	for i := 0; i < len(lines); i++ {
+		start := hcl.Pos{Line: i + 1, Column: 1, Byte: offset}
+		line := lines[i]
+		offset += len(line) + 1
+
+		// Join continuation lines. systemd replaces the trailing backslash
+		// with a space and skips comment lines inside a continuation.
+		for !isCommentLine(line) && endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			next := lines[i]
+			offset += len(next) + 1
+			if isCommentLine(next) {
+				continue
+			}
+			line = strings.TrimRight(line, " \t\r")
+			line = line[:len(line)-1] + " " + next
+		}
+
+		rng := hcl.Range{
+			Filename: filename,
+			Start:    start,
+			End:      hcl.Pos{Line: i + 1, Column: len(lines[i]) + 1, Byte: offset - 1},
+		}
+
+		text := strings.TrimSpace(line)
+		switch {
+		case text == "" || isCommentLine(text):
+			continue
+
+		case strings.HasPrefix(text, "["):
+			if !strings.HasSuffix(text, "]") || len(text) < 3 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid section header",
+					Detail:   fmt.Sprintf("%q is not a valid section header.", text),
+					Subject:  rng.Ptr(),
+				})
+				continue
+			}
+			section = text[1 : len(text)-1]
+			if _, ok := unit.Sections[section]; !ok {
+				unit.Sections[section] = nil
+			}
+
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing '='",
+					Detail:   fmt.Sprintf("Line %q is neither a section header nor an assignment.", text),
+					Subject:  rng.Ptr(),
+				})
+				continue
+			}
+			if section == "" {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Assignment outside of section",
+					Detail:   fmt.Sprintf("%s= appears before the first section header.", strings.TrimSpace(key)),
+					Subject:  rng.Ptr(),
+				})
+				continue
+			}
+			unit.Sections[section] = append(unit.Sections[section], Entry{
+				Key:   strings.TrimSpace(key),
+				Value: strings.TrimSpace(value),
+				Range: rng,
+			})
+		}
+	}
+
+	return diags
+}
+
+func isCommentLine(line string) bool {
+	line = strings.TrimLeft(line, " \t")
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";")
+}
+
+// endsWithContinuation reports whether line ends with an unescaped backslash.
+func endsWithContinuation(line string) bool {
+	line = strings.TrimRight(line, " \t\r")
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
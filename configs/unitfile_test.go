@@ -0,0 +1,252 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestParseUnitFile(t *testing.T) {
+	src := `# leading comment
+[Unit]
+Description=Example %% service
+After=network.target \
+  ; comment inside a continuation
+  db.service
+After=
+
+[Service]
+ExecStart=/usr/bin/example --name %i
+Environment="GREETING=hello world" PORT=8080
+PrivateTmp=no
+RestartSec=1min 30s
+X-Custom=ignored
+`
+	unit, diags := ParseUnitFile("example@.service", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	after := unit.Sections["Unit"][1]
+	if after.Value != "network.target    db.service" || after.Range.Start.Line != 4 {
+		t.Errorf("continuation: got %q at line %d", after.Value, after.Range.Start.Line)
+	}
+
+	svc, err := NewUnitCodec[Service]().Decode(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if svc.Name != "example" || !svc.Template {
+		t.Errorf("name/template: got %q/%v", svc.Name, svc.Template)
+	}
+	if svc.Unit.Description != "Example % service" {
+		t.Errorf("description: got %q", svc.Unit.Description)
+	}
+	if svc.Unit.After != nil {
+		t.Errorf("empty assignment should reset After=, got %q", svc.Unit.After)
+	}
+	if got := svc.Service.Environment["GREETING"]; got != "hello world" {
+		t.Errorf("environment: got %q", got)
+	}
+	if svc.Service.PrivateTmp != "no" {
+		t.Errorf("private tmp: got %q", svc.Service.PrivateTmp)
+	}
+	if svc.Service.RestartSec == nil || *svc.Service.RestartSec != 90 {
+		t.Errorf("restart sec: got %v", svc.Service.RestartSec)
+	}
+
+	encoded, err := svc.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[Unit]
+Description=Example %% service
+
+[Service]
+Environment="GREETING=hello world"
+Environment=PORT=8080
+ExecStart=/usr/bin/example --name %i
+PrivateTmp=no
+RestartSec=90
+`
+	if encoded != want {
+		t.Errorf("re-encoded:\n%s\nwant:\n%s", encoded, want)
+	}
+}
+
+func TestDecodeReportsUnknownDirectives(t *testing.T) {
+	src := "[Unit]\nDescription=x\n\n[Service]\nExecStart=/bin/true\nFrobnicate=yes\n"
+	unit, diags := ParseUnitFile("x.service", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	var svc Service
+	diags = DecodeUnit(unit, &svc)
+	if len(diags) != 1 || diags[0].Subject == nil || diags[0].Subject.Start.Line != 6 {
+		t.Fatalf("expected one diagnostic on line 6, got %v", diags)
+	}
+}
+
+func TestLoadUnitFileDropIns(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db.service")
+	writeFile(t, path, "[Service]\nExecStart=/usr/bin/db\nEnvironment=A=1\n")
+	writeFile(t, path+".d/20-b.conf", "[Service]\nEnvironment=\nEnvironment=B=2\n")
+	writeFile(t, path+".d/10-a.conf", "[Service]\nExecStart=\nExecStart=/usr/bin/db --fast\n")
+
+	unit, diags := LoadUnitFile(path)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	svc, err := NewUnitCodec[Service]().Decode(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(svc.Service.ExecStart) != 1 || svc.Service.ExecStart[0] != "/usr/bin/db --fast" {
+		t.Errorf("exec start: got %q", svc.Service.ExecStart)
+	}
+	if len(svc.Service.Environment) != 1 || svc.Service.Environment["B"] != "2" {
+		t.Errorf("environment: got %v", svc.Service.Environment)
+	}
+}
+
+func TestDropInDirs(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "web-api@eu.service")
+	writeFile(t, path, "[Service]\nExecStart=/usr/bin/api %i\n")
+	writeFile(t, filepath.Join(dir, "service.d/10-all.conf"), "[Service]\nNice=5\n")
+	writeFile(t, filepath.Join(dir, "service.d/30-limits.conf"), "[Service]\nLimitNOFILE=1024\n")
+	writeFile(t, filepath.Join(dir, "web-.service.d/20-web.conf"), "[Service]\nUser=web\n")
+	writeFile(t, filepath.Join(dir, "web-api@.service.d/30-limits.conf"), "[Service]\nLimitNOFILE=65536\n")
+	writeFile(t, filepath.Join(dir, "web-api@eu.service.d/40-eu.conf"), "[Service]\nEnvironment=REGION=eu\n")
+
+	files, err := DropInFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range files {
+		rel, _ := filepath.Rel(dir, f)
+		got = append(got, rel)
+	}
+	want := []string{
+		"service.d/10-all.conf",
+		"web-.service.d/20-web.conf",
+		// The template drop-in replaces the one of the same name for all
+		// services.
+		"web-api@.service.d/30-limits.conf",
+		"web-api@eu.service.d/40-eu.conf",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("drop-ins (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeRoundTrips(t *testing.T) {
+	tests := []struct {
+		name, filename, src string
+	}{
+		{
+			"repeated command lines",
+			"db.service",
+			"[Service]\nExecStartPre=/bin/one\nExecStartPre=/bin/two\nExecStart=/usr/bin/db\n",
+		},
+		{
+			"infinite time span",
+			"db.service",
+			"[Service]\nExecStart=/usr/bin/db\nTimeoutStartSec=infinity\n",
+		},
+		{
+			"template",
+			"worker@.service",
+			"[Service]\nExecStart=/usr/bin/worker %i\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, diags := ParseUnitFile(tt.filename, []byte(tt.src))
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			codec := NewUnitCodec[Service]()
+			svc, err := codec.Decode(unit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := codec.Encode(svc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.Filename != tt.filename {
+				t.Errorf("filename: got %q, want %q", out.Filename, tt.filename)
+			}
+			normalized, diags := NormalizeUnit(unit)
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			if got := normalized.ToString(); got != out.ToString() {
+				t.Errorf("normalized:\n%s\nencoded:\n%s", got, out.ToString())
+			}
+			// Entries are sorted by directive name when encoded.
+			want := strings.Split(strings.TrimSpace(tt.src), "\n")
+			sort.Strings(want[1:])
+			if got := strings.TrimSpace(out.ToString()); got != strings.Join(want, "\n") {
+				t.Errorf("re-encoded:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+			}
+		})
+	}
+}
+
+func TestDecodeRepeatedScalarsKeepsLast(t *testing.T) {
+	src := "[Unit]\nDescription=one\nDescription=two\n\n[Service]\nExecStart=/bin/true\n"
+	unit, diags := ParseUnitFile("x.service", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	var svc Service
+	diags = DecodeUnit(unit, &svc)
+	if len(diags) != 1 || diags[0].Severity != hcl.DiagWarning || diags[0].Summary != "Duplicate directive" || diags[0].Subject.Start.Line != 3 {
+		t.Fatalf("expected a duplicate directive warning on line 3, got %v", diags)
+	}
+	if svc.Unit.Description != "two" {
+		t.Errorf("Description = %q, want the last value", svc.Unit.Description)
+	}
+}
+
+func TestRenderedExampleRoundTrips(t *testing.T) {
+	for _, f := range renderExample(t) {
+		unit, diags := ParseUnitFile(f.Name, []byte(f.Content))
+		if diags.HasErrors() {
+			t.Fatalf("%s: %s", f.Name, diags.Error())
+		}
+		svc, err := NewUnitCodec[Service]().Decode(unit)
+		if err != nil {
+			t.Fatalf("%s: %s", f.Name, err)
+		}
+		got, err := svc.Encode()
+		if err != nil {
+			t.Fatalf("%s: %s", f.Name, err)
+		}
+		if got != f.Content {
+			t.Errorf("%s does not round-trip\n--- want\n%s\n--- got\n%s", f.Name, f.Content, got)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,246 @@
+// Package deb writes Debian binary packages, as described in deb(5), in
+// pure Go: an ar archive of the control and data tarballs, without dpkg.
+//
+// The archives are reproducible: entries are sorted, owned by root and
+// stamped with the time of the package, and compression records neither
+// names nor times.
+package deb
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Control holds the fields of the control file of a package.
+type Control struct {
+	Package      string
+	Version      string
+	Architecture string
+	Maintainer   string
+	Depends      []string
+	Section      string
+	Priority     string
+	Description  string
+}
+
+// File is a file the package installs, at Path relative to the root.
+type File struct {
+	Path    string
+	Content []byte
+	Mode    int64
+}
+
+// Package is a binary package.
+type Package struct {
+	Control Control
+	Files   []File
+
+	// Scripts are the maintainer scripts, by name: preinst, postinst,
+	// prerm and postrm.
+	Scripts map[string]string
+
+	// Time stamps every entry of the archives, such as the time of
+	// SOURCE_DATE_EPOCH.
+	Time time.Time
+}
+
+// Filename returns the conventional file name of p:
+// name_version_architecture.deb, without the epoch of the version.
+func (p *Package) Filename() string {
+	version := p.Control.Version
+	if _, rest, ok := strings.Cut(version, ":"); ok {
+		version = rest
+	}
+	return fmt.Sprintf("%s_%s_%s.deb", p.Control.Package, version, p.Control.Architecture)
+}
+
+// Write writes the .deb archive of p to w.
+func (p *Package) Write(w io.Writer) error {
+	data, size, err := p.dataTar()
+	if err != nil {
+		return err
+	}
+	control, err := p.controlTar(size)
+	if err != nil {
+		return err
+	}
+
+	ar := &arWriter{w: w, time: p.Time}
+	if err := ar.header(); err != nil {
+		return err
+	}
+	if err := ar.file("debian-binary", []byte("2.0\n")); err != nil {
+		return err
+	}
+	if err := ar.file("control.tar.gz", control); err != nil {
+		return err
+	}
+	return ar.file("data.tar.gz", data)
+}
+
+// dataTar returns the data tarball and the installed size in KiB.
+func (p *Package) dataTar() ([]byte, int64, error) {
+	files := append([]File(nil), p.Files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	t := newTarball(p.Time)
+	var size int64
+	dirs := map[string]bool{".": true}
+	t.dir(".")
+	for _, f := range files {
+		name := path.Clean(strings.TrimPrefix(f.Path, "/"))
+		var parents []string
+		for dir := path.Dir(name); !dirs[dir]; dir = path.Dir(dir) {
+			dirs[dir] = true
+			parents = append(parents, dir)
+		}
+		for i := len(parents) - 1; i >= 0; i-- {
+			t.dir(parents[i])
+		}
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0o644
+		}
+		t.file(name, f.Content, mode)
+		size += (int64(len(f.Content)) + 1023) / 1024
+	}
+	data, err := t.close()
+	return data, size, err
+}
+
+func (p *Package) controlTar(installedSize int64) ([]byte, error) {
+	c := p.Control
+	var b strings.Builder
+	fmt.Fprintf(&b, "Package: %s\n", c.Package)
+	fmt.Fprintf(&b, "Version: %s\n", c.Version)
+	fmt.Fprintf(&b, "Architecture: %s\n", c.Architecture)
+	fmt.Fprintf(&b, "Maintainer: %s\n", c.Maintainer)
+	fmt.Fprintf(&b, "Installed-Size: %d\n", installedSize)
+	if len(c.Depends) > 0 {
+		fmt.Fprintf(&b, "Depends: %s\n", strings.Join(c.Depends, ", "))
+	}
+	if c.Section != "" {
+		fmt.Fprintf(&b, "Section: %s\n", c.Section)
+	}
+	if c.Priority != "" {
+		fmt.Fprintf(&b, "Priority: %s\n", c.Priority)
+	}
+	fmt.Fprintf(&b, "Description: %s\n", description(c.Description))
+
+	files := append([]File(nil), p.Files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	var sums strings.Builder
+	for _, f := range files {
+		sum := md5.Sum(f.Content)
+		fmt.Fprintf(&sums, "%s  %s\n", hex.EncodeToString(sum[:]), strings.TrimPrefix(path.Clean("/"+f.Path), "/"))
+	}
+
+	t := newTarball(p.Time)
+	t.dir(".")
+	t.file("control", []byte(b.String()), 0o644)
+	t.file("md5sums", []byte(sums.String()), 0o644)
+	for _, name := range []string{"preinst", "postinst", "prerm", "postrm"} {
+		if script, ok := p.Scripts[name]; ok {
+			t.file(name, []byte(script), 0o755)
+		}
+	}
+	return t.close()
+}
+
+// description formats a description for the control file: the synopsis on
+// the first line, the extended description indented, with "." for empty
+// lines.
+func description(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" {
+			lines[i] = "."
+		}
+		lines[i] = " " + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tarball writes a gzipped tarball of root-owned entries with one time.
+type tarball struct {
+	buf  bytes.Buffer
+	gz   *gzip.Writer
+	tw   *tar.Writer
+	time time.Time
+	err  error
+}
+
+func newTarball(t time.Time) *tarball {
+	tb := &tarball{time: t}
+	tb.gz, _ = gzip.NewWriterLevel(&tb.buf, gzip.BestCompression)
+	tb.tw = tar.NewWriter(tb.gz)
+	return tb
+}
+
+func (t *tarball) dir(name string) {
+	t.write(&tar.Header{Typeflag: tar.TypeDir, Name: "./" + strings.TrimPrefix(name+"/", "./"), Mode: 0o755}, nil)
+}
+
+func (t *tarball) file(name string, content []byte, mode int64) {
+	t.write(&tar.Header{Typeflag: tar.TypeReg, Name: "./" + name, Mode: mode, Size: int64(len(content))}, content)
+}
+
+func (t *tarball) write(hdr *tar.Header, content []byte) {
+	if t.err != nil {
+		return
+	}
+	hdr.ModTime = t.time
+	hdr.Uname, hdr.Gname = "root", "root"
+	hdr.Format = tar.FormatGNU
+	if t.err = t.tw.WriteHeader(hdr); t.err == nil {
+		_, t.err = t.tw.Write(content)
+	}
+}
+
+func (t *tarball) close() ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if err := t.tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := t.gz.Close(); err != nil {
+		return nil, err
+	}
+	return t.buf.Bytes(), nil
+}
+
+// arWriter writes the common ar format dpkg reads.
+type arWriter struct {
+	w    io.Writer
+	time time.Time
+}
+
+func (a *arWriter) header() error {
+	_, err := io.WriteString(a.w, "!<arch>\n")
+	return err
+}
+
+func (a *arWriter) file(name string, content []byte) error {
+	hdr := fmt.Sprintf("%-16s%-12d%-6d%-6d%-8o%-10d`\n", name, a.time.Unix(), 0, 0, 0o100644, len(content))
+	if _, err := io.WriteString(a.w, hdr); err != nil {
+		return err
+	}
+	if _, err := a.w.Write(content); err != nil {
+		return err
+	}
+	if len(content)%2 == 1 {
+		_, err := io.WriteString(a.w, "\n")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package deb
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// readAr returns the members of the ar archive data, in order.
+func readAr(t *testing.T, data []byte) (names []string, members map[string][]byte) {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("!<arch>\n")) {
+		t.Fatalf("not an ar archive: %q", data[:8])
+	}
+	members = make(map[string][]byte)
+	data = data[8:]
+	for len(data) > 0 {
+		hdr := data[:60]
+		name := strings.TrimSpace(string(hdr[:16]))
+		size, err := strconv.Atoi(strings.TrimSpace(string(hdr[48:58])))
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+		members[name] = data[60 : 60+size]
+		data = data[60+size+size%2:]
+	}
+	return names, members
+}
+
+// readTar returns the headers and contents of the gzipped tarball data.
+func readTar(t *testing.T, data []byte) ([]*tar.Header, map[string]string) {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var headers []*tar.Header
+	contents := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(tr)
+		headers = append(headers, hdr)
+		contents[hdr.Name] = string(b)
+	}
+	return headers, contents
+}
+
+func TestWrite(t *testing.T) {
+	pkg := func() *Package {
+		return &Package{
+			Control: Control{
+				Package:      "web-units",
+				Version:      "1:1.2-3",
+				Architecture: "all",
+				Maintainer:   "Ops <ops@example.com>",
+				Depends:      []string{"nginx", "curl (>= 7)"},
+				Description:  "Web units\nServices of the web hosts.\n\nBuilt by unitd.",
+			},
+			Files: []File{
+				{Path: "/usr/lib/systemd/system/web.service", Content: []byte("[Service]\nExecStart=/usr/bin/web\n")},
+				{Path: "/usr/lib/systemd/system/db.service", Content: []byte("[Service]\nExecStart=/usr/bin/db\n")},
+			},
+			Scripts: Scripts(Units{Enable: []string{"web.service"}}),
+			Time:    time.Unix(1700000000, 0),
+		}
+	}
+
+	var a, b bytes.Buffer
+	if err := pkg().Write(&a); err != nil {
+		t.Fatal(err)
+	}
+	if err := pkg().Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Error("two builds of the same package differ")
+	}
+	if got := pkg().Filename(); got != "web-units_1.2-3_all.deb" {
+		t.Errorf("Filename() = %q", got)
+	}
+
+	names, members := readAr(t, a.Bytes())
+	if diff := cmp.Diff([]string{"debian-binary", "control.tar.gz", "data.tar.gz"}, names); diff != "" {
+		t.Errorf("ar members (-want +got):\n%s", diff)
+	}
+	if got := string(members["debian-binary"]); got != "2.0\n" {
+		t.Errorf("debian-binary = %q", got)
+	}
+
+	headers, data := readTar(t, members["data.tar.gz"])
+	var entries []string
+	for _, h := range headers {
+		if h.Uid != 0 || h.Gid != 0 || h.Uname != "root" || !h.ModTime.Equal(time.Unix(1700000000, 0)) {
+			t.Errorf("%s: owner %d:%d %s, time %s", h.Name, h.Uid, h.Gid, h.Uname, h.ModTime)
+		}
+		entries = append(entries, h.Name+" "+strconv.FormatInt(h.Mode, 8))
+	}
+	want := []string{
+		"./ 755",
+		"./usr/ 755",
+		"./usr/lib/ 755",
+		"./usr/lib/systemd/ 755",
+		"./usr/lib/systemd/system/ 755",
+		"./usr/lib/systemd/system/db.service 644",
+		"./usr/lib/systemd/system/web.service 644",
+	}
+	if diff := cmp.Diff(want, entries); diff != "" {
+		t.Errorf("data entries (-want +got):\n%s", diff)
+	}
+	if got := data["./usr/lib/systemd/system/web.service"]; got != "[Service]\nExecStart=/usr/bin/web\n" {
+		t.Errorf("web.service = %q", got)
+	}
+
+	headers, control := readTar(t, members["control.tar.gz"])
+	entries = nil
+	for _, h := range headers {
+		entries = append(entries, h.Name+" "+strconv.FormatInt(h.Mode, 8))
+	}
+	want = []string{"./ 755", "./control 644", "./md5sums 644", "./postinst 755", "./prerm 755", "./postrm 755"}
+	if diff := cmp.Diff(want, entries); diff != "" {
+		t.Errorf("control entries (-want +got):\n%s", diff)
+	}
+	wantControl := `Package: web-units
+Version: 1:1.2-3
+Architecture: all
+Maintainer: Ops <ops@example.com>
+Installed-Size: 2
+Depends: nginx, curl (>= 7)
+Description: Web units
+ Services of the web hosts.
+ .
+ Built by unitd.
+`
+	if diff := cmp.Diff(wantControl, control["./control"]); diff != "" {
+		t.Errorf("control (-want +got):\n%s", diff)
+	}
+	wantSums := "6d85aaee7c6ab389b5d8449a587c577f  usr/lib/systemd/system/db.service\n"
+	if got := strings.SplitAfter(control["./md5sums"], "\n")[0]; got != wantSums {
+		t.Errorf("md5sums line = %q, want %q", got, wantSums)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	scripts := Scripts(Units{
+		Enable:  []string{"web.service", "getty@tty1.service"},
+		Disable: []string{"db.service"},
+		Restart: []string{"web.service"},
+		Reload:  []string{"getty@tty1.service"},
+	})
+	want := map[string]string{
+		"postinst": `#!/bin/sh
+# Generated by unitd.
+set -e
+
+if [ "$1" = configure ]; then
+	systemctl --no-reload enable 'web.service' 'getty@tty1.service'
+	systemctl --no-reload disable 'db.service'
+	if [ -d /run/systemd/system ]; then
+		systemctl daemon-reload
+		systemctl try-restart 'web.service'
+		systemctl try-reload-or-restart 'getty@tty1.service'
+		systemctl start 'web.service' 'getty@tty1.service'
+	fi
+fi
+`,
+		"prerm": `#!/bin/sh
+# Generated by unitd.
+set -e
+
+if [ "$1" = remove ]; then
+	if [ -d /run/systemd/system ]; then
+		systemctl stop 'web.service' 'getty@tty1.service' || true
+	fi
+	systemctl --no-reload disable 'web.service' 'getty@tty1.service'
+fi
+`,
+		"postrm": `#!/bin/sh
+# Generated by unitd.
+set -e
+
+if [ -d /run/systemd/system ]; then
+	systemctl daemon-reload
+fi
+`,
+	}
+	if diff := cmp.Diff(want, scripts); diff != "" {
+		t.Errorf("scripts (-want +got):\n%s", diff)
+	}
+}
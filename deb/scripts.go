@@ -0,0 +1,78 @@
+package deb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Units says what the maintainer scripts of a package do with the systemd
+// units it installs.
+type Units struct {
+	Enable  []string // enabled on install and started, disabled on removal
+	Disable []string // disabled on install
+	Restart []string // restarted on upgrade, when running
+	Reload  []string // reloaded on upgrade, or restarted, when running
+}
+
+// Scripts returns the maintainer scripts handling u: postinst enables the
+// units and, when systemd runs, reloads it, restarts or reloads the running
+// units and starts the enabled ones; prerm stops and disables the enabled
+// units on removal, when there are any; postrm reloads systemd once they
+// are gone. Enabling does not need systemd to run, so installing into an
+// image works. The units must not be templates: systemctl cannot start
+// them.
+func Scripts(u Units) map[string]string {
+	var post strings.Builder
+	post.WriteString(scriptHeader)
+	post.WriteString("if [ \"$1\" = configure ]; then\n")
+	systemctl(&post, "\t", "--no-reload enable", u.Enable)
+	systemctl(&post, "\t", "--no-reload disable", u.Disable)
+	post.WriteString("\tif [ -d /run/systemd/system ]; then\n")
+	post.WriteString("\t\tsystemctl daemon-reload\n")
+	systemctl(&post, "\t\t", "try-restart", u.Restart)
+	systemctl(&post, "\t\t", "try-reload-or-restart", u.Reload)
+	systemctl(&post, "\t\t", "start", u.Enable)
+	post.WriteString("\tfi\nfi\n")
+
+	var prerm strings.Builder
+	if len(u.Enable) > 0 {
+		prerm.WriteString(scriptHeader)
+		prerm.WriteString("if [ \"$1\" = remove ]; then\n")
+		prerm.WriteString("\tif [ -d /run/systemd/system ]; then\n")
+		fmt.Fprintf(&prerm, "\t\tsystemctl stop %s || true\n", quoteAll(u.Enable))
+		prerm.WriteString("\tfi\n")
+		systemctl(&prerm, "\t", "--no-reload disable", u.Enable)
+		prerm.WriteString("fi\n")
+	}
+
+	var postrm strings.Builder
+	postrm.WriteString(scriptHeader)
+	postrm.WriteString("if [ -d /run/systemd/system ]; then\n")
+	postrm.WriteString("\tsystemctl daemon-reload\n")
+	postrm.WriteString("fi\n")
+
+	scripts := map[string]string{
+		"postinst": post.String(),
+		"postrm":   postrm.String(),
+	}
+	if prerm.Len() > 0 {
+		scripts["prerm"] = prerm.String()
+	}
+	return scripts
+}
+
+const scriptHeader = "#!/bin/sh\n# Generated by unitd.\nset -e\n\n"
+
+func systemctl(b *strings.Builder, indent, verb string, units []string) {
+	if len(units) > 0 {
+		fmt.Fprintf(b, "%ssystemctl %s %s\n", indent, verb, quoteAll(units))
+	}
+}
+
+func quoteAll(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
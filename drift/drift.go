@@ -0,0 +1,222 @@
+// Package drift finds the differences between the unit directory of a host
+// and the tree unitd compiles for it.
+package drift
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/install"
+)
+
+// Kind is the kind of a difference.
+type Kind string
+
+const (
+	// Modified is a unit file or link whose content differs from the
+	// compiled one.
+	Modified Kind = "modified"
+	// Missing is a compiled unit file or enablement link absent from the
+	// host.
+	Missing Kind = "missing"
+	// DropIn is a drop-in of a compiled unit that unitd did not write.
+	DropIn Kind = "drop-in"
+	// Enabled is a link enabling a compiled unit that unitd did not write.
+	Enabled Kind = "enabled"
+)
+
+// Finding is one difference, for the file Name of the unit directory.
+type Finding struct {
+	Kind   Kind
+	Name   string
+	Detail string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Name, f.Detail)
+}
+
+// Detect compares the unit directory live with tree, the files compiled for
+// it. Unit files are compared after normalization, so that only changes
+// systemd would see are reported. Drop-ins and enablement links are only
+// checked for the units of tree: other units belong to the host.
+func Detect(tree []configs.File, live fs.FS) ([]Finding, error) {
+	var findings []Finding
+	expected := make(map[string]configs.File, len(tree))
+	units := make(map[string]bool)
+	for _, f := range tree {
+		expected[f.Name] = f
+		if f.Link == "" && !strings.Contains(f.Name, "/") {
+			units[f.Name] = true
+		}
+	}
+
+	for _, f := range tree {
+		fi, err := fs.Lstat(live, f.Name)
+		if errors.Is(err, fs.ErrNotExist) {
+			what := "unit file"
+			if f.Link != "" {
+				what = "link"
+			}
+			findings = append(findings, Finding{Kind: Missing, Name: f.Name, Detail: what + " missing"})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		isLink := fi.Mode()&fs.ModeSymlink != 0
+		switch {
+		case f.Link != "" && !isLink:
+			findings = append(findings, Finding{Kind: Modified, Name: f.Name, Detail: "not a link, want a link to " + f.Link})
+		case f.Link != "":
+			target, err := fs.ReadLink(live, f.Name)
+			if err != nil {
+				return nil, err
+			}
+			if target != f.Link {
+				findings = append(findings, Finding{Kind: Modified, Name: f.Name, Detail: fmt.Sprintf("links to %s, want %s", target, f.Link)})
+			}
+		case isLink:
+			target, err := fs.ReadLink(live, f.Name)
+			if err != nil {
+				return nil, err
+			}
+			detail := "replaced by a link to " + target
+			if target == "/dev/null" {
+				detail = "masked"
+			}
+			findings = append(findings, Finding{Kind: Modified, Name: f.Name, Detail: detail})
+		default:
+			data, err := fs.ReadFile(live, f.Name)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range compareUnits(f.Name, f.Content, string(data)) {
+				findings = append(findings, Finding{Kind: Modified, Name: f.Name, Detail: d})
+			}
+		}
+	}
+
+	entries, err := fs.ReadDir(live, ".")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		dir := e.Name()
+		if !e.IsDir() {
+			continue
+		}
+		var kind Kind
+		var detail string
+		switch {
+		case strings.HasSuffix(dir, ".d") && managed(units, strings.TrimSuffix(dir, ".d")):
+			kind, detail = DropIn, "drop-in not written by unitd"
+		case install.IsLinkDir(dir):
+			kind, detail = Enabled, "enabled outside of unitd"
+		default:
+			continue
+		}
+		files, err := fs.ReadDir(live, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			name := path.Join(dir, f.Name())
+			if _, ok := expected[name]; ok {
+				continue
+			}
+			if kind == DropIn && !strings.HasSuffix(name, ".conf") {
+				continue
+			}
+			if kind == Enabled && !managed(units, f.Name()) {
+				continue
+			}
+			findings = append(findings, Finding{Kind: kind, Name: name, Detail: detail})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Name < findings[j].Name })
+	return findings, nil
+}
+
+// managed reports whether the unit name is one of units or an instance of
+// one of them.
+func managed(units map[string]bool, name string) bool {
+	if units[name] {
+		return true
+	}
+	tmpl, _, ok := configs.SplitInstanceName(name)
+	return ok && units[tmpl]
+}
+
+// compareUnits describes how the unit file live differs from want, both
+// named name, after normalization.
+func compareUnits(name, want, live string) []string {
+	wantUnit, err := normalize(name, want)
+	if err != nil {
+		return []string{"compiled unit does not parse: " + err.Error()}
+	}
+	liveUnit, err := normalize(name, live)
+	if err != nil {
+		return []string{"does not parse: " + err.Error()}
+	}
+
+	var out []string
+	for _, section := range unionKeys(wantUnit, liveUnit) {
+		w, l := wantUnit[section], liveUnit[section]
+		for _, key := range unionKeys(w, l) {
+			wv, lv := w[key], l[key]
+			switch {
+			case len(lv) == 0:
+				out = append(out, fmt.Sprintf("[%s] %s= removed, want %s", section, key, strings.Join(wv, " ")))
+			case len(wv) == 0:
+				out = append(out, fmt.Sprintf("[%s] %s=%s added", section, key, strings.Join(lv, " ")))
+			case strings.Join(wv, "\n") != strings.Join(lv, "\n"):
+				out = append(out, fmt.Sprintf("[%s] %s=%s, want %s", section, key, strings.Join(lv, " "), strings.Join(wv, " ")))
+			}
+		}
+	}
+	return out
+}
+
+// normalize returns the values of each key of each section of the unit
+// file content.
+func normalize(name, content string) (map[string]map[string][]string, error) {
+	su, diags := configs.ParseUnitFile(name, []byte(content))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	su, diags = configs.NormalizeUnit(su)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	out := make(map[string]map[string][]string, len(su.Sections))
+	for section, entries := range su.Sections {
+		keys := make(map[string][]string)
+		for _, e := range entries {
+			keys[e.Key] = append(keys[e.Key], e.Value)
+		}
+		out[section] = keys
+	}
+	return out, nil
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func link(target string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(target), Mode: fs.ModeSymlink | 0o777}
+}
+
+func TestDetect(t *testing.T) {
+	tree := []configs.File{
+		{Name: "web.service", Content: "[Unit]\nDescription=Web\nAfter=network.target db.service\n\n[Service]\nExecStart=/usr/bin/web\n"},
+		{Name: "db.service", Content: "[Service]\nExecStart=/usr/bin/db\n"},
+		{Name: "cache.service", Content: "[Service]\nExecStart=/usr/bin/cache\n"},
+		{Name: "gone.service", Content: "[Service]\nExecStart=/usr/bin/gone\n"},
+		{Name: "worker@.service", Content: "[Service]\nExecStart=/usr/bin/worker %i\n"},
+		{Name: "multi-user.target.wants/web.service", Link: "/etc/systemd/system/web.service"},
+		{Name: "multi-user.target.wants/db.service", Link: "/etc/systemd/system/db.service"},
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		live := fstest.MapFS{
+			// Same directives, written differently.
+			"web.service":                         {Data: []byte("# edited layout\n[Service]\nExecStart=/usr/bin/web\n[Unit]\nAfter=network.target\nAfter=db.service\nDescription=Web\n")},
+			"db.service":                          {Data: []byte("[Service]\nExecStart=/usr/bin/db\n")},
+			"cache.service":                       {Data: []byte("[Service]\nExecStart=/usr/bin/cache\n")},
+			"gone.service":                        {Data: []byte("[Service]\nExecStart=/usr/bin/gone\n")},
+			"worker@.service":                     {Data: []byte("[Service]\nExecStart=/usr/bin/worker %i\n")},
+			"multi-user.target.wants/web.service": link("/etc/systemd/system/web.service"),
+			"multi-user.target.wants/db.service":  link("/etc/systemd/system/db.service"),
+			// Units the configuration does not define are left alone.
+			"multi-user.target.wants/sshd.service": link("/usr/lib/systemd/system/sshd.service"),
+			"sshd.service.d/override.conf":         {Data: []byte("[Service]\nNice=5\n")},
+		}
+		findings, err := Detect(tree, live)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(findings) != 0 {
+			t.Errorf("findings: %v", findings)
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		live := fstest.MapFS{
+			"web.service":                              {Data: []byte("[Unit]\nDescription=Web\nAfter=network.target\n\n[Service]\nExecStart=/usr/bin/web --debug\nUser=root\n")},
+			"db.service":                               {Data: []byte("[Service]\nExecStart=/usr/bin/db\n")},
+			"cache.service":                            link("/dev/null"),
+			"worker@.service":                          {Data: []byte("[Service]\nExecStart=/usr/bin/worker %i\n")},
+			"multi-user.target.wants/web.service":      link("/etc/systemd/system/web.service"),
+			"multi-user.target.wants/cache.service":    link("/etc/systemd/system/cache.service"),
+			"multi-user.target.wants/worker@a.service": link("/etc/systemd/system/worker@.service"),
+			"db.service.d/override.conf":               {Data: []byte("[Service]\nNice=5\n")},
+		}
+		findings, err := Detect(tree, live)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Finding{
+			{Kind: Modified, Name: "cache.service", Detail: "masked"},
+			{Kind: DropIn, Name: "db.service.d/override.conf", Detail: "drop-in not written by unitd"},
+			{Kind: Missing, Name: "gone.service", Detail: "unit file missing"},
+			{Kind: Enabled, Name: "multi-user.target.wants/cache.service", Detail: "enabled outside of unitd"},
+			{Kind: Missing, Name: "multi-user.target.wants/db.service", Detail: "link missing"},
+			{Kind: Enabled, Name: "multi-user.target.wants/worker@a.service", Detail: "enabled outside of unitd"},
+			{Kind: Modified, Name: "web.service", Detail: "[Service] ExecStart=/usr/bin/web --debug, want /usr/bin/web"},
+			{Kind: Modified, Name: "web.service", Detail: "[Service] User=root added"},
+			{Kind: Modified, Name: "web.service", Detail: "[Unit] After=network.target, want network.target db.service"},
+		}
+		if diff := cmp.Diff(want, findings); diff != "" {
+			t.Errorf("findings (-want +got):\n%s", diff)
+		}
+	})
+}
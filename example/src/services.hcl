@@ -1,13 +1,12 @@
 service "nginx" {
   unit {
+    after       = [builtin.target.network, service.db, instance.queue_workers["q1"]]
     description = "NGINX Web"
-
-    after = [builtin.target.network, service.db, instance.queue_workers["q1"]]
-    wants = [builtin.target.network_online]
+    wants       = [builtin.target.network_online]
   }
 
   service {
-    exec_start = "/usr/sbin/nginx -g 'daemon off;'"
+    exec_start      = ["/usr/sbin/nginx -g 'daemon off;'"]
     standard_output = "journal"
   }
 
@@ -18,13 +17,18 @@ service "nginx" {
 
 service "db" {
   unit {
+    after       = [builtin.target.network]
     description = "Database"
-
-    after = [builtin.target.network]
   }
 
   service {
-    exec_start = "/usr/bin/db-server"
+    environment = {
+      DB_PORT    = "5432"
+      DB_OPTIONS = "--max-connections 100 --name \"primary\""
+      DB_SHARE   = "50%"
+    }
+    environment_file = ["-/etc/default/db"]
+    exec_start       = ["/usr/bin/db-server"]
   }
 
   install {
@@ -40,13 +44,12 @@ service "worker" {
   }
 
   unit {
+    after       = [builtin.target.network, service.db]
     description = "Worker - ${each.value}"
-
-    after = [builtin.target.network, service.db]
   }
 
   service {
-    exec_start = "/usr/bin/worker --type ${each.key} --name ${self.instance}"
+    exec_start = ["/usr/bin/worker --type ${each.key} --name ${self.instance}"]
   }
 
   install {
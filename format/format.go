@@ -0,0 +1,270 @@
+// Package format implements the canonical layout of unitd configurations
+// applied by "unitd fmt".
+//
+// On top of the standard HCL formatting of hclwrite.Format it:
+//
+//   - orders the content of service and instance blocks: template,
+//     for_each, unit, the type specific section, install;
+//   - sorts the attributes of each section in directive registry order,
+//     which is the generated struct field order, unknown attributes last,
+//     in name order;
+//   - lays lists out on one line when they fit, one element per line with a
+//     trailing comma otherwise.
+//
+// Bodies holding free-standing comments are only reformatted, never
+// reordered, so that no comment is moved away from its context.
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// maxLineWidth is the width up to which lists are kept on one line.
+const maxLineWidth = 100
+
+// rootAttributes are the attributes of the top-level blocks, written
+// before the section blocks of the unit.
+var rootAttributes = map[string][]string{
+	"service":  {"template", "for_each", "restart_policy"},
+	"instance": {"template", "instances"},
+	"host":     {"enable", "disable"},
+}
+
+// Source formats a configuration file.
+func Source(filename string, src []byte) ([]byte, hcl.Diagnostics) {
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	for _, block := range f.Body().Blocks() {
+		if _, ok := rootAttributes[block.Type()]; ok {
+			formatBody(block.Body(), rootLayout(block.Type()), 1)
+		}
+	}
+
+	return hclwrite.Format(f.Bytes()), diags
+}
+
+// layout is the canonical order of the items of a body.
+type layout struct {
+	attrs  []string
+	lists  map[string]bool
+	blocks []string // section blocks
+}
+
+func rootLayout(typ string) layout {
+	l := layout{attrs: rootAttributes[typ], lists: map[string]bool{"instances": true, "enable": true, "disable": true}}
+	for _, s := range configs.UnitSections(typ) {
+		l.blocks = append(l.blocks, s.Name)
+	}
+	return l
+}
+
+func sectionLayout(section string) layout {
+	l := layout{lists: make(map[string]bool)}
+	for _, d := range configs.SectionDirectives(section) {
+		l.attrs = append(l.attrs, d.Name)
+		l.lists[d.Name] = strings.HasPrefix(d.Type, "list(")
+	}
+	return l
+}
+
+func formatBody(body *hclwrite.Body, l layout, depth int) {
+	attrs := body.Attributes()
+	for name, attr := range attrs {
+		if l.lists[name] {
+			normalizeList(body, name, attr, depth)
+		}
+	}
+	attrs = body.Attributes()
+
+	blocks := body.Blocks()
+	for _, block := range blocks {
+		if containsString(l.blocks, block.Type()) {
+			formatBody(block.Body(), sectionLayout(block.Type()), depth+1)
+		}
+	}
+
+	if hasFreeComments(body, attrs, blocks) {
+		return
+	}
+
+	var attrTokens []hclwrite.Tokens
+	for _, name := range orderedNames(l.attrs, attrs) {
+		attrTokens = append(attrTokens, attrs[name].BuildTokens(nil))
+	}
+
+	rank := make(map[string]int, len(l.blocks))
+	for i, name := range l.blocks {
+		rank[name] = i
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		ri, ok := rank[blocks[i].Type()]
+		if !ok {
+			ri = len(rank)
+		}
+		rj, ok := rank[blocks[j].Type()]
+		if !ok {
+			rj = len(rank)
+		}
+		return ri < rj
+	})
+
+	body.Clear()
+	body.AppendNewline()
+	for _, toks := range attrTokens {
+		body.AppendUnstructuredTokens(toks)
+	}
+	for i, block := range blocks {
+		if i > 0 || len(attrTokens) > 0 {
+			body.AppendNewline()
+		}
+		body.AppendUnstructuredTokens(block.BuildTokens(nil))
+	}
+}
+
+// orderedNames returns the names of attrs in the order of known, followed by
+// the unknown names sorted.
+func orderedNames(known []string, attrs map[string]*hclwrite.Attribute) []string {
+	names := make([]string, 0, len(attrs))
+	seen := make(map[string]bool, len(known))
+	for _, name := range known {
+		seen[name] = true
+		if _, ok := attrs[name]; ok {
+			names = append(names, name)
+		}
+	}
+	var rest []string
+	for name := range attrs {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// hasFreeComments reports whether body holds comments that are not attached
+// to one of its attributes or blocks.
+func hasFreeComments(body *hclwrite.Body, attrs map[string]*hclwrite.Attribute, blocks []*hclwrite.Block) bool {
+	attached := 0
+	for _, attr := range attrs {
+		attached += countComments(attr.BuildTokens(nil))
+	}
+	for _, block := range blocks {
+		attached += countComments(block.BuildTokens(nil))
+	}
+	return countComments(body.BuildTokens(nil)) != attached
+}
+
+func countComments(toks hclwrite.Tokens) int {
+	n := 0
+	for _, tok := range toks {
+		if tok.Type == hclsyntax.TokenComment {
+			n++
+		}
+	}
+	return n
+}
+
+// normalizeList rewrites a list literal to one line when it fits and has no
+// comments or multi-line elements, and to one element per line otherwise.
+func normalizeList(body *hclwrite.Body, name string, attr *hclwrite.Attribute, depth int) {
+	toks := attr.Expr().BuildTokens(nil)
+	if len(toks) < 2 || toks[0].Type != hclsyntax.TokenOBrack || toks[len(toks)-1].Type != hclsyntax.TokenCBrack {
+		return
+	}
+
+	elems, ok := splitElements(toks[1 : len(toks)-1])
+	if !ok {
+		return
+	}
+
+	width := 2*depth + len(name) + len(" = []")
+	for i, elem := range elems {
+		if i > 0 {
+			width += len(", ")
+		}
+		width += len(strings.TrimSpace(string(elem.Bytes())))
+	}
+
+	out := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+	if width <= maxLineWidth {
+		for i, elem := range elems {
+			if i > 0 {
+				out = append(out, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+			}
+			out = append(out, elem...)
+		}
+	} else {
+		out = append(out, newline())
+		for _, elem := range elems {
+			out = append(out, elem...)
+			out = append(out, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")}, newline())
+		}
+	}
+	out = append(out, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+
+	body.SetAttributeRaw(name, out)
+}
+
+// splitElements splits the tokens between the brackets of a list at its
+// top-level commas. It gives up on comments and multi-line elements.
+func splitElements(toks hclwrite.Tokens) ([]hclwrite.Tokens, bool) {
+	var elems []hclwrite.Tokens
+	var cur hclwrite.Tokens
+	depth := 0
+
+	flush := func() {
+		if len(cur) > 0 {
+			elems = append(elems, cur)
+			cur = nil
+		}
+	}
+
+	for _, tok := range toks {
+		switch tok.Type {
+		case hclsyntax.TokenComment:
+			return nil, false
+		case hclsyntax.TokenNewline:
+			if depth > 0 {
+				return nil, false
+			}
+			continue
+		case hclsyntax.TokenOBrack, hclsyntax.TokenOBrace, hclsyntax.TokenOParen,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl, hclsyntax.TokenOQuote, hclsyntax.TokenOHeredoc:
+			depth++
+		case hclsyntax.TokenCBrack, hclsyntax.TokenCBrace, hclsyntax.TokenCParen,
+			hclsyntax.TokenTemplateSeqEnd, hclsyntax.TokenCQuote, hclsyntax.TokenCHeredoc:
+			depth--
+		case hclsyntax.TokenComma:
+			if depth == 0 {
+				flush()
+				continue
+			}
+		}
+		cur = append(cur, tok)
+	}
+	flush()
+	return elems, true
+}
+
+func newline() *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package format
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	src := `service "worker" {
+  install {
+    wanted_by = [builtin.target.multi_user,]
+  }
+  unit {
+      description = "Worker"
+
+    after = [
+      builtin.target.network,
+      service.db
+    ]
+  }
+  service {
+    exec_start = ["/usr/bin/worker --name ${self.instance}"]
+    environment_file = ["-/etc/default/worker-with-a-rather-long-name", "-/etc/default/another-rather-long-name"]
+  }
+  template = true
+}
+
+instance "workers" {
+  instances = ["q1", "q2"] # trailing
+  template  = service.worker
+}
+`
+	want := `service "worker" {
+  template = true
+
+  unit {
+    after       = [builtin.target.network, service.db]
+    description = "Worker"
+  }
+
+  service {
+    environment_file = [
+      "-/etc/default/worker-with-a-rather-long-name",
+      "-/etc/default/another-rather-long-name",
+    ]
+    exec_start = ["/usr/bin/worker --name ${self.instance}"]
+  }
+
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+instance "workers" {
+  template  = service.worker
+  instances = ["q1", "q2"] # trailing
+}
+`
+	got, diags := Source("test.hcl", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	again, _ := Source("test.hcl", got)
+	if string(again) != string(got) {
+		t.Errorf("formatting is not idempotent:\n%s", again)
+	}
+}
+
+func TestSourceKeepsFreeComments(t *testing.T) {
+	src := `service "db" {
+  unit {
+    wants = [builtin.target.network_online]
+
+    # ordering
+
+    after = [builtin.target.network]
+  }
+}
+`
+	got, diags := Source("test.hcl", []byte(src))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	if string(got) != src {
+		t.Errorf("body with a free-standing comment was reordered:\n%s", got)
+	}
+}
+
+func TestExampleIsFormatted(t *testing.T) {
+	path := "../example/src/services.hcl"
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, diags := Source(path, src)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	if string(got) != string(src) {
+		t.Errorf("%s is not formatted; run unitd fmt", path)
+	}
+}
@@ -0,0 +1,191 @@
+// Package generation records the deployments unitd apply makes to a host,
+// on the host itself, so that any of them can be restored.
+//
+// Generation N is the directory Dir/N:
+//
+//	generation.json  number, time, source hash and file count
+//	manifest.json    the manifest of the unit directory once deployed
+//	tree/            the files deployed
+//
+// Only the last Keep generations are kept.
+package generation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/output"
+	"github.com/vanviethieuanh/unitd/transport"
+)
+
+// Dir is where the generations of a host are kept.
+const Dir = "/var/lib/unitd/generations"
+
+// Keep is the number of generations kept on a host. Recording a generation
+// removes the oldest ones beyond it.
+const Keep = 10
+
+// Generation is one deployment to a host.
+type Generation struct {
+	Number int       `json:"generation"`
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"` // hash of the configuration deployed
+
+	// Restores is the generation a rollback brought back, if any.
+	Restores int `json:"restores,omitempty"`
+
+	// Count is the number of files deployed.
+	Count int `json:"files"`
+
+	// Files is the tree deployed. List leaves it empty; see Load.
+	Files []configs.File `json:"-"`
+}
+
+// Live is the generation whose tree g deployed: the one it restores, or
+// g itself.
+func (g Generation) Live() int {
+	if g.Restores != 0 {
+		return g.Restores
+	}
+	return g.Number
+}
+
+// List returns the generations recorded on the host, oldest first, without
+// their files. Only the generation.json of each generation is read.
+func List(t transport.Transport) ([]Generation, error) {
+	entries, err := t.ReadDir(Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var gens []Generation
+	for _, e := range entries {
+		n, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		var g Generation
+		data, err := t.ReadFile(path.Join(Dir, e.Name(), "generation.json"))
+		if err == nil {
+			err = json.Unmarshal(data, &g)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("generation %d: %w", n, err)
+		}
+		gens = append(gens, g)
+	}
+	sort.Slice(gens, func(i, j int) bool { return gens[i].Number < gens[j].Number })
+	return gens, nil
+}
+
+// Load returns generation n with the files it deployed.
+func Load(t transport.Transport, n int) (Generation, error) {
+	fsys, err := t.Snapshot(path.Join(Dir, strconv.Itoa(n)))
+	if err != nil {
+		return Generation{}, err
+	}
+	g, err := read(fsys)
+	if err != nil {
+		return g, fmt.Errorf("generation %d: %w", n, err)
+	}
+	return g, nil
+}
+
+func read(fsys fs.FS) (Generation, error) {
+	var g Generation
+	data, err := fs.ReadFile(fsys, "generation.json")
+	if err != nil {
+		return g, err
+	}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return g, err
+	}
+
+	var m output.Manifest
+	data, err = fs.ReadFile(fsys, "manifest.json")
+	if err != nil {
+		return g, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return g, err
+	}
+	for _, e := range m.Files {
+		name := path.Join("tree", e.File)
+		f := configs.File{Name: e.File, Source: e.Source}
+		if e.Symlink {
+			f.Link, err = fs.ReadLink(fsys, name)
+		} else {
+			var content []byte
+			content, err = fs.ReadFile(fsys, name)
+			f.Content = string(content)
+		}
+		if err != nil {
+			return g, err
+		}
+		g.Files = append(g.Files, f)
+	}
+	return g, nil
+}
+
+// Record writes g, the deployment the plan made, as the next generation of
+// the host and returns its number. The generations before the last Keep are
+// then removed.
+//
+// Each generation holds a full copy of the tree deployed, not only the files
+// that changed, so that it can be restored on its own once the generations
+// before it are removed. Unit trees are small; the copies are cheap.
+func Record(t transport.Transport, g Generation, p *output.Plan) (int, error) {
+	gens, err := List(t)
+	if err != nil {
+		return 0, err
+	}
+	g.Number = 1
+	if len(gens) > 0 {
+		g.Number = gens[len(gens)-1].Number + 1
+	}
+	g.Count = len(g.Files)
+
+	meta, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	manifest, err := p.Manifest.Marshal()
+	if err != nil {
+		return 0, err
+	}
+	files := []configs.File{
+		{Name: "generation.json", Content: string(meta) + "\n"},
+		{Name: "manifest.json", Content: string(manifest)},
+	}
+	for _, f := range g.Files {
+		f.Name = path.Join("tree", f.Name)
+		files = append(files, f)
+	}
+
+	dir := path.Join(Dir, strconv.Itoa(g.Number))
+	current, err := t.Snapshot(dir)
+	if err != nil {
+		return 0, err
+	}
+	plan, err := output.NewPlanFS(dir, current, files)
+	if err != nil {
+		return 0, err
+	}
+	if err := t.Apply(plan, output.DefaultOptions, nil); err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(gens)+1-Keep; i++ {
+		old := path.Join(Dir, strconv.Itoa(gens[i].Number))
+		if err := t.RemoveAll(old); err != nil {
+			return g.Number, fmt.Errorf("removing generation %d: %w", gens[i].Number, err)
+		}
+	}
+	return g.Number, nil
+}
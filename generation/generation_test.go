@@ -0,0 +1,109 @@
+package generation
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/output"
+	"github.com/vanviethieuanh/unitd/transport"
+)
+
+// deploy applies files to the unit directory of t and records the
+// deployment.
+func deploy(t *testing.T, tr transport.Transport, g Generation) int {
+	t.Helper()
+	dir := "/etc/systemd/system"
+	current, err := tr.Snapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := output.NewPlanFS(dir, current, g.Files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Apply(plan, output.DefaultOptions, nil); err != nil {
+		t.Fatal(err)
+	}
+	n, err := Record(tr, g, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestRecord(t *testing.T) {
+	tr := &transport.Local{Root: t.TempDir()}
+	if gens, err := List(tr); err != nil || len(gens) != 0 {
+		t.Fatalf("List on a new host = %v, %v", gens, err)
+	}
+
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	first := []configs.File{
+		{Name: "multi-user.target.wants/web.service", Link: "/etc/systemd/system/web.service", Source: "service.web"},
+		{Name: "web.service", Content: "[Service]\nExecStart=/bin/web\n", Source: "service.web"},
+	}
+	second := []configs.File{
+		{Name: "web.service", Content: "[Service]\nExecStart=/bin/web -v\n", Source: "service.web"},
+	}
+	if n := deploy(t, tr, Generation{Time: at, Source: "sha256:01", Files: first}); n != 1 {
+		t.Errorf("first generation is %d", n)
+	}
+	if n := deploy(t, tr, Generation{Time: at.Add(time.Hour), Source: "sha256:02", Files: second}); n != 2 {
+		t.Errorf("second generation is %d", n)
+	}
+	if n := deploy(t, tr, Generation{Time: at.Add(2 * time.Hour), Source: "sha256:01", Restores: 1, Files: first}); n != 3 {
+		t.Errorf("rollback generation is %d", n)
+	}
+
+	gens, err := List(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Generation{
+		{Number: 1, Time: at, Source: "sha256:01", Count: 2},
+		{Number: 2, Time: at.Add(time.Hour), Source: "sha256:02", Count: 1},
+		{Number: 3, Time: at.Add(2 * time.Hour), Source: "sha256:01", Restores: 1, Count: 2},
+	}
+	if diff := cmp.Diff(want, gens); diff != "" {
+		t.Errorf("generations (-want +got):\n%s", diff)
+	}
+	if live := gens[2].Live(); live != 1 {
+		t.Errorf("live generation is %d, want 1", live)
+	}
+
+	g, err := Load(tr, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want[0].Files = first
+	if diff := cmp.Diff(want[0], g); diff != "" {
+		t.Errorf("generation 1 (-want +got):\n%s", diff)
+	}
+	if _, err := Load(tr, 4); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load of a missing generation: %v", err)
+	}
+}
+
+func TestRecordKeepsTheLastGenerations(t *testing.T) {
+	tr := &transport.Local{Root: t.TempDir()}
+	for i := 1; i <= Keep+2; i++ {
+		files := []configs.File{{Name: "web.service", Content: fmt.Sprintf("[Service]\nExecStart=/bin/web %d\n", i)}}
+		deploy(t, tr, Generation{Source: "sha256:01", Files: files})
+	}
+
+	gens, err := List(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gens) != Keep || gens[0].Number != 3 || gens[Keep-1].Number != Keep+2 {
+		t.Errorf("kept generations %d to %d (%d), want 3 to %d", gens[0].Number, gens[len(gens)-1].Number, len(gens), Keep+2)
+	}
+	if entries, _ := tr.ReadDir(Dir); len(entries) != Keep {
+		t.Errorf("%d entries left in %s, want %d", len(entries), Dir, Keep)
+	}
+}
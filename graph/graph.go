@@ -0,0 +1,269 @@
+// Package graph builds the dependency graph between the units of a
+// configuration, from every directive holding unit names.
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// NodeKind tells where a unit is defined.
+type NodeKind string
+
+const (
+	// NodeUser is a unit defined by the configuration.
+	NodeUser NodeKind = "user"
+	// NodeBuiltin is a well-known unit shipped with systemd.
+	NodeBuiltin NodeKind = "builtin"
+	// NodeExternal is a unit referenced but defined nowhere known.
+	NodeExternal NodeKind = "external"
+)
+
+// EdgeClass groups relationship types for filtering.
+type EdgeClass string
+
+const (
+	// ClassOrdering covers After= and Before=.
+	ClassOrdering EdgeClass = "ordering"
+	// ClassRequirement covers Requires=, Wants=, WantedBy=, ….
+	ClassRequirement EdgeClass = "requirement"
+	// ClassOther covers the remaining relationships: Conflicts=, OnFailure=, ….
+	ClassOther EdgeClass = "other"
+)
+
+var edgeClasses = map[string]EdgeClass{
+	"After":      ClassOrdering,
+	"Before":     ClassOrdering,
+	"BindTo":     ClassRequirement,
+	"BindsTo":    ClassRequirement,
+	"PartOf":     ClassRequirement,
+	"RequiredBy": ClassRequirement,
+	"Requires":   ClassRequirement,
+	"Requisite":  ClassRequirement,
+	"UpheldBy":   ClassRequirement,
+	"Upholds":    ClassRequirement,
+	"WantedBy":   ClassRequirement,
+	"Wants":      ClassRequirement,
+}
+
+// Node is a unit.
+type Node struct {
+	ID   string   `json:"id"` // unit file name
+	Kind NodeKind `json:"kind"`
+}
+
+// Edge is a relationship declared by the From unit: From has Type=To.
+type Edge struct {
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	Type  string    `json:"type"` // directive name: After, WantedBy, …
+	Class EdgeClass `json:"class"`
+}
+
+// Graph is a unit dependency graph. Nodes and edges are sorted.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Build returns the graph of the units rendered from config. Instances are
+// linked to their template with an "InstanceOf" edge.
+func Build(config *configs.Config) (*Graph, error) {
+	nodes := make(map[string]NodeKind)
+	var edges []Edge
+
+	for _, svc := range config.Services {
+		unit, err := configs.NewUnitCodec[configs.Service]().Encode(svc)
+		if err != nil {
+			return nil, fmt.Errorf("service %q: %w", svc.Name, err)
+		}
+		for _, name := range svc.UnitFilenames() {
+			nodes[name] = NodeUser
+			refs, err := unitRefs(unit, "service")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			for _, e := range refs {
+				e.From = name
+				edges = append(edges, e)
+			}
+		}
+	}
+
+	for _, inst := range config.Instances {
+		for _, id := range inst.Instances {
+			name := configs.InstanceUnitName(inst.Template, id)
+			nodes[name] = NodeUser
+			edges = append(edges, Edge{From: name, To: inst.Template, Type: "InstanceOf", Class: ClassOther})
+		}
+	}
+
+	for _, e := range edges {
+		if _, ok := nodes[e.To]; !ok {
+			nodes[e.To] = NodeExternal
+			if _, _, builtin := configs.BuiltinAddress(e.To); builtin {
+				nodes[e.To] = NodeBuiltin
+			}
+		}
+	}
+
+	g := &Graph{Nodes: []Node{}, Edges: dedupe(edges)}
+	for id, kind := range nodes {
+		g.Nodes = append(g.Nodes, Node{ID: id, Kind: kind})
+	}
+	g.sort()
+	return g, nil
+}
+
+// unitRefs returns the edges of the unit reference directives of unit, a
+// unit of type unitType, with From left empty.
+func unitRefs(unit *configs.SystemdUnit, unitType string) ([]Edge, error) {
+	var edges []Edge
+	for _, s := range configs.UnitSections(unitType) {
+		refs := make(map[string]bool)
+		for _, d := range configs.SectionDirectives(s.Name) {
+			if d.Ref == "unit" {
+				refs[d.Systemd] = true
+			}
+		}
+
+		for _, entry := range unit.Sections[s.Systemd[1:len(s.Systemd)-1]] {
+			if !refs[entry.Key] {
+				continue
+			}
+			names, err := configs.SplitWords(entry.Value, false)
+			if err != nil {
+				return nil, fmt.Errorf("%s=: %w", entry.Key, err)
+			}
+			for _, name := range names {
+				edges = append(edges, Edge{To: name, Type: entry.Key, Class: classOf(entry.Key)})
+			}
+		}
+	}
+	return edges, nil
+}
+
+func classOf(typ string) EdgeClass {
+	if c, ok := edgeClasses[typ]; ok {
+		return c
+	}
+	return ClassOther
+}
+
+func dedupe(edges []Edge) []Edge {
+	seen := make(map[Edge]bool, len(edges))
+	out := []Edge{}
+	for _, e := range edges {
+		if !seen[e] {
+			seen[e] = true
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (g *Graph) sort() {
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Type < b.Type
+	})
+}
+
+// FilterClass returns the graph restricted to edges of class c. Nodes left
+// without edges are dropped, except for the units in keep, such as the focus
+// of a neighbourhood.
+func (g *Graph) FilterClass(c EdgeClass, keep ...string) *Graph {
+	var edges []Edge
+	for _, e := range g.Edges {
+		if e.Class == c {
+			edges = append(edges, e)
+		}
+	}
+	kept := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		kept[id] = true
+	}
+	return g.withEdges(edges, kept)
+}
+
+// Neighbourhood returns the graph restricted to the units at most depth
+// edges away from one of units, following edges in both directions.
+func (g *Graph) Neighbourhood(units []string, depth int) (*Graph, error) {
+	keep := make(map[string]bool, len(units))
+	for _, unit := range units {
+		if _, ok := g.node(unit); !ok {
+			return nil, fmt.Errorf("unit %q is not in the graph", unit)
+		}
+		keep[unit] = true
+	}
+
+	frontier := append([]string{}, units...)
+	for i := 0; i < depth && len(frontier) > 0; i++ {
+		var next []string
+		for _, e := range g.Edges {
+			for _, pair := range [][2]string{{e.From, e.To}, {e.To, e.From}} {
+				if containsString(frontier, pair[0]) && !keep[pair[1]] {
+					keep[pair[1]] = true
+					next = append(next, pair[1])
+				}
+			}
+		}
+		frontier = next
+	}
+
+	var edges []Edge
+	for _, e := range g.Edges {
+		if keep[e.From] && keep[e.To] {
+			edges = append(edges, e)
+		}
+	}
+	return g.withEdges(edges, keep), nil
+}
+
+// withEdges returns a graph of edges and the nodes they connect, plus the
+// nodes in keep.
+func (g *Graph) withEdges(edges []Edge, keep map[string]bool) *Graph {
+	used := make(map[string]bool, len(keep))
+	for id := range keep {
+		used[id] = true
+	}
+	for _, e := range edges {
+		used[e.From] = true
+		used[e.To] = true
+	}
+
+	out := &Graph{Nodes: []Node{}, Edges: append([]Edge{}, edges...)}
+	for _, n := range g.Nodes {
+		if used[n.ID] {
+			out.Nodes = append(out.Nodes, n)
+		}
+	}
+	return out
+}
+
+func (g *Graph) node(id string) (Node, bool) {
+	for _, n := range g.Nodes {
+		if n.ID == id {
+			return n, true
+		}
+	}
+	return Node{}, false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+const testConfig = `
+service "db" {
+  unit {
+    after = [builtin.target.network]
+  }
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+  service {
+    exec_start = ["/usr/bin/db"]
+  }
+}
+
+service "app" {
+  unit {
+    after     = [service.db]
+    requires  = [service.db]
+    conflicts = ["legacy.service"]
+  }
+  service {
+    exec_start = ["/usr/bin/app"]
+  }
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+service "worker" {
+  template = true
+
+  unit {
+    part_of = [service.app]
+  }
+  service {
+    exec_start = ["/usr/bin/worker ${self.instance}"]
+  }
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+instance "workers" {
+  template  = service.worker
+  instances = ["a"]
+}
+`
+
+func testGraph(t *testing.T) *Graph {
+	t.Helper()
+	config, diags := configs.Decode("test.hcl", []byte(testConfig))
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	g, err := Build(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestBuild(t *testing.T) {
+	g := testGraph(t)
+
+	wantNodes := []Node{
+		{"app.service", NodeUser},
+		{"db.service", NodeUser},
+		{"legacy.service", NodeExternal},
+		{"multi-user.target", NodeBuiltin},
+		{"network.target", NodeBuiltin},
+		{"worker@.service", NodeUser},
+		{"worker@a.service", NodeUser},
+	}
+	wantEdges := []Edge{
+		{"app.service", "db.service", "After", ClassOrdering},
+		{"app.service", "db.service", "Requires", ClassRequirement},
+		{"app.service", "legacy.service", "Conflicts", ClassOther},
+		{"app.service", "multi-user.target", "WantedBy", ClassRequirement},
+		{"db.service", "multi-user.target", "WantedBy", ClassRequirement},
+		{"db.service", "network.target", "After", ClassOrdering},
+		{"worker@.service", "app.service", "PartOf", ClassRequirement},
+		{"worker@.service", "multi-user.target", "WantedBy", ClassRequirement},
+		{"worker@a.service", "worker@.service", "InstanceOf", ClassOther},
+	}
+	if diff := cmp.Diff(wantNodes, g.Nodes); diff != "" {
+		t.Errorf("nodes (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantEdges, g.Edges); diff != "" {
+		t.Errorf("edges (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	g := testGraph(t)
+
+	ordering := g.FilterClass(ClassOrdering)
+	for _, e := range ordering.Edges {
+		if e.Class != ClassOrdering {
+			t.Errorf("ordering filter kept %+v", e)
+		}
+	}
+	if len(ordering.Nodes) != 3 {
+		t.Errorf("ordering filter kept %d nodes, want 3", len(ordering.Nodes))
+	}
+
+	near, err := g.Neighbourhood([]string{"worker@.service"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, n := range near.Nodes {
+		ids = append(ids, n.ID)
+	}
+	want := []string{"app.service", "multi-user.target", "worker@.service", "worker@a.service"}
+	if diff := cmp.Diff(want, ids); diff != "" {
+		t.Errorf("neighbourhood (-want +got):\n%s", diff)
+	}
+
+	if _, err := g.Neighbourhood([]string{"missing.service"}, 1); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+
+	// worker@.service has no ordering edges but is still the focus.
+	focus := []string{"worker@.service"}
+	near, err = g.FilterClass(ClassOrdering, focus...).Neighbourhood(focus, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(near.Nodes) != 1 || near.Nodes[0].ID != "worker@.service" || len(near.Edges) != 0 {
+		t.Errorf("ordering neighbourhood of worker@.service: %+v", near)
+	}
+	if _, err := g.FilterClass(ClassOrdering, "missing.service").Neighbourhood([]string{"missing.service"}, 1); err == nil {
+		t.Error("expected an error for an unknown unit after filtering")
+	}
+}
+
+func TestWriters(t *testing.T) {
+	g := testGraph(t).FilterClass(ClassOrdering)
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"network.target" [shape=ellipse, style=dashed];`,
+		`"app.service" -> "db.service" [label="After", style=dashed];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output lacks %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`n2(["network.target"]):::builtin`,
+		`n0 -.->|After| n1`,
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid output lacks %q:\n%s", want, mermaid.String())
+		}
+	}
+
+	var js bytes.Buffer
+	if err := g.WriteJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(js.String(), `"class": "ordering"`) {
+		t.Errorf("JSON output lacks edge class:\n%s", js.String())
+	}
+}
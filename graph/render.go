@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes g in the Graphviz DOT language. Builtin units are drawn as
+// dashed ellipses, units from elsewhere as dotted boxes.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph units {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=filled, fillcolor=\"#e8f0fe\"];\n")
+	for _, n := range g.Nodes {
+		switch n.Kind {
+		case NodeBuiltin:
+			fmt.Fprintf(&b, "  %q [shape=ellipse, style=dashed];\n", n.ID)
+		case NodeExternal:
+			fmt.Fprintf(&b, "  %q [style=dotted];\n", n.ID)
+		default:
+			fmt.Fprintf(&b, "  %q;\n", n.ID)
+		}
+	}
+	for _, e := range g.Edges {
+		attrs := fmt.Sprintf("label=%q", e.Type)
+		if e.Class == ClassOrdering {
+			attrs += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %q -> %q [%s];\n", e.From, e.To, attrs)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes g as a Mermaid flowchart. Builtin units get the
+// stadium shape and the "builtin" class.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		switch n.Kind {
+		case NodeBuiltin:
+			fmt.Fprintf(&b, "  %s([%q]):::builtin\n", id, n.ID)
+		case NodeExternal:
+			fmt.Fprintf(&b, "  %s[%q]:::external\n", id, n.ID)
+		default:
+			fmt.Fprintf(&b, "  %s[%q]\n", id, n.ID)
+		}
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Class == ClassOrdering {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", ids[e.From], arrow, e.Type, ids[e.To])
+	}
+	b.WriteString("  classDef builtin fill:#eee,stroke-dasharray:5 5\n")
+	b.WriteString("  classDef external stroke-dasharray:2 2\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes g as JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	out, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
@@ -0,0 +1,314 @@
+// Package importer converts existing systemd unit files into unitd HCL.
+package importer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Importer accumulates unit files and renders them as one HCL file.
+//
+// Dependencies are rewritten into references where possible:
+//
+//	multi-user.target       →  builtin.target.multi_user
+//	db.service (imported)   →  service.db
+//	worker@q1.service       →  instance.worker["q1"]   (worker@.service imported)
+type Importer struct {
+	services []configs.Service
+
+	// instances maps an imported template name to the instance ids seen for
+	// it, either in references or in *.wants/ style enablement directories.
+	instances map[string]map[string]struct{}
+
+	diags hcl.Diagnostics
+}
+
+// New returns an empty Importer.
+func New() *Importer {
+	return &Importer{instances: make(map[string]map[string]struct{})}
+}
+
+// AddUnit decodes a parsed unit file. Only .service units can be expressed in
+// unitd today; other unit types are skipped with a warning.
+func (im *Importer) AddUnit(unit *configs.SystemdUnit) {
+	if !strings.HasSuffix(unit.Filename, ".service") {
+		im.diags = append(im.diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Unit type not supported",
+			Detail:   fmt.Sprintf("%s was skipped: unitd configurations only contain service blocks.", unit.Filename),
+		})
+		return
+	}
+
+	var svc configs.Service
+	for _, diag := range configs.DecodeUnit(unit, &svc) {
+		if diag.Summary == "Unknown directive" || diag.Summary == "Unknown section" {
+			diag.Severity = hcl.DiagWarning
+			diag.Detail += " It was dropped from the import."
+		}
+		im.diags = append(im.diags, diag)
+	}
+	im.services = append(im.services, svc)
+}
+
+// AddInstanceHint records a unit name found outside of the unit files, such
+// as the "worker@q1.service" link of a multi-user.target.wants/ directory.
+func (im *Importer) AddInstanceHint(name string) {
+	im.instanceRef(name)
+}
+
+// File renders every added unit. Services are emitted in name order,
+// followed by one instance block per template with known instances.
+func (im *Importer) File() (*hclwrite.File, hcl.Diagnostics) {
+	sort.SliceStable(im.services, func(i, j int) bool {
+		return im.services[i].Name < im.services[j].Name
+	})
+
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+
+	// Resolve every reference first so instance blocks are complete.
+	blocks := make([]*hclwrite.Block, 0, len(im.services))
+	for _, svc := range im.services {
+		blocks = append(blocks, im.serviceBlock(svc))
+	}
+	for i, b := range blocks {
+		if i > 0 {
+			root.AppendNewline()
+		}
+		root.AppendBlock(b)
+	}
+
+	for _, tmpl := range sortedSet(im.instanceTemplates()) {
+		ids := sortedSet(im.instances[tmpl])
+		if len(ids) == 0 {
+			continue
+		}
+		root.AppendNewline()
+		block := root.AppendNewBlock("instance", []string{tmpl})
+		block.Body().SetAttributeTraversal("template", hcl.Traversal{
+			hcl.TraverseRoot{Name: "service"},
+			hcl.TraverseAttr{Name: tmpl},
+		})
+		values := make([]cty.Value, len(ids))
+		for i, id := range ids {
+			values[i] = cty.StringVal(id)
+		}
+		block.Body().SetAttributeValue("instances", cty.ListVal(values))
+	}
+
+	return f, im.diags
+}
+
+func (im *Importer) instanceTemplates() map[string]struct{} {
+	set := make(map[string]struct{}, len(im.instances))
+	for k := range im.instances {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+func (im *Importer) serviceBlock(svc configs.Service) *hclwrite.Block {
+	block := hclwrite.NewBlock("service", []string{svc.Name})
+	body := block.Body()
+
+	if svc.Template {
+		body.SetAttributeValue("template", cty.True)
+		body.AppendNewline()
+	}
+
+	rv := reflect.ValueOf(svc)
+	rt := rv.Type()
+	first := true
+	for i := 0; i < rt.NumField(); i++ {
+		name, kind, _ := strings.Cut(rt.Field(i).Tag.Get("hcl"), ",")
+		if kind != "block" {
+			continue
+		}
+		if !first {
+			body.AppendNewline()
+		}
+		first = false
+		inner := body.AppendNewBlock(name, nil).Body()
+		im.writeAttributes(svc.Name, inner, rv.Field(i))
+	}
+
+	return block
+}
+
+func (im *Importer) writeAttributes(unit string, body *hclwrite.Body, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("hcl"), ",")
+		if name == "" {
+			continue
+		}
+		value := rv.Field(i)
+		if value.IsZero() {
+			continue
+		}
+		isRef := strings.Contains(field.Tag.Get("unitd"), "ref=unit")
+
+		switch value.Kind() {
+		case reflect.String:
+			if isRef {
+				body.SetAttributeRaw(name, im.refTokens(value.String()))
+			} else {
+				body.SetAttributeRaw(name, im.stringTokens(unit, value.String()))
+			}
+
+		case reflect.Bool:
+			body.SetAttributeValue(name, cty.BoolVal(value.Bool()))
+
+		case reflect.Pointer:
+			body.SetAttributeValue(name, scalarValue(value.Elem()))
+
+		case reflect.Slice:
+			elems := make([]hclwrite.Tokens, value.Len())
+			for j := range elems {
+				if isRef {
+					elems[j] = im.refTokens(value.Index(j).String())
+				} else {
+					elems[j] = im.stringTokens(unit, value.Index(j).String())
+				}
+			}
+			body.SetAttributeRaw(name, hclwrite.TokensForTuple(elems))
+
+		case reflect.Map:
+			keys := value.MapKeys()
+			sort.Slice(keys, func(a, b int) bool { return keys[a].String() < keys[b].String() })
+			attrs := make([]hclwrite.ObjectAttrTokens, len(keys))
+			for j, k := range keys {
+				attrs[j] = hclwrite.ObjectAttrTokens{
+					Name:  hclwrite.TokensForIdentifier(k.String()),
+					Value: im.stringTokens(unit, value.MapIndex(k).String()),
+				}
+			}
+			body.SetAttributeRaw(name, hclwrite.TokensForObject(attrs))
+		}
+	}
+}
+
+func scalarValue(v reflect.Value) cty.Value {
+	switch v.Kind() {
+	case reflect.Bool:
+		return cty.BoolVal(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cty.NumberIntVal(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cty.NumberUIntVal(v.Uint())
+	}
+	return cty.StringVal(fmt.Sprint(v.Interface()))
+}
+
+// stringTokens renders a decoded value as an HCL string. Specifiers become
+// self.* interpolations ("--name ${self.instance}").
+func (im *Importer) stringTokens(unit, value string) hclwrite.Tokens {
+	toks := hclwrite.Tokens{{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)}}
+
+	lit := func(s string) {
+		quoted := hclwrite.TokensForValue(cty.StringVal(s))
+		toks = append(toks, quoted[1:len(quoted)-1]...)
+	}
+	configs.SplitSpecifiers(value, lit, func(c rune) {
+		attr, ok := configs.SelfAttribute(c)
+		if !ok {
+			// Kept as text, it would render as %%c: not what systemd
+			// reads today, which is an error anyway.
+			im.diags = append(im.diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unknown specifier",
+				Detail:   fmt.Sprintf("%%%c in %s is not a systemd specifier; write %%%%%c for a literal %%%c.", c, unit, c, c),
+			})
+			lit("%" + string(c))
+			return
+		}
+		toks = append(toks,
+			&hclwrite.Token{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")},
+			&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte("self")},
+			&hclwrite.Token{Type: hclsyntax.TokenDot, Bytes: []byte(".")},
+			&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(attr)},
+			&hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")},
+		)
+	})
+
+	return append(toks, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)})
+}
+
+// refTokens renders a unit name as a reference when its target is known.
+func (im *Importer) refTokens(name string) hclwrite.Tokens {
+	if svc, ok := im.serviceRef(name); ok {
+		return hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "service"},
+			hcl.TraverseAttr{Name: svc},
+		})
+	}
+	if tmpl, id, ok := im.instanceRef(name); ok {
+		return hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "instance"},
+			hcl.TraverseAttr{Name: tmpl},
+			hcl.TraverseIndex{Key: cty.StringVal(id)},
+		})
+	}
+	if unitType, attr, ok := configs.BuiltinAddress(name); ok {
+		return hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "builtin"},
+			hcl.TraverseAttr{Name: unitType},
+			hcl.TraverseAttr{Name: attr},
+		})
+	}
+	return hclwrite.TokensForValue(cty.StringVal(name))
+}
+
+func (im *Importer) serviceRef(name string) (string, bool) {
+	for _, svc := range im.services {
+		filename := svc.Name + ".service"
+		if svc.Template {
+			filename = configs.TemplateUnitName(svc.Name, "", "service")
+		}
+		if filename == name && hclsyntax.ValidIdentifier(svc.Name) {
+			return svc.Name, true
+		}
+	}
+	return "", false
+}
+
+// instanceRef matches "tmpl@id.service" against the imported templates and
+// records the instance id.
+func (im *Importer) instanceRef(name string) (string, string, bool) {
+	at := strings.Index(name, "@")
+	dot := strings.LastIndex(name, ".")
+	if at < 0 || dot <= at+1 || name[dot:] != ".service" {
+		return "", "", false
+	}
+	tmpl, id := name[:at], name[at+1:dot]
+
+	for _, svc := range im.services {
+		if svc.Template && svc.Name == tmpl && hclsyntax.ValidIdentifier(tmpl) {
+			if im.instances[tmpl] == nil {
+				im.instances[tmpl] = make(map[string]struct{})
+			}
+			im.instances[tmpl][id] = struct{}{}
+			return tmpl, id, true
+		}
+	}
+	return "", "", false
+}
+
+func sortedSet(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func TestImportRoundTrip(t *testing.T) {
+	golden := "../example/transplied"
+	paths, err := filepath.Glob(filepath.Join(golden, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	im := New()
+	for _, path := range paths {
+		unit, diags := configs.LoadUnitFile(path)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		im.AddUnit(unit)
+	}
+	f, diags := im.File()
+	if len(diags) > 0 {
+		t.Fatal(diags.Error())
+	}
+
+	src := string(f.Bytes())
+	for _, want := range []string{
+		`after       = [builtin.target.network, service.db, instance.worker-queue["q1"]]`,
+		`exec_start = ["/usr/bin/worker --type queue --name ${self.instance}"]`,
+		`template  = service.worker-queue`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("import is missing %q:\n%s", want, src)
+		}
+	}
+
+	hclPath := filepath.Join(t.TempDir(), "units.hcl")
+	if err := os.WriteFile(hclPath, f.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := configs.DecodeFile(hclPath)
+	if err != nil {
+		t.Fatalf("decode imported configuration: %s\n%s", err, src)
+	}
+	files, err := config.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(paths) {
+		t.Fatalf("rendered %d files, imported %d", len(files), len(paths))
+	}
+	for _, file := range files {
+		want, err := os.ReadFile(filepath.Join(golden, file.Name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if file.Content != string(want) {
+			t.Errorf("%s does not round-trip\n--- want\n%s\n--- got\n%s", file.Name, want, file.Content)
+		}
+	}
+}
+
+func TestImportInstanceHints(t *testing.T) {
+	unit, diags := configs.ParseUnitFile("getty@.service", []byte("[Service]\nExecStart=/sbin/agetty %I %j\nExecStartPost=/bin/echo %z\n"))
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	im := New()
+	im.AddUnit(unit)
+	im.AddInstanceHint("getty@tty2.service")
+	im.AddInstanceHint("getty@tty1.service")
+	im.AddInstanceHint("other@x.service")
+	f, diags := im.File()
+
+	// %z has no meaning; kept as text, it would render as %%z.
+	if len(diags) != 1 || !diags.HasErrors() || !strings.Contains(diags[0].Detail, "%z") {
+		t.Errorf("expected an error for %%z, got %v", diags)
+	}
+	src := string(f.Bytes())
+	for _, want := range []string{
+		`["/sbin/agetty ${self.instance_unescaped} ${self.prefix_last}"]`,
+		`instances = ["tty1", "tty2"]`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("import is missing %q:\n%s", want, src)
+		}
+	}
+}
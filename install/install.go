@@ -0,0 +1,338 @@
+// Package install lays units out in a root filesystem the way
+// "systemctl --root=… enable" does, without running systemctl.
+package install
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// UnitDir is where units are installed, relative to the root filesystem.
+const UnitDir = "etc/systemd/system"
+
+// linkDirs maps the [Install] dependencies to the directory suffix of the
+// symlinks they create.
+var linkDirs = []struct{ key, suffix string }{
+	{"WantedBy", ".wants"},
+	{"RequiredBy", ".requires"},
+	{"UpheldBy", ".upholds"},
+}
+
+// closureKeys are the [Unit] dependencies pulled into a host's tree.
+var closureKeys = []string{"Requires", "Wants"}
+
+// Names returns the unit names to enable to enable everything: every unit
+// file and the given instances of templates.
+func Names(units []configs.File, instances []string) []string {
+	names := make([]string, 0, len(units)+len(instances))
+	for _, f := range units {
+		names = append(names, f.Name)
+	}
+	return append(names, instances...)
+}
+
+// Enable returns the symlinks enabling the units names, relative to
+// UnitDir. Each name is a file of units or an instance of a template of
+// units, e.g. "worker@q1.service". Templates are enabled through their
+// DefaultInstance=, if any; units without [Install] section are static and
+// get no link. Also= units are enabled in turn and must be among units.
+//
+//	nginx.service  WantedBy=multi-user.target  →  multi-user.target.wants/nginx.service
+//	                                                → /etc/systemd/system/nginx.service
+func Enable(units []configs.File, names []string) ([]configs.File, error) {
+	set, err := parseUnits(units)
+	if err != nil {
+		return nil, err
+	}
+	e := &enabler{unitSet: set, links: make(map[string]configs.File), done: make(map[string]bool)}
+	for _, name := range names {
+		if err := e.enable(name); err != nil {
+			return nil, err
+		}
+	}
+
+	links := make([]configs.File, 0, len(e.links))
+	for _, l := range e.links {
+		links = append(links, l)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].Name < links[j].Name })
+	return links, nil
+}
+
+// Closure returns the units of names together with their Requires= and
+// Wants= dependencies, transitively, sorted by name. An instance brings in
+// its template. Names must be defined by units; dependencies that are not,
+// such as builtin targets, are left to the host.
+func Closure(units []configs.File, names []string) ([]configs.File, error) {
+	set, err := parseUnits(units)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	files := make(map[string]bool)
+	var visit func(name string, explicit bool) error
+	visit = func(name string, explicit bool) error {
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+
+		file, instance := set.resolve(name)
+		if file == "" {
+			if explicit {
+				return fmt.Errorf("%s is not defined by the configuration", name)
+			}
+			return nil
+		}
+		files[file] = true
+
+		for _, entry := range set.units[file].Sections["Unit"] {
+			if !containsString(closureKeys, entry.Key) {
+				continue
+			}
+			deps, err := configs.SplitWords(entry.Value, false)
+			if err != nil {
+				return fmt.Errorf("%s: %s=: %w", file, entry.Key, err)
+			}
+			for _, dep := range deps {
+				if err := visit(expandInstance(dep, instance), false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, true); err != nil {
+			return nil, err
+		}
+	}
+
+	var out []configs.File
+	for _, f := range units {
+		if files[f.Name] {
+			out = append(out, f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Host returns the output tree of host: the units it enables or disables
+// with their dependencies, and the links enabling the units of its enable
+// list that are not also disabled.
+func Host(units []configs.File, host configs.Host) ([]configs.File, error) {
+	tree, err := Closure(units, host.Units())
+	if err != nil {
+		return nil, fmt.Errorf("host %q: %w", host.Name, err)
+	}
+
+	var enable []string
+	for _, name := range host.Enable {
+		if !containsString(host.Disable, name) {
+			enable = append(enable, name)
+		}
+	}
+	links, err := Enable(tree, enable)
+	if err != nil {
+		return nil, fmt.Errorf("host %q: %w", host.Name, err)
+	}
+	return append(tree, links...), nil
+}
+
+// unitSet indexes parsed unit files by name.
+type unitSet struct {
+	units   map[string]*configs.SystemdUnit
+	sources map[string]string
+}
+
+func parseUnits(units []configs.File) (*unitSet, error) {
+	s := &unitSet{
+		units:   make(map[string]*configs.SystemdUnit, len(units)),
+		sources: make(map[string]string, len(units)),
+	}
+	for _, f := range units {
+		if f.Link != "" {
+			continue
+		}
+		unit, diags := configs.ParseUnitFile(f.Name, []byte(f.Content))
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		s.units[f.Name] = unit
+		s.sources[f.Name] = f.Source
+	}
+	return s, nil
+}
+
+// resolve returns the file defining the unit name and its instance name,
+// if any. file is empty when name is not defined.
+func (s *unitSet) resolve(name string) (file, instance string) {
+	if _, ok := s.units[name]; ok {
+		return name, ""
+	}
+	if tmpl, id, ok := configs.SplitInstanceName(name); ok {
+		if _, ok := s.units[tmpl]; ok {
+			return tmpl, id
+		}
+	}
+	return "", ""
+}
+
+type enabler struct {
+	*unitSet
+	links map[string]configs.File
+	done  map[string]bool
+}
+
+// enable creates the links of the unit name.
+func (e *enabler) enable(name string) error {
+	if e.done[name] {
+		return nil
+	}
+	e.done[name] = true
+
+	file, instance := e.resolve(name)
+	if file == "" {
+		return fmt.Errorf("cannot enable %s: it is not defined by the configuration", name)
+	}
+	install := e.units[file].Sections["Install"]
+	if len(install) == 0 {
+		return nil
+	}
+	source := e.sources[file]
+
+	// A template is enabled through its default instance, unless an
+	// instance was asked for.
+	if isTemplate(name) {
+		instance = defaultInstance(install)
+		if instance == "" {
+			return nil
+		}
+		name = configs.InstanceUnitName(name, instance)
+	}
+	target := "/" + path.Join(UnitDir, file)
+
+	for _, entry := range install {
+		values, err := configs.SplitWords(entry.Value, false)
+		if err != nil {
+			return fmt.Errorf("%s: %s=: %w", file, entry.Key, err)
+		}
+		for _, v := range values {
+			v = expandInstance(v, instance)
+			switch entry.Key {
+			case "Alias":
+				e.link(v, target, source)
+			case "Also":
+				if f, _ := e.resolve(v); f == "" {
+					return fmt.Errorf("%s: Also=%s is not defined by the configuration", file, v)
+				}
+				if err := e.enable(v); err != nil {
+					return err
+				}
+			default:
+				for _, d := range linkDirs {
+					if entry.Key == d.key {
+						e.link(path.Join(v+d.suffix, name), target, source)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Instances returns names with each template replaced by the instance its
+// DefaultInstance= names, as systemctl enables and starts it: getty@.service
+// becomes getty@tty1.service. Templates without one are left out, since no
+// unit can be started from them. Names must be defined by units.
+func Instances(units []configs.File, names []string) ([]string, error) {
+	set, err := parseUnits(units)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, name := range names {
+		if !isTemplate(name) {
+			out = append(out, name)
+			continue
+		}
+		file, _ := set.resolve(name)
+		if file == "" {
+			return nil, fmt.Errorf("%s is not defined by the configuration", name)
+		}
+		if instance := defaultInstance(set.units[file].Sections["Install"]); instance != "" {
+			out = append(out, configs.InstanceUnitName(name, instance))
+		}
+	}
+	return out, nil
+}
+
+// defaultInstance returns the DefaultInstance= of an [Install] section.
+func defaultInstance(install []configs.Entry) string {
+	instance := ""
+	for _, entry := range install {
+		if entry.Key == "DefaultInstance" && entry.Value != "" {
+			instance = entry.Value
+		}
+	}
+	return instance
+}
+
+func (e *enabler) link(name, target, source string) {
+	e.links[name] = configs.File{Name: name, Link: target, Source: source}
+}
+
+func isTemplate(name string) bool {
+	return strings.Contains(name, "@.")
+}
+
+// expandInstance resolves the %i specifier systemctl expands in [Install].
+func expandInstance(value, instance string) string {
+	if instance == "" {
+		return value
+	}
+	return strings.ReplaceAll(value, "%i", instance)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled returns the units the dependency links of tree enable, sorted:
+// nginx.service for multi-user.target.wants/nginx.service.
+func Enabled(tree []configs.File) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, f := range tree {
+		dir, name := path.Split(f.Name)
+		if f.Link == "" || !IsLinkDir(strings.TrimSuffix(dir, "/")) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsLinkDir reports whether dir holds the links of an [Install] dependency,
+// such as multi-user.target.wants.
+func IsLinkDir(dir string) bool {
+	for _, d := range linkDirs {
+		if strings.HasSuffix(dir, d.suffix) && !strings.Contains(dir, "/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func TestEnable(t *testing.T) {
+	units := []configs.File{
+		{Name: "web.service", Source: "service.web", Content: `[Service]
+ExecStart=/usr/bin/web
+
+[Install]
+Alias=www.service
+Also=helper.service
+RequiredBy=app.target
+WantedBy=multi-user.target
+`},
+		{Name: "helper.service", Source: "service.helper", Content: `[Install]
+UpheldBy=web.service
+`},
+		{Name: "getty@.service", Source: "service.getty", Content: `[Install]
+DefaultInstance=tty1
+WantedBy=getty.target
+`},
+		{Name: "worker@.service", Source: "service.worker", Content: `[Install]
+WantedBy=workers@%i.target
+`},
+		{Name: "static.service", Source: "service.static", Content: `[Service]
+ExecStart=/bin/true
+`},
+	}
+
+	links, err := Enable(units, Names(units, []string{"worker@q1.service"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string, len(links))
+	for _, l := range links {
+		got[l.Name] = l.Link + " " + l.Source
+	}
+	want := map[string]string{
+		"app.target.requires/web.service":           "/etc/systemd/system/web.service service.web",
+		"getty.target.wants/getty@tty1.service":     "/etc/systemd/system/getty@.service service.getty",
+		"multi-user.target.wants/web.service":       "/etc/systemd/system/web.service service.web",
+		"web.service.upholds/helper.service":        "/etc/systemd/system/helper.service service.helper",
+		"workers@q1.target.wants/worker@q1.service": "/etc/systemd/system/worker@.service service.worker",
+		"www.service": "/etc/systemd/system/web.service service.web",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("links (-want +got):\n%s", diff)
+	}
+
+	if _, err := Enable(units, []string{"missing@a.service"}); err == nil {
+		t.Error("expected an error for an instance of an undefined template")
+	}
+	units[0].Content += "Also=nowhere.service\n"
+	if _, err := Enable(units, []string{"web.service"}); err == nil {
+		t.Error("expected an error for an undefined Also= unit")
+	}
+}
+
+func TestInstances(t *testing.T) {
+	units := []configs.File{
+		{Name: "web.service", Content: "[Service]\nExecStart=/usr/bin/web\n"},
+		{Name: "getty@.service", Content: "[Install]\nDefaultInstance=tty1\nWantedBy=getty.target\n"},
+		{Name: "worker@.service", Content: "[Install]\nWantedBy=multi-user.target\n"},
+	}
+
+	got, err := Instances(units, []string{"web.service", "getty@.service", "worker@.service", "worker@q1.service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// worker@.service has no DefaultInstance=, so nothing can be started
+	// from it.
+	want := []string{"web.service", "getty@tty1.service", "worker@q1.service"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("instances (-want +got):\n%s", diff)
+	}
+
+	if _, err := Instances(units, []string{"missing@.service"}); err == nil {
+		t.Error("expected an error for an undefined template")
+	}
+}
+
+func TestHost(t *testing.T) {
+	units := []configs.File{
+		{Name: "app.service", Content: "[Unit]\nRequires=db.service\nWants=cache@main.service network-online.target\n\n[Install]\nWantedBy=multi-user.target\n"},
+		{Name: "db.service", Content: "[Unit]\nWants=backup.service\n\n[Install]\nWantedBy=multi-user.target\n"},
+		{Name: "backup.service", Content: "[Service]\nExecStart=/bin/backup\n"},
+		{Name: "cache@.service", Content: "[Service]\nExecStart=/bin/cache %i\n"},
+		{Name: "unrelated.service", Content: "[Install]\nWantedBy=multi-user.target\n"},
+		{Name: "debug.service", Content: "[Install]\nWantedBy=multi-user.target\n"},
+	}
+
+	tree, err := Host(units, configs.Host{
+		Name:    "web01",
+		Enable:  []string{"app.service"},
+		Disable: []string{"debug.service"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range tree {
+		got = append(got, f.Name)
+	}
+	want := []string{
+		"app.service",
+		"backup.service",
+		"cache@.service",
+		"db.service",
+		"debug.service",
+		"multi-user.target.wants/app.service",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("tree (-want +got):\n%s", diff)
+	}
+
+	_, err = Host(units, configs.Host{Name: "web01", Enable: []string{"missing.service"}})
+	if err == nil {
+		t.Error("expected an error for an undefined unit")
+	}
+}
+
+func TestPreset(t *testing.T) {
+	host := configs.Host{
+		Name:    "web01",
+		Enable:  []string{"nginx.service", "getty@tty1.service", "sshd.service", "getty@tty2.service", "worker@.service"},
+		Disable: []string{"cups.service", "getty@tty3.service"},
+	}
+	got := Preset(host)
+	want := configs.File{
+		Name: "50-unitd-web01.preset",
+		Content: `# Presets of host "web01", generated by unitd.
+enable nginx.service
+enable getty@.service tty1 tty2
+enable sshd.service
+enable worker@.service
+disable cups.service
+disable getty@tty3.service
+`,
+		Source: "host.web01",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("preset (-want +got):\n%s", diff)
+	}
+}
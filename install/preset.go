@@ -0,0 +1,59 @@
+package install
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// PresetDir is where preset files are installed, relative to the root
+// filesystem.
+const PresetDir = "etc/systemd/system-preset"
+
+// PresetName returns the name of the preset file of the host name. The
+// first matching line of all preset files wins: 50- sorts before the 90-
+// defaults of distributions, and after the files meant to override it.
+func PresetName(host string) string {
+	return "50-unitd-" + host + ".preset"
+}
+
+// Preset returns the systemd.preset(5) file of host, for "systemctl
+// preset-all" to enable or disable its units in place of the links Host
+// creates. Instances of a template share one line:
+//
+//	enable = ["getty@tty1.service", "getty@tty2.service"]  →  enable getty@.service tty1 tty2
+func Preset(host configs.Host) configs.File {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Presets of host %q, generated by unitd.\n", host.Name)
+
+	var order []string
+	instances := make(map[string][]string)
+	for _, name := range host.Enable {
+		if containsString(host.Disable, name) {
+			continue
+		}
+		key := name
+		if tmpl, id, ok := configs.SplitInstanceName(name); ok {
+			key = tmpl
+			if !containsString(instances[tmpl], id) {
+				instances[tmpl] = append(instances[tmpl], id)
+			}
+		}
+		if !containsString(order, key) {
+			order = append(order, key)
+		}
+	}
+	for _, name := range order {
+		fmt.Fprintf(&b, "enable %s\n", strings.TrimSpace(name+" "+strings.Join(instances[name], " ")))
+	}
+	for _, name := range host.Disable {
+		fmt.Fprintf(&b, "disable %s\n", name)
+	}
+
+	return configs.File{
+		Name:    PresetName(host.Name),
+		Content: b.String(),
+		Source:  "host." + host.Name,
+	}
+}
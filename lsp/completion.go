@@ -0,0 +1,125 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+var (
+	// keyPattern matches a line holding only the start of a name.
+	keyPattern = regexp.MustCompile(`^\s*[A-Za-z_]?[\w-]*$`)
+
+	// traversalPattern matches a reference being written at the end of a line.
+	traversalPattern = regexp.MustCompile(`[A-Za-z_][\w-]*(\.[\w-]*)+$`)
+)
+
+// rootVariables are the variables available in expressions.
+var rootVariables = []struct{ name, detail string }{
+	{"builtin", "well-known systemd units"},
+	{"each", "the for_each element of the service"},
+	{"infinity", "a time span without limit"},
+	{"instance", "instances declared by instance blocks"},
+	{"reset", "an empty assignment, resetting the directive"},
+	{"self", "systemd specifiers of the unit"},
+	{"service", "services declared in this file"},
+}
+
+func (s *Server) completion(d *document, pos Position) []CompletionItem {
+	c := d.cursorAt(d.offset(pos))
+
+	switch {
+	case c.inBody() && keyPattern.MatchString(c.line):
+		return keyCompletions(c.path)
+	case traversalPattern.MatchString(c.line):
+		return referenceCompletions(d, strings.Split(traversalPattern.FindString(c.line), "."))
+	case strings.Contains(c.line, "="):
+		items := make([]CompletionItem, len(rootVariables))
+		for i, v := range rootVariables {
+			items[i] = CompletionItem{Label: v.name, Kind: kindVariable, Detail: v.detail}
+		}
+		return items
+	}
+	return []CompletionItem{}
+}
+
+// keyCompletions lists the attributes and blocks allowed in the body at path.
+func keyCompletions(path []string) []CompletionItem {
+	items := []CompletionItem{}
+	members, _ := bodyMembers(path)
+	for _, m := range members {
+		item := CompletionItem{Label: m.name, Kind: kindProperty, Detail: m.detail}
+		switch {
+		case len(path) == 0:
+			item.Kind = kindClass
+		case m.block:
+			item.Kind = kindModule
+		}
+		if m.doc != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: m.doc}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// referenceCompletions completes the last element of parts, a reference
+// being written such as ["builtin", "target", "multi"].
+func referenceCompletions(d *document, parts []string) []CompletionItem {
+	items := []CompletionItem{}
+	add := func(label string, kind CompletionItemKind, detail string) {
+		items = append(items, CompletionItem{Label: label, Kind: kind, Detail: detail})
+	}
+
+	switch len(parts) {
+	case 2:
+		switch parts[0] {
+		case "builtin":
+			seen := make(map[string]bool)
+			for _, u := range configs.DefaultKnownUnits {
+				if !seen[u.UnitType] {
+					seen[u.UnitType] = true
+					add(u.UnitType, kindModule, u.UnitType+" units")
+				}
+			}
+		case "service", "instance":
+			for _, decl := range d.declarations() {
+				if decl.typ == parts[0] {
+					add(decl.name, kindReference, parts[0]+" "+`"`+decl.name+`"`)
+				}
+			}
+		case "self":
+			for _, name := range configs.SelfAttributes() {
+				add(name, kindField, "systemd specifier")
+			}
+		case "each":
+			add("key", kindField, "the for_each key")
+			add("value", kindField, "the for_each value")
+		}
+	case 3:
+		if parts[0] == "builtin" {
+			for _, u := range configs.DefaultKnownUnits {
+				if u.UnitType == parts[1] {
+					unitType, attr, _ := configs.BuiltinAddress(u.Name)
+					if unitType == parts[1] {
+						add(attr, kindReference, u.Name)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,130 @@
+package lsp
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// cursor describes where in the document a position is.
+type cursor struct {
+	// path holds the types of the enclosing blocks, outermost first. An empty
+	// element stands for an object expression rather than a block body.
+	path []string
+
+	// line is the text of the line up to the position.
+	line string
+}
+
+// inBody reports whether the cursor is directly in a block body, where
+// attributes and blocks can be written.
+func (c cursor) inBody() bool {
+	for _, p := range c.path {
+		if p == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// cursorAt works from tokens rather than the syntax tree so that it keeps
+// working on the half-written lines completion is asked for.
+func (d *document) cursorAt(offset int) cursor {
+	var c cursor
+	var lineToks hclsyntax.Tokens
+	toks, _ := hclsyntax.LexConfig(d.text[:offset], d.filename(), hcl.InitialPos)
+	for _, tok := range toks {
+		switch tok.Type {
+		case hclsyntax.TokenNewline:
+			lineToks = nil
+			continue
+		case hclsyntax.TokenOBrace:
+			typ := ""
+			if len(lineToks) > 0 && lineToks[0].Type == hclsyntax.TokenIdent && !hasToken(lineToks, hclsyntax.TokenEqual) {
+				typ = string(lineToks[0].Bytes)
+			}
+			c.path = append(c.path, typ)
+		case hclsyntax.TokenCBrace:
+			if len(c.path) > 0 {
+				c.path = c.path[:len(c.path)-1]
+			}
+		}
+		lineToks = append(lineToks, tok)
+	}
+
+	start := bytes.LastIndexByte(d.text[:offset], '\n') + 1
+	c.line = string(d.text[start:offset])
+	return c
+}
+
+func hasToken(toks hclsyntax.Tokens, typ hclsyntax.TokenType) bool {
+	for _, tok := range toks {
+		if tok.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// declaration is a top-level labelled block: service "db" { … }.
+type declaration struct {
+	typ, name string
+	rng       hcl.Range
+}
+
+// declarations lists the top-level blocks of the document, tolerating
+// syntax errors elsewhere in the file.
+func (d *document) declarations() []declaration {
+	toks, _ := hclsyntax.LexConfig(d.text, d.filename(), hcl.InitialPos)
+
+	var decls []declaration
+	depth := 0
+	lineStart := true
+	for i, tok := range toks {
+		switch tok.Type {
+		case hclsyntax.TokenOBrace:
+			depth++
+		case hclsyntax.TokenCBrace:
+			depth--
+		}
+		if depth == 0 && lineStart && tok.Type == hclsyntax.TokenIdent && i+3 < len(toks) &&
+			toks[i+1].Type == hclsyntax.TokenOQuote &&
+			toks[i+2].Type == hclsyntax.TokenQuotedLit &&
+			toks[i+3].Type == hclsyntax.TokenCQuote {
+			decls = append(decls, declaration{
+				typ:  string(tok.Bytes),
+				name: string(toks[i+2].Bytes),
+				rng:  hcl.RangeBetween(tok.Range, toks[i+3].Range),
+			})
+		}
+		lineStart = tok.Type == hclsyntax.TokenNewline
+	}
+	return decls
+}
+
+func (d *document) lookupDeclaration(typ, name string) (declaration, bool) {
+	for _, decl := range d.declarations() {
+		if decl.typ == typ && decl.name == name {
+			return decl, true
+		}
+	}
+	return declaration{}, false
+}
+
+var identChars = regexp.MustCompile(`[A-Za-z0-9_\-.]`)
+
+// wordAt returns the dotted word around offset ("builtin.target.multi_user")
+// and its start offset. The word ends at the segment holding the offset.
+func (d *document) wordAt(offset int) (string, int) {
+	start, end := offset, offset
+	for start > 0 && identChars.Match(d.text[start-1:start]) {
+		start--
+	}
+	for end < len(d.text) && identChars.Match(d.text[end:end+1]) && d.text[end] != '.' {
+		end++
+	}
+	return strings.Trim(string(d.text[start:end]), "."), start
+}
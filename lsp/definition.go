@@ -0,0 +1,16 @@
+package lsp
+
+import "strings"
+
+func (s *Server) definition(d *document, pos Position) *Location {
+	word, _ := d.wordAt(d.offset(pos))
+	parts := strings.Split(word, ".")
+	if len(parts) < 2 || (parts[0] != "service" && parts[0] != "instance") {
+		return nil
+	}
+	decl, ok := d.lookupDeclaration(parts[0], parts[1])
+	if !ok {
+		return nil
+	}
+	return &Location{URI: d.uri, Range: d.lspRange(decl.rng)}
+}
@@ -0,0 +1,32 @@
+package lsp
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// diagnostics decodes and validates the document.
+func (s *Server) diagnostics(d *document) []Diagnostic {
+	config, diags := configs.Decode(d.filename(), d.text)
+	if !diags.HasErrors() {
+		diags = append(diags, config.Validate()...)
+	}
+
+	out := []Diagnostic{}
+	for _, diag := range diags {
+		var rng Range
+		if diag.Subject != nil {
+			rng = d.lspRange(*diag.Subject)
+		}
+		severity := severityError
+		if diag.Severity == hcl.DiagWarning {
+			severity = severityWarning
+		}
+		msg := diag.Summary
+		if diag.Detail != "" {
+			msg += ": " + diag.Detail
+		}
+		out = append(out, Diagnostic{Range: rng, Severity: severity, Source: "unitd", Message: msg})
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package lsp
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// document is an open text document.
+type document struct {
+	uri  string
+	text []byte
+}
+
+// filename returns the path of a file:// URI, or the URI itself.
+func (d *document) filename() string {
+	u, err := url.Parse(d.uri)
+	if err != nil || u.Scheme != "file" {
+		return d.uri
+	}
+	return u.Path
+}
+
+// parse parses the document. The body is returned even when it has syntax
+// errors, holding what could be recovered.
+func (d *document) parse() *hclsyntax.Body {
+	file, _ := hclsyntax.ParseConfig(d.text, d.filename(), hcl.InitialPos)
+	if file == nil {
+		return &hclsyntax.Body{}
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return &hclsyntax.Body{}
+	}
+	return body
+}
+
+// offset converts an LSP position, counted in UTF-16 code units, to a byte
+// offset into the document.
+func (d *document) offset(pos Position) int {
+	off := 0
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(string(d.text[off:]), '\n')
+		if i < 0 {
+			return len(d.text)
+		}
+		off += i + 1
+	}
+
+	units := 0
+	for off < len(d.text) && d.text[off] != '\n' && units < pos.Character {
+		r, size := utf8.DecodeRune(d.text[off:])
+		units += utf16.RuneLen(r)
+		off += size
+	}
+	return off
+}
+
+// position converts a byte offset into an LSP position.
+func (d *document) position(offset int) Position {
+	offset = min(max(offset, 0), len(d.text))
+	var pos Position
+	lineStart := 0
+	for i := 0; i < offset; i++ {
+		if d.text[i] == '\n' {
+			pos.Line++
+			lineStart = i + 1
+		}
+	}
+	for _, r := range string(d.text[lineStart:offset]) {
+		pos.Character += utf16.RuneLen(r)
+	}
+	return pos
+}
+
+// lspRange converts an HCL source range into an LSP range.
+func (d *document) lspRange(r hcl.Range) Range {
+	return Range{Start: d.position(r.Start.Byte), End: d.position(r.End.Byte)}
+}
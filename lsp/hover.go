@@ -0,0 +1,70 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// keySuffix matches what follows the name of an attribute or block.
+var keySuffix = regexp.MustCompile(`^\s*(=|\{|")`)
+
+func (s *Server) hover(d *document, pos Position) *Hover {
+	offset := d.offset(pos)
+	word, start := d.wordAt(offset)
+	if word == "" {
+		return nil
+	}
+	rng := Range{Start: d.position(start), End: d.position(start + len(word))}
+
+	parts := strings.Split(word, ".")
+	if len(parts) > 1 {
+		text, ok := referenceDoc(d, parts)
+		if !ok {
+			return nil
+		}
+		return &Hover{Contents: MarkupContent{Kind: "markdown", Value: text}, Range: &rng}
+	}
+
+	c := d.cursorAt(start)
+	if strings.TrimSpace(c.line) != "" || !keySuffix.Match(d.text[start+len(word):]) || !c.inBody() {
+		return nil
+	}
+
+	m, ok := lookupMember(c.path, word)
+	if !ok || m.doc == "" {
+		return nil
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: m.doc}, Range: &rng}
+}
+
+// referenceDoc describes what a reference resolves to.
+func referenceDoc(d *document, parts []string) (string, bool) {
+	switch parts[0] {
+	case "builtin":
+		if len(parts) != 3 {
+			return "", false
+		}
+		for _, u := range configs.DefaultKnownUnits {
+			unitType, attr, _ := configs.BuiltinAddress(u.Name)
+			if unitType == parts[1] && attr == parts[2] {
+				return fmt.Sprintf("`%s`\n\nWell-known %s unit (%s).", u.Name, u.UnitType, u.Source), true
+			}
+		}
+
+	case "service", "instance":
+		decl, ok := d.lookupDeclaration(parts[0], parts[1])
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%s \"%s\", declared on line %d.", decl.typ, decl.name, decl.rng.Start.Line), true
+
+	case "self":
+		if c, ok := configs.SelfSpecifier(parts[1]); ok {
+			return fmt.Sprintf("Rendered as the systemd specifier `%%%c`.", c), true
+		}
+	}
+	return "", false
+}
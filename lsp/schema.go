@@ -0,0 +1,122 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// member is an attribute or nested block allowed in a body.
+type member struct {
+	name   string
+	block  bool
+	detail string // ExecStart= or [Service]
+	doc    string // markdown
+}
+
+// rootBlocks are the block types allowed at the top level of a file, with
+// the members of their bodies besides the sections of the unit type.
+var rootBlocks = map[string]struct {
+	doc     string
+	members []member
+}{
+	"service": {
+		doc: "Declares a systemd service unit.",
+		members: []member{
+			{name: "template", doc: "Renders a template unit, `name@.service`, to be instantiated by instance blocks."},
+			{name: "for_each", doc: "Renders one unit per element of the map, named `name-key.service`. Use `each.key` and `each.value` in the body."},
+			{name: "restart_policy", doc: "What `unitd apply` does to the running unit when its file changes: `restart` (the default), `reload` or `none`."},
+		},
+	},
+	"instance": {
+		doc: "Declares instances of a template service.",
+		members: []member{
+			{name: "template", doc: "The template service, such as `service.worker`."},
+			{name: "instances", doc: "The instance names, rendered as `template@name.service`."},
+		},
+	},
+	"host": {
+		doc: "Declares a machine and the units applied to it.",
+		members: []member{
+			{name: "enable", doc: "Units installed and enabled on the host, with their `Requires=` and `Wants=` dependencies."},
+			{name: "disable", doc: "Units installed on the host but not enabled."},
+			{name: "package", block: true, doc: "The Debian package `unitd package` builds for the host: `name`, `version`, `depends`, `maintainer`, `description` and `architecture`."},
+			{name: "ssh", block: true, doc: "How `unitd apply` reaches the host: `host`, `user`, `port`, `identity_file` and `known_hosts`, or the `name` of an `~/.ssh/config` entry."},
+		},
+	},
+}
+
+// bodyMembers returns what may be written in the body at path, the types of
+// the enclosing blocks: ["service", "unit"] lists the [Unit] directives.
+func bodyMembers(path []string) ([]member, bool) {
+	if len(path) == 0 {
+		var out []member
+		for _, name := range sortedKeys(rootBlocks) {
+			out = append(out, member{name: name, block: true, doc: rootBlocks[name].doc})
+		}
+		return out, true
+	}
+
+	root, ok := rootBlocks[path[0]]
+	if !ok {
+		return nil, false
+	}
+	sections := configs.UnitSections(path[0])
+
+	switch len(path) {
+	case 1:
+		out := append([]member(nil), root.members...)
+		for _, s := range sections {
+			out = append(out, sectionMember(s))
+		}
+		return out, true
+
+	case 2:
+		for _, s := range sections {
+			if s.Name != path[1] {
+				continue
+			}
+			var out []member
+			for _, d := range configs.SectionDirectives(s.Name) {
+				out = append(out, directiveMember(d))
+			}
+			return out, true
+		}
+	}
+	return nil, false
+}
+
+func lookupMember(path []string, name string) (member, bool) {
+	members, _ := bodyMembers(path)
+	for _, m := range members {
+		if m.name == name {
+			return m, true
+		}
+	}
+	return member{}, false
+}
+
+func sectionMember(s configs.Section) member {
+	return member{
+		name:   s.Name,
+		block:  true,
+		detail: s.Systemd,
+		doc:    docMarkdown(s.Systemd, s.ManPage, s.Doc),
+	}
+}
+
+func directiveMember(d configs.Directive) member {
+	doc := d.Doc
+	if len(d.Values) > 0 {
+		doc += "\n\nOne of: `" + strings.Join(d.Values, "`, `") + "`."
+	}
+	return member{
+		name:   d.Name,
+		detail: d.Systemd + "=",
+		doc:    docMarkdown(d.Systemd+"=", d.ManPage, doc),
+	}
+}
+
+func docMarkdown(title, manPage, text string) string {
+	return strings.TrimSpace("**" + title + "** · " + manPage + "\n\n" + text)
+}
@@ -0,0 +1,164 @@
+// Package lsp implements a Language Server Protocol server for unitd
+// configurations, spoken over a pair of streams such as stdin and stdout.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Server is a language server. Requests are handled one at a time, in the
+// order they are received.
+type Server struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	docs     map[string]*document
+	shutdown bool
+}
+
+// NewServer returns a server reading requests from in and writing responses
+// and notifications to out.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:   bufio.NewReader(in),
+		out:  out,
+		docs: make(map[string]*document),
+	}
+}
+
+// errExit is returned by handle when the client asks the server to exit.
+var errExit = errors.New("exit")
+
+// Run serves requests until the client sends "exit" or closes the input.
+func (s *Server) Run() error {
+	for {
+		msg, err := readMessage(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err := s.handle(msg)
+		if err == errExit {
+			if !s.shutdown {
+				return fmt.Errorf("exit without shutdown")
+			}
+			return nil
+		}
+		if msg.ID == nil {
+			continue // notifications get no response
+		}
+
+		resp := &message{ID: msg.ID}
+		var rerr *responseError
+		switch {
+		case errors.As(err, &rerr):
+			resp.Error = rerr
+		case err != nil:
+			resp.Error = &responseError{Code: codeInvalidParams, Message: err.Error()}
+		default:
+			resp.Result, err = json.Marshal(result)
+			if err != nil {
+				return err
+			}
+		}
+		if err := writeMessage(s.out, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+func (s *Server) handle(msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var result InitializeResult
+		result.ServerInfo.Name = "unitd"
+		result.Capabilities = ServerCapabilities{
+			TextDocumentSync:   syncFull,
+			CompletionProvider: &CompletionOptions{TriggerCharacters: []string{"."}},
+			HoverProvider:      true,
+			DefinitionProvider: true,
+		}
+		return result, nil
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "exit":
+		return nil, errExit
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d := &document{uri: params.TextDocument.URI, text: []byte(params.TextDocument.Text)}
+		s.docs[d.uri] = d
+		return nil, s.publishDiagnostics(d)
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d, ok := s.docs[params.TextDocument.URI]
+		if !ok || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		d.text = []byte(params.ContentChanges[len(params.ContentChanges)-1].Text)
+		return nil, s.publishDiagnostics(d)
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/completion", "textDocument/hover", "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return nil, &responseError{Code: codeInvalidParams, Message: "document is not open: " + params.TextDocument.URI}
+		}
+		switch msg.Method {
+		case "textDocument/completion":
+			return s.completion(d, params.Position), nil
+		case "textDocument/hover":
+			return s.hover(d, params.Position), nil
+		default:
+			return s.definition(d, params.Position), nil
+		}
+	}
+
+	if msg.ID == nil {
+		return nil, nil // unknown notifications are ignored
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func (s *Server) publishDiagnostics(d *document) error {
+	params, err := json.Marshal(PublishDiagnosticsParams{URI: d.uri, Diagnostics: s.diagnostics(d)})
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{Method: "textDocument/publishDiagnostics", Params: params})
+}
@@ -0,0 +1,253 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testURI = "file:///etc/unitd/services.hcl"
+
+const testSource = `service "db" {
+  unit {
+    description = "Database"
+  }
+
+  service {
+    exec_start = ["/usr/bin/db"]
+  }
+
+  install {
+    wanted_by = [builtin.target.multi_user]
+  }
+}
+
+service "web" {
+  unit {
+    after = [service.db, builtin.target.]
+  }
+  service {
+    
+  }
+  install {}
+}
+`
+
+// client drives a Server running in-process over pipes.
+type client struct {
+	t      *testing.T
+	in     io.Writer
+	out    *bufio.Reader
+	nextID int
+}
+
+func newClient(t *testing.T) *client {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewServer(inR, outW).Run()
+		outW.Close()
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		if err := <-done; err != nil {
+			t.Errorf("server: %s", err)
+		}
+	})
+
+	return &client{t: t, in: inW, out: bufio.NewReader(outR)}
+}
+
+func (c *client) send(method string, params any, id *int) {
+	c.t.Helper()
+	raw, err := json.Marshal(params)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	msg := &message{Method: method, Params: raw}
+	if id != nil {
+		rawID := json.RawMessage(strings.TrimSpace(string(mustJSON(c.t, *id))))
+		msg.ID = &rawID
+	}
+	if err := writeMessage(c.in, msg); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// call sends a request and decodes the result of its response into result.
+func (c *client) call(method string, params, result any) {
+	c.t.Helper()
+	c.nextID++
+	id := c.nextID
+	c.send(method, params, &id)
+
+	msg := c.read()
+	if msg.Error != nil {
+		c.t.Fatalf("%s: %s", method, msg.Error.Message)
+	}
+	if err := json.Unmarshal(msg.Result, result); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *client) read() *message {
+	c.t.Helper()
+	msg, err := readMessage(c.out)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return msg
+}
+
+func (c *client) open(text string) []Diagnostic {
+	c.t.Helper()
+	c.send("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: testURI, Text: text},
+	}, nil)
+
+	msg := c.read()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("expected diagnostics, got %+v", msg)
+	}
+	var params PublishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		c.t.Fatal(err)
+	}
+	return params.Diagnostics
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func at(line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: testURI},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func labels(items []CompletionItem) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.Label
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestServer(t *testing.T) {
+	c := newClient(t)
+
+	var init InitializeResult
+	c.call("initialize", map[string]any{}, &init)
+	if !init.Capabilities.HoverProvider || init.Capabilities.CompletionProvider == nil {
+		t.Fatalf("capabilities: %+v", init.Capabilities)
+	}
+	c.send("initialized", map[string]any{}, nil)
+
+	diags := c.open(testSource)
+	if len(diags) == 0 || diags[0].Range.Start.Line != 16 {
+		t.Errorf("expected a syntax error on line 17, got %+v", diags)
+	}
+
+	t.Run("builtin completion", func(t *testing.T) {
+		var items []CompletionItem
+		c.call("textDocument/completion", at(16, 40), &items)
+		if got := labels(items); !contains(got, "multi_user") || !contains(got, "network_online") {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("service completion", func(t *testing.T) {
+		var items []CompletionItem
+		c.call("textDocument/completion", at(16, 21), &items)
+		if got := labels(items); !contains(got, "db") || !contains(got, "web") {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("attribute completion", func(t *testing.T) {
+		var items []CompletionItem
+		c.call("textDocument/completion", at(19, 4), &items)
+		var execStart *CompletionItem
+		for i := range items {
+			if items[i].Label == "exec_start" {
+				execStart = &items[i]
+			}
+		}
+		if execStart == nil || execStart.Detail != "ExecStart=" || execStart.Documentation == nil {
+			t.Fatalf("exec_start missing or undocumented: %+v", execStart)
+		}
+	})
+
+	t.Run("block completion", func(t *testing.T) {
+		var items []CompletionItem
+		c.call("textDocument/completion", at(14, 0), &items)
+		if got := labels(items); !contains(got, "service") || !contains(got, "instance") {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		var hover Hover
+		c.call("textDocument/hover", at(6, 6), &hover)
+		if !strings.Contains(hover.Contents.Value, "**ExecStart=** · systemd.service(5)") {
+			t.Errorf("got %q", hover.Contents.Value)
+		}
+
+		c.call("textDocument/hover", at(10, 34), &hover)
+		if !strings.Contains(hover.Contents.Value, "multi-user.target") {
+			t.Errorf("got %q", hover.Contents.Value)
+		}
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		var loc Location
+		c.call("textDocument/definition", at(16, 22), &loc)
+		if loc.URI != testURI || loc.Range.Start.Line != 0 {
+			t.Errorf("got %+v", loc)
+		}
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		text := strings.Replace(testSource, "builtin.target.]", "builtin.target.network]", 1)
+		text = strings.Replace(text, `exec_start = ["/usr/bin/db"]`, `exec_stat = ["/usr/bin/db"]`, 1)
+		c.send("textDocument/didChange", map[string]any{
+			"textDocument":   TextDocumentIdentifier{URI: testURI},
+			"contentChanges": []map[string]string{{"text": text}},
+		}, nil)
+
+		msg := c.read()
+		var params PublishDiagnosticsParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+		if len(params.Diagnostics) != 1 || params.Diagnostics[0].Range.Start.Line != 6 ||
+			!strings.Contains(params.Diagnostics[0].Message, `Did you mean "exec_start"?`) {
+			t.Errorf("got %+v", params.Diagnostics)
+		}
+	})
+
+	var none any
+	c.call("shutdown", nil, &none)
+	c.send("exit", nil, nil)
+}
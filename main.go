@@ -1,46 +1,29 @@
 package main
 
 import (
-	"fmt"
-	"log"
 	"os"
-	"path/filepath"
 
-	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/command"
 )
 
 func main() {
-	if len(os.Args) < 3 {
-		fmt.Fprintf(os.Stderr, "usage: unitd <src.hcl> <outdir>\n")
-		os.Exit(1)
-	}
-
-	srcFile := os.Args[1]
-	outDir := os.Args[2]
-
-	_ = os.MkdirAll(outDir, 0o755)
-
-	config, err := configs.DecodeFile(srcFile)
-	if err != nil {
-		log.Fatalf("Failed to load configuration: %s", err)
-	}
+	meta := command.DefaultMeta()
+	commands := command.Commands(meta)
 
-	if err := config.Validate(); err != nil {
-		log.Fatal(err)
+	args := os.Args[1:]
+	if len(args) == 0 {
+		command.Usage(meta.Stderr, commands)
+		os.Exit(1)
 	}
 
-	for _, svc := range config.Services {
-		content, err := svc.Encode()
-		if err != nil {
-			log.Fatalf("Failed to encode %s: %s", svc.Name, err)
-		}
-
-		for _, filename := range svc.UnitFilenames() {
-			path := filepath.Join(outDir, filename)
-			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
-				log.Fatalf("Failed to write %s: %s", path, err)
-			}
-			fmt.Println("wrote", path)
+	cmd, ok := commands[args[0]]
+	if !ok {
+		// "unitd <src.hcl> <outdir>" predates subcommands.
+		if len(args) == 2 {
+			os.Exit(commands["build"].Run(args))
 		}
+		command.Usage(meta.Stderr, commands)
+		os.Exit(1)
 	}
+	os.Exit(cmd.Run(args[1:]))
 }
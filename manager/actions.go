@@ -0,0 +1,255 @@
+package manager
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/install"
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+// Actions returns what the service manager of a host must do once the plan
+// is applied to its unit directory, whose new content is tree. policies
+// maps unit files to their restart policy; instances follow their
+// template, and units without one are restarted.
+//
+// systemd is reloaded first. Units no longer enabled or whose file is gone
+// are stopped, in the reverse of their After= order. Newly enabled units
+// are then enabled and started, and units whose file, template or drop-in
+// changed are restarted or reloaded, dependencies first.
+func Actions(p *output.Plan, tree []configs.File, policies map[string]string) []Action {
+	if p.Empty() {
+		return nil
+	}
+
+	// Units enabled before the plan are those of the links it keeps or
+	// deletes; after it, those of the links of tree.
+	created := make(map[string]bool)
+	for _, c := range p.Changes {
+		if c.Action == output.Create {
+			created[c.Name] = true
+		}
+	}
+	wasEnabled, isEnabled := make(map[string]bool), make(map[string]bool)
+	for _, f := range tree {
+		if f.Link != "" && !created[f.Name] && isDependencyLink(f.Name) {
+			wasEnabled[path.Base(f.Name)] = true
+		}
+	}
+	for _, name := range install.Enabled(tree) {
+		isEnabled[name] = true
+	}
+
+	stopped, changed := make(map[string]bool), make(map[string]bool)
+	var templates []string
+	for _, c := range p.Changes {
+		dir, name := path.Split(c.Name)
+		dir = strings.TrimSuffix(dir, "/")
+		switch {
+		case c.Symlink && isDependencyLink(c.Name):
+			if c.Action == output.Delete {
+				wasEnabled[name] = true
+			}
+		case dir == "" && !c.Symlink:
+			switch {
+			case c.Action == output.Delete:
+				stopped[name] = true
+			case c.Action == output.Update && isTemplate(name):
+				templates = append(templates, name)
+			case c.Action == output.Update:
+				changed[name] = true
+			}
+		case strings.HasSuffix(dir, ".d") && !strings.Contains(dir, "/"):
+			unit := strings.TrimSuffix(dir, ".d")
+			if isTemplate(unit) {
+				templates = append(templates, unit)
+			} else {
+				changed[unit] = true
+			}
+		}
+	}
+	for name := range wasEnabled {
+		if !isEnabled[name] {
+			stopped[name] = true
+		}
+	}
+	for name := range isEnabled {
+		if tmpl, _, ok := configs.SplitInstanceName(name); ok && containsString(templates, tmpl) {
+			changed[name] = true
+		}
+	}
+
+	var started []string
+	for name := range isEnabled {
+		if !wasEnabled[name] && !stopped[name] {
+			started = append(started, name)
+		}
+	}
+	sort.Strings(started)
+
+	verbs := make(map[string]Verb)
+	for _, name := range started {
+		verbs[name] = Start
+	}
+	for name := range changed {
+		if stopped[name] || verbs[name] == Start {
+			continue
+		}
+		switch Policy(name, policies) {
+		case configs.RestartPolicyReload:
+			verbs[name] = Reload
+		case configs.RestartPolicyNone:
+		default:
+			verbs[name] = Restart
+		}
+	}
+
+	order := newOrdering(p, tree)
+	actions := []Action{{Verb: DaemonReload}}
+	stops := order.sort(stopped)
+	for i := len(stops) - 1; i >= 0; i-- {
+		actions = append(actions, Action{Verb: Stop, Unit: stops[i]})
+	}
+	for _, name := range started {
+		actions = append(actions, Action{Verb: Enable, Unit: name})
+	}
+	running := make(map[string]bool, len(verbs))
+	for name := range verbs {
+		running[name] = true
+	}
+	for _, name := range order.sort(running) {
+		actions = append(actions, Action{Verb: verbs[name], Unit: name})
+	}
+	return actions
+}
+
+// Policy returns the restart policy of the unit name in policies, as
+// returned by configs.Config.RestartPolicies: its own, that of its template,
+// or restart.
+func Policy(name string, policies map[string]string) string {
+	if policy, ok := policies[name]; ok {
+		return policy
+	}
+	if tmpl, _, ok := configs.SplitInstanceName(name); ok {
+		if policy, ok := policies[tmpl]; ok {
+			return policy
+		}
+	}
+	return configs.RestartPolicyRestart
+}
+
+// ordering holds the After= and Before= dependencies of the units of a
+// tree and of the unit files a plan deletes.
+type ordering struct {
+	after map[string]map[string]bool // unit → units it starts after
+}
+
+func newOrdering(p *output.Plan, tree []configs.File) *ordering {
+	o := &ordering{after: make(map[string]map[string]bool)}
+	add := func(name, content string) {
+		if strings.Contains(name, "/") {
+			return
+		}
+		unit, diags := configs.ParseUnitFile(name, []byte(content))
+		if diags.HasErrors() {
+			return
+		}
+		for _, e := range unit.Sections["Unit"] {
+			for _, other := range strings.Fields(e.Value) {
+				switch e.Key {
+				case "After":
+					o.edge(name, other)
+				case "Before":
+					o.edge(other, name)
+				}
+			}
+		}
+	}
+	for _, f := range tree {
+		if f.Link == "" {
+			add(f.Name, f.Content)
+		}
+	}
+	for _, c := range p.Changes {
+		if c.Action == output.Delete && !c.Symlink {
+			add(c.Name, c.Old)
+		}
+	}
+	return o
+}
+
+func (o *ordering) edge(unit, after string) {
+	if o.after[unit] == nil {
+		o.after[unit] = make(map[string]bool)
+	}
+	o.after[unit][after] = true
+}
+
+// afterOf returns the units name starts after. Instances have the
+// dependencies of their template.
+func (o *ordering) afterOf(name string) map[string]bool {
+	if deps, ok := o.after[name]; ok {
+		return deps
+	}
+	if tmpl, _, ok := configs.SplitInstanceName(name); ok {
+		return o.after[tmpl]
+	}
+	return nil
+}
+
+// sort returns the units of set so that each comes after the units it is
+// ordered After=, then by name. Cycles are broken by name.
+func (o *ordering) sort(set map[string]bool) []string {
+	remaining := make([]string, 0, len(set))
+	for name := range set {
+		remaining = append(remaining, name)
+	}
+	sort.Strings(remaining)
+
+	done := make(map[string]bool, len(set))
+	var out []string
+	for len(remaining) > 0 {
+		next := 0
+		for i, name := range remaining {
+			if o.ready(name, set, done) {
+				next = i
+				break
+			}
+		}
+		done[remaining[next]] = true
+		out = append(out, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return out
+}
+
+func (o *ordering) ready(name string, set, done map[string]bool) bool {
+	for dep := range o.afterOf(name) {
+		if set[dep] && !done[dep] && dep != name {
+			return false
+		}
+	}
+	return true
+}
+
+// isDependencyLink reports whether name is a link enabling a unit, such as
+// multi-user.target.wants/nginx.service.
+func isDependencyLink(name string) bool {
+	dir, _ := path.Split(name)
+	return dir != "" && install.IsLinkDir(strings.TrimSuffix(dir, "/"))
+}
+
+func isTemplate(name string) bool {
+	return strings.Contains(name, "@.")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package manager
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+func unit(name, content string) configs.File {
+	return configs.File{Name: name, Content: content}
+}
+
+func wants(name string) configs.File {
+	return configs.File{Name: "multi-user.target.wants/" + name, Link: "/etc/systemd/system/" + name}
+}
+
+// plan applies before to a directory and plans after against it.
+func plan(t *testing.T, before, after []configs.File) *output.Plan {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "system")
+	p, err := output.NewPlan(dir, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply(output.DefaultOptions, nil); err != nil {
+		t.Fatal(err)
+	}
+	p, err = output.NewPlan(dir, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestActions(t *testing.T) {
+	before := []configs.File{
+		unit("db.service", "[Service]\nExecStart=/bin/db\n"),
+		unit("web.service", "[Unit]\nAfter=db.service\n[Service]\nExecStart=/bin/web\n"),
+		unit("proxy.service", "[Unit]\nBefore=web.service\n[Service]\nExecStart=/bin/proxy\n"),
+		unit("old.service", "[Unit]\nAfter=web.service\n[Service]\nExecStart=/bin/old\n"),
+		unit("worker@.service", "[Service]\nExecStart=/bin/worker %i\n"),
+		unit("static.service", "[Service]\nExecStart=/bin/static\n"),
+		wants("db.service"),
+		wants("web.service"),
+		wants("proxy.service"),
+		wants("old.service"),
+		wants("worker@1.service"),
+	}
+	after := []configs.File{
+		unit("db.service", "[Service]\nExecStart=/bin/db -v\n"),
+		unit("web.service", "[Unit]\nAfter=db.service\n[Service]\nExecStart=/bin/web -v\n"),
+		unit("proxy.service", "[Unit]\nBefore=web.service\n[Service]\nExecStart=/bin/proxy -v\n"),
+		unit("worker@.service", "[Service]\nExecStart=/bin/worker -v %i\n"),
+		unit("static.service", "[Service]\nExecStart=/bin/static -v\n"),
+		unit("new.service", "[Unit]\nAfter=web.service\n[Service]\nExecStart=/bin/new\n"),
+		wants("db.service"),
+		wants("web.service"),
+		wants("proxy.service"),
+		wants("worker@1.service"),
+		wants("new.service"),
+	}
+	policies := map[string]string{
+		"proxy.service":   configs.RestartPolicyReload,
+		"static.service":  configs.RestartPolicyNone,
+		"worker@.service": configs.RestartPolicyRestart,
+	}
+
+	got := Actions(plan(t, before, after), after, policies)
+	want := []Action{
+		{Verb: DaemonReload},
+		{Verb: Stop, Unit: "old.service"},
+		{Verb: Enable, Unit: "new.service"},
+		{Verb: Restart, Unit: "db.service"},
+		{Verb: Reload, Unit: "proxy.service"},
+		{Verb: Restart, Unit: "web.service"},
+		{Verb: Start, Unit: "new.service"},
+		{Verb: Restart, Unit: "worker@1.service"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("actions (-want +got):\n%s", diff)
+	}
+
+	m := &Fake{}
+	if err := Run(m, got, nil); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, m.Actions); diff != "" {
+		t.Errorf("performed actions (-want +got):\n%s", diff)
+	}
+}
+
+func TestActionsStopOrder(t *testing.T) {
+	before := []configs.File{
+		unit("db.service", "[Service]\nExecStart=/bin/db\n"),
+		unit("web.service", "[Unit]\nAfter=db.service\n[Service]\nExecStart=/bin/web\n"),
+		wants("db.service"),
+		wants("web.service"),
+	}
+	got := Actions(plan(t, before, nil), nil, nil)
+	want := []Action{
+		{Verb: DaemonReload},
+		{Verb: Stop, Unit: "web.service"},
+		{Verb: Stop, Unit: "db.service"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("actions (-want +got):\n%s", diff)
+	}
+}
+
+func TestActionsNoChanges(t *testing.T) {
+	files := []configs.File{unit("db.service", "[Service]\nExecStart=/bin/db\n"), wants("db.service")}
+	if got := Actions(plan(t, files, files), files, nil); len(got) != 0 {
+		t.Errorf("actions for an empty plan: %v", got)
+	}
+}
+
+func TestRunStopsAtFailure(t *testing.T) {
+	m := &Fake{Fail: map[string]error{"restart web.service": errors.New("failed")}}
+	err := Run(m, []Action{
+		{Verb: DaemonReload},
+		{Verb: Restart, Unit: "web.service"},
+		{Verb: Restart, Unit: "worker.service"},
+	}, nil)
+	if err == nil || err.Error() != "restart web.service: failed" {
+		t.Errorf("err = %v", err)
+	}
+	if diff := cmp.Diff([]Action{{Verb: DaemonReload}}, m.Actions); diff != "" {
+		t.Errorf("performed actions (-want +got):\n%s", diff)
+	}
+}
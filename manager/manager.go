@@ -0,0 +1,122 @@
+// Package manager tells the service manager of a host about the unit files
+// unitd apply changed: it reloads systemd, enables and starts new units,
+// restarts or reloads changed ones and stops removed ones.
+package manager
+
+import (
+	"fmt"
+
+	"github.com/vanviethieuanh/unitd/transport"
+)
+
+// Manager controls the units of one host.
+type Manager interface {
+	DaemonReload() error
+	Enable(unit string) error
+	Start(unit string) error
+	Stop(unit string) error
+
+	// Restart and Reload leave units that are not running alone.
+	Restart(unit string) error
+	Reload(unit string) error
+}
+
+// Verb is what an action does.
+type Verb string
+
+const (
+	DaemonReload Verb = "daemon-reload"
+	Enable       Verb = "enable"
+	Start        Verb = "start"
+	Stop         Verb = "stop"
+	Restart      Verb = "restart"
+	Reload       Verb = "reload"
+)
+
+// Action is one call to a Manager. Unit is empty for DaemonReload.
+type Action struct {
+	Verb Verb
+	Unit string
+}
+
+func (a Action) String() string {
+	if a.Unit == "" {
+		return string(a.Verb)
+	}
+	return string(a.Verb) + " " + a.Unit
+}
+
+// Run performs the actions in order, stopping at the first failure.
+func Run(m Manager, actions []Action, progress func(Action)) error {
+	for _, a := range actions {
+		var err error
+		switch a.Verb {
+		case DaemonReload:
+			err = m.DaemonReload()
+		case Enable:
+			err = m.Enable(a.Unit)
+		case Start:
+			err = m.Start(a.Unit)
+		case Stop:
+			err = m.Stop(a.Unit)
+		case Restart:
+			err = m.Restart(a.Unit)
+		case Reload:
+			err = m.Reload(a.Unit)
+		default:
+			err = fmt.Errorf("unknown action %q", a.Verb)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", a, err)
+		}
+		if progress != nil {
+			progress(a)
+		}
+	}
+	return nil
+}
+
+// Systemctl is the systemd of a host, driven with systemctl over its
+// transport.
+type Systemctl struct {
+	Transport transport.Transport
+}
+
+func (s *Systemctl) DaemonReload() error       { return s.run("daemon-reload") }
+func (s *Systemctl) Enable(unit string) error  { return s.run("enable", unit) }
+func (s *Systemctl) Start(unit string) error   { return s.run("start", unit) }
+func (s *Systemctl) Stop(unit string) error    { return s.run("stop", unit) }
+func (s *Systemctl) Restart(unit string) error { return s.run("try-restart", unit) }
+
+// Reload reloads the unit, or restarts it when it cannot reload.
+func (s *Systemctl) Reload(unit string) error {
+	return s.run("try-reload-or-restart", unit)
+}
+
+func (s *Systemctl) run(args ...string) error {
+	_, err := s.Transport.Run("systemctl", append([]string{"--no-ask-password"}, args...)...)
+	return err
+}
+
+// Fake records the actions it is asked to perform, for tests. Fail makes
+// the action of the same string fail.
+type Fake struct {
+	Actions []Action
+	Fail    map[string]error
+}
+
+func (f *Fake) DaemonReload() error       { return f.do(DaemonReload, "") }
+func (f *Fake) Enable(unit string) error  { return f.do(Enable, unit) }
+func (f *Fake) Start(unit string) error   { return f.do(Start, unit) }
+func (f *Fake) Stop(unit string) error    { return f.do(Stop, unit) }
+func (f *Fake) Restart(unit string) error { return f.do(Restart, unit) }
+func (f *Fake) Reload(unit string) error  { return f.do(Reload, unit) }
+
+func (f *Fake) do(verb Verb, unit string) error {
+	a := Action{Verb: verb, Unit: unit}
+	if err := f.Fail[a.String()]; err != nil {
+		return err
+	}
+	f.Actions = append(f.Actions, a)
+	return nil
+}
@@ -0,0 +1,338 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options control the files Apply writes. Start from DefaultOptions: a
+// zero UID or GID stands for root.
+type Options struct {
+	Mode os.FileMode // permission bits of the unit files
+	UID  int         // owner of the written files, -1 to leave it unset
+	GID  int         // group of the written files, -1 to leave it unset
+}
+
+// DefaultOptions writes world-readable files owned by the current user.
+var DefaultOptions = Options{Mode: 0o644, UID: -1, GID: -1}
+
+// Apply brings the output directory in line with the plan, then calls
+// progress, if not nil, for each change.
+//
+// The new tree is staged in a sibling directory, synced to disk and swapped
+// in at once, so that a failure or a crash leaves either the old or the new
+// tree in place, never a mix. Files that do not change are hard linked into
+// the staged tree and keep their inode. When the output directory is a
+// symlink, the link is flipped to the staged tree.
+func (p *Plan) Apply(opts Options, progress func(Change)) error {
+	changed := make(map[string]bool, len(p.Changes))
+	for _, c := range p.Changes {
+		changed[c.Name] = true
+	}
+
+	// Metadata of unchanged files is fixed in place: their content is
+	// already what it should be.
+	for _, e := range p.Manifest.Files {
+		if changed[e.File] {
+			continue
+		}
+		if err := setMetadata(filepath.Join(p.Dir, e.File), opts.Mode, opts); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := p.Manifest.Marshal()
+	if err != nil {
+		return err
+	}
+	if current, _, err := readFile(filepath.Join(p.Dir, ManifestName)); err == nil && p.Empty() && current == string(manifest) {
+		return nil
+	}
+
+	dir := filepath.Clean(p.Dir)
+	parent, base := filepath.Dir(dir), filepath.Base(dir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return err
+	}
+	removeStale(parent, base)
+
+	stage, err := os.MkdirTemp(parent, StagePrefix(base))
+	if err != nil {
+		return err
+	}
+	swapped := false
+	defer func() {
+		if !swapped {
+			os.RemoveAll(stage)
+		}
+	}()
+
+	if err := p.stage(stage, changed, manifest, opts); err != nil {
+		return err
+	}
+	if err := swap(dir, stage); err != nil {
+		return err
+	}
+	swapped = true
+	if err := syncDir(parent); err != nil {
+		return err
+	}
+
+	if progress != nil {
+		for _, c := range p.Changes {
+			progress(c)
+		}
+	}
+	return nil
+}
+
+// stage fills the directory stage with the tree the plan produces.
+func (p *Plan) stage(stage string, changed map[string]bool, manifest []byte, opts Options) error {
+	fi, err := os.Stat(p.Dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if fi == nil {
+		if err := os.Chmod(stage, 0o755); err != nil {
+			return err
+		}
+	} else {
+		if err := os.Chmod(stage, fi.Mode().Perm()); err != nil {
+			return err
+		}
+		if err := keepOwner(stage, fi); err != nil {
+			return err
+		}
+
+		// Everything else in the directory, owned or not, is carried
+		// over with its owner.
+		skip := func(rel string) bool { return rel == ManifestName || changed[rel] }
+		if err := mirror(p.Dir, stage, skip); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range p.Changes {
+		if c.Action == Delete {
+			continue
+		}
+		path := filepath.Join(stage, c.Name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		var err error
+		if c.Symlink {
+			err = os.Symlink(c.New, path)
+			if err == nil {
+				err = setMetadata(path, opts.Mode, opts)
+			}
+		} else {
+			err = writeSynced(path, []byte(c.New), opts.Mode, opts)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := writeSynced(filepath.Join(stage, ManifestName), manifest, 0o644, opts); err != nil {
+		return err
+	}
+	return syncDir(stage)
+}
+
+// mirror recreates the tree at src in dst, except for the paths for which
+// skip is true. Regular files are hard linked, or copied when linking is
+// not possible; directories and symlinks are recreated. Recreated and copied
+// files keep their mode, owner and group.
+func mirror(src, dst string, skip func(rel string) bool) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if skip(filepath.ToSlash(rel)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			// Mkdir applies the umask.
+			if err = os.Mkdir(target, info.Mode().Perm()); err == nil {
+				err = os.Chmod(target, info.Mode().Perm())
+			}
+		case info.Mode()&fs.ModeSymlink != 0:
+			var link string
+			if link, err = os.Readlink(path); err == nil {
+				err = os.Symlink(link, target)
+			}
+		case info.Mode().IsRegular():
+			if os.Link(path, target) == nil {
+				return nil
+			}
+			err = copyFile(path, target, info.Mode().Perm())
+		default:
+			return fmt.Errorf("%s: unsupported file type %s", path, info.Mode().Type())
+		}
+		if err != nil {
+			return err
+		}
+		return keepOwner(target, info)
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	return writeSynced(dst, data, mode, Options{UID: -1, GID: -1})
+}
+
+// writeSynced creates path with data and flushes it to disk.
+func writeSynced(path string, data []byte, mode os.FileMode, opts Options) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return setMetadata(path, mode, opts)
+}
+
+// setMetadata gives path the mode, despite the umask, and the owner of opts.
+// Symlinks only get the owner.
+func setMetadata(path string, mode os.FileMode, opts Options) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&fs.ModeSymlink == 0 && fi.Mode().Perm() != mode {
+		if err := os.Chmod(path, mode); err != nil {
+			return err
+		}
+	}
+	if opts.UID >= 0 || opts.GID >= 0 {
+		return os.Lchown(path, opts.UID, opts.GID)
+	}
+	return nil
+}
+
+func syncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		return err
+	}
+	return nil
+}
+
+// StagePrefix names the staged trees of the output directory base. Trees
+// staged on a remote host follow the same convention.
+func StagePrefix(base string) string {
+	return "." + base + ".unitd-"
+}
+
+// swap puts the tree at stage in place of dir.
+func swap(dir, stage string) error {
+	fi, err := os.Lstat(dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return os.Rename(stage, dir)
+	case err != nil:
+		return err
+	case fi.Mode()&fs.ModeSymlink != 0:
+		return flipSymlink(dir, stage)
+	case !fi.IsDir():
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	if err := exchange(stage, dir); err == nil {
+		return os.RemoveAll(stage)
+	}
+
+	// Without an atomic exchange the directory is briefly missing, but
+	// never holds a mix of old and new files.
+	old := stage + ".old"
+	if err := os.Rename(dir, old); err != nil {
+		return err
+	}
+	if err := os.Rename(stage, dir); err != nil {
+		os.Rename(old, dir)
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+// flipSymlink points the symlink dir to stage. The previous target is
+// removed when it is a tree staged by unitd.
+func flipSymlink(dir, stage string) error {
+	previous, err := os.Readlink(dir)
+	if err != nil {
+		return err
+	}
+
+	tmp := stage + ".link"
+	if err := os.Symlink(filepath.Base(stage), tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if !filepath.IsAbs(previous) && filepath.Dir(previous) == "." &&
+		strings.HasPrefix(previous, StagePrefix(filepath.Base(dir))) {
+		return os.RemoveAll(filepath.Join(filepath.Dir(dir), previous))
+	}
+	return nil
+}
+
+// removeStale removes the staged trees an interrupted Apply left behind,
+// except the one the output directory links to.
+func removeStale(parent, base string) {
+	live, _ := os.Readlink(filepath.Join(parent, base))
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), StagePrefix(base)) && e.Name() != live {
+			os.RemoveAll(filepath.Join(parent, e.Name()))
+		}
+	}
+}
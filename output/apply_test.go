@@ -0,0 +1,82 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func TestApplySymlink(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "v1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(root, "out")
+	if err := os.Symlink("v1", out); err != nil {
+		t.Fatal(err)
+	}
+
+	apply(t, out, []configs.File{{Name: "a.service", Content: "a\n", Source: "service.a"}})
+	first, err := os.Readlink(out)
+	if err != nil {
+		t.Fatalf("output is no longer a symlink: %s", err)
+	}
+	if !strings.HasPrefix(first, ".out.unitd-") {
+		t.Errorf("symlink points to %q, want a staged tree", first)
+	}
+	if _, err := os.Stat(filepath.Join(root, "v1")); err != nil {
+		t.Errorf("previous target not created by unitd was removed: %s", err)
+	}
+
+	apply(t, out, []configs.File{{Name: "a.service", Content: "a2\n", Source: "service.a"}})
+	if _, err := os.Stat(filepath.Join(root, first)); !os.IsNotExist(err) {
+		t.Errorf("previous staged tree %s was left behind", first)
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, "a.service")); string(data) != "a2\n" {
+		t.Errorf("a.service = %q", data)
+	}
+}
+
+func TestApplyKeepsUnchangedInodes(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	files := []configs.File{
+		{Name: "a.service", Content: "a\n"},
+		{Name: "b.service", Content: "b\n"},
+	}
+	apply(t, out, files)
+	before, _ := os.Stat(filepath.Join(out, "a.service"))
+
+	files[1].Content = "b2\n"
+	apply(t, out, files)
+	after, _ := os.Stat(filepath.Join(out, "a.service"))
+	if !os.SameFile(before, after) {
+		t.Error("unchanged a.service was rewritten")
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(out))
+	if len(entries) != 1 {
+		t.Errorf("staging directories left behind: %v", entries)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("renameat2 is Linux only")
+	}
+	root := t.TempDir()
+	a, b := filepath.Join(root, "a"), filepath.Join(root, "b")
+	os.Mkdir(a, 0o755)
+	os.Mkdir(b, 0o755)
+	os.WriteFile(filepath.Join(a, "marker"), nil, 0o644)
+
+	if err := exchange(a, b); err != nil {
+		t.Skipf("renameat2 unavailable: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(b, "marker")); err != nil {
+		t.Errorf("directories were not exchanged: %s", err)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build linux
+
+package output
+
+import "golang.org/x/sys/unix"
+
+// exchange atomically swaps the paths a and b with renameat2(2).
+func exchange(a, b string) error {
+	return unix.Renameat2(unix.AT_FDCWD, a, unix.AT_FDCWD, b, unix.RENAME_EXCHANGE)
+}
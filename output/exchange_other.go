@@ -0,0 +1,10 @@
+//go:build !linux
+
+package output
+
+import "errors"
+
+// exchange is only implemented on Linux.
+func exchange(a, b string) error {
+	return errors.ErrUnsupported
+}
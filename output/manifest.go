@@ -0,0 +1,87 @@
+// Package output manages the directories unitd renders units into: which
+// files it owns there, and how to bring them up to date.
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// ManifestName is the file, in an output directory, recording the files
+// unitd wrote there.
+const ManifestName = ".unitd-manifest.json"
+
+// manifestVersion is bumped when the manifest format changes incompatibly.
+const manifestVersion = 1
+
+// Manifest lists the files unitd owns in an output directory. Only those
+// files are ever changed or deleted.
+type Manifest struct {
+	Version int             `json:"version"`
+	Files   []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry describes one owned file.
+type ManifestEntry struct {
+	File    string `json:"file"`              // name relative to the output directory
+	Hash    string `json:"hash"`              // "sha256:<hex>" of the content or link target
+	Source  string `json:"source"`            // address of the originating block
+	Symlink bool   `json:"symlink,omitempty"` // whether the file is a symlink
+}
+
+// Hash returns the manifest hash of content.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ReadManifest reads the manifest of dir. A directory without manifest,
+// or no directory at all, owns nothing.
+func ReadManifest(dir string) (*Manifest, error) {
+	return readManifestFS(os.DirFS(dir))
+}
+
+func readManifestFS(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Manifest{Version: manifestVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestName, err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("%s: unsupported version %d", ManifestName, m.Version)
+	}
+	return &m, nil
+}
+
+// Lookup returns the entry for file.
+func (m *Manifest) Lookup(file string) (ManifestEntry, bool) {
+	for _, e := range m.Files {
+		if e.File == file {
+			return e, true
+		}
+	}
+	return ManifestEntry{}, false
+}
+
+// Marshal returns the manifest as written to disk, entries sorted by file.
+func (m *Manifest) Marshal() ([]byte, error) {
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].File < m.Files[j].File })
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package output
+
+import "io/fs"
+
+// keepOwner is only implemented on Unix systems.
+func keepOwner(path string, info fs.FileInfo) error {
+	return nil
+}
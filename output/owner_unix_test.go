@@ -0,0 +1,49 @@
+//go:build unix
+
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func TestApplyKeepsForeignOwners(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("changing owners needs root")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	apply(t, out, []configs.File{{Name: "a.service", Content: "a\n"}})
+
+	// A drop-in directory and a symlink another tool put there.
+	dropins := filepath.Join(out, "a.service.d")
+	if err := os.Mkdir(dropins, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/dev/null", filepath.Join(out, "b.service")); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{out, dropins, filepath.Join(out, "b.service")} {
+		if err := os.Lchown(p, 1234, 5678); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	apply(t, out, []configs.File{{Name: "a.service", Content: "a2\n"}})
+	for _, p := range []string{out, dropins, filepath.Join(out, "b.service")} {
+		fi, err := os.Lstat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st := fi.Sys().(*syscall.Stat_t)
+		if st.Uid != 1234 || st.Gid != 5678 {
+			t.Errorf("%s is owned by %d:%d, want 1234:5678", p, st.Uid, st.Gid)
+		}
+	}
+	if fi, _ := os.Stat(dropins); fi.Mode().Perm() != 0o750 {
+		t.Errorf("%s has mode %s, want 0750", dropins, fi.Mode().Perm())
+	}
+}
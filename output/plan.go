@@ -0,0 +1,171 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+// Action is what applying a plan does to one file.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Change is one file a plan writes or removes. Old is the content on disk,
+// New the rendered one; Old is empty for Create and New for Delete. For a
+// symlink, New is the link target.
+type Change struct {
+	Action  Action
+	Name    string
+	Source  string
+	Old     string
+	New     string
+	Symlink bool
+}
+
+// Plan is the set of changes bringing an output directory in line with a
+// rendered configuration.
+type Plan struct {
+	Dir      string
+	Changes  []Change
+	Manifest *Manifest // manifest once the plan is applied
+
+	// Kept lists files the previous manifest owned that are no longer
+	// rendered but were left in place because they were edited since.
+	Kept []string
+
+	// Foreign lists rendered files that already exist with the rendered
+	// content but were not written by unitd. They are left out of the
+	// manifest, so they are never changed or removed.
+	Foreign []string
+}
+
+// NewPlan compares the files rendered from a configuration with the content
+// of dir.
+//
+// Files recorded in the manifest of dir are owned: they are updated when
+// their content differs and deleted when no longer rendered. Any other file
+// is never touched: rendering a file of the same name with other content is
+// an error, and one with the same content is left unowned (see Foreign).
+func NewPlan(dir string, files []configs.File) (*Plan, error) {
+	return NewPlanFS(dir, os.DirFS(dir), files)
+}
+
+// NewPlanFS is NewPlan for a directory whose content is read from fsys, such
+// as a snapshot of a remote host. Errors and the plan still name dir.
+func NewPlanFS(dir string, fsys fs.FS, files []configs.File) (*Plan, error) {
+	old, err := readManifestFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{Dir: dir, Manifest: &Manifest{Version: manifestVersion, Files: []ManifestEntry{}}}
+	rendered := make(map[string]bool, len(files))
+	for _, f := range files {
+		want := fileState{exists: true, data: f.Content}
+		if f.Link != "" {
+			want = fileState{exists: true, link: true, data: f.Link}
+		}
+		rendered[f.Name] = true
+
+		current, err := readState(fsys, dir, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		_, owned := old.Lookup(f.Name)
+		if current.exists && !owned && current == want {
+			p.Foreign = append(p.Foreign, f.Name)
+			continue
+		}
+		p.Manifest.Files = append(p.Manifest.Files, ManifestEntry{
+			File:    f.Name,
+			Hash:    Hash([]byte(want.data)),
+			Source:  f.Source,
+			Symlink: want.link,
+		})
+
+		change := Change{Name: f.Name, Source: f.Source, Old: current.data, New: want.data, Symlink: want.link}
+		switch {
+		case !current.exists:
+			change.Action = Create
+			p.Changes = append(p.Changes, change)
+		case current == want:
+			// Up to date.
+		case !owned:
+			return nil, fmt.Errorf("%s exists and was not written by unitd; move it away to let unitd manage it", filepath.Join(dir, f.Name))
+		default:
+			change.Action = Update
+			p.Changes = append(p.Changes, change)
+		}
+	}
+
+	for _, e := range old.Files {
+		if rendered[e.File] {
+			continue
+		}
+		current, err := readState(fsys, dir, e.File)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !current.exists:
+		case current.link != e.Symlink || Hash([]byte(current.data)) != e.Hash:
+			p.Kept = append(p.Kept, e.File)
+		default:
+			p.Changes = append(p.Changes, Change{Action: Delete, Name: e.File, Source: e.Source, Old: current.data, Symlink: current.link})
+		}
+	}
+	return p, nil
+}
+
+// Empty reports whether applying p changes no unit file.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// fileState is what a path holds: the content of a regular file or the
+// target of a symlink.
+type fileState struct {
+	exists bool
+	link   bool
+	data   string
+}
+
+func readState(fsys fs.FS, dir, name string) (fileState, error) {
+	fi, err := fs.Lstat(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileState{}, nil
+	}
+	if err != nil {
+		return fileState{}, err
+	}
+	if fi.Mode()&fs.ModeSymlink != 0 {
+		target, err := fs.ReadLink(fsys, name)
+		return fileState{exists: true, link: true, data: target}, err
+	}
+	if !fi.Mode().IsRegular() {
+		return fileState{}, fmt.Errorf("%s is not a regular file", filepath.Join(dir, name))
+	}
+	data, err := fs.ReadFile(fsys, name)
+	return fileState{exists: true, data: string(data)}, err
+}
+
+// readFile returns the content of path and whether it exists.
+func readFile(path string) (string, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func apply(t *testing.T, dir string, files []configs.File) *Plan {
+	t.Helper()
+	p, err := NewPlan(dir, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply(DefaultOptions, nil); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func actions(p *Plan) string {
+	var out []string
+	for _, c := range p.Changes {
+		out = append(out, string(c.Action)+" "+c.Name)
+	}
+	return strings.Join(out, ", ")
+}
+
+func TestPlan(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("foreign.service", "not ours\n")
+
+	p := apply(t, dir, []configs.File{
+		{Name: "a.service", Content: "a\n", Source: "service.a"},
+		{Name: "b.service", Content: "b\n", Source: "service.b"},
+	})
+	if got, want := actions(p), "create a.service, create b.service"; got != want {
+		t.Errorf("first build: %s, want %s", got, want)
+	}
+
+	m, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := m.Lookup("b.service"); !ok || e.Source != "service.b" || e.Hash != Hash([]byte("b\n")) {
+		t.Errorf("manifest entry for b.service: %+v", e)
+	}
+
+	// b is renamed to c: b is pruned, the foreign file stays.
+	p = apply(t, dir, []configs.File{
+		{Name: "a.service", Content: "a2\n", Source: "service.a"},
+		{Name: "c.service", Content: "b\n", Source: "service.c"},
+	})
+	if got, want := actions(p), "update a.service, create c.service, delete b.service"; got != want {
+		t.Errorf("rename: %s, want %s", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.service")); !os.IsNotExist(err) {
+		t.Errorf("b.service was not pruned: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "foreign.service")); string(data) != "not ours\n" {
+		t.Errorf("foreign file changed: %q", data)
+	}
+
+	// An owned file edited by hand is kept when no longer rendered.
+	write("c.service", "edited\n")
+	p = apply(t, dir, []configs.File{{Name: "a.service", Content: "a2\n", Source: "service.a"}})
+	if len(p.Changes) != 0 || len(p.Kept) != 1 || p.Kept[0] != "c.service" {
+		t.Errorf("edited file: changes %q, kept %q", actions(p), p.Kept)
+	}
+
+	// Rendering over a foreign file is refused.
+	if _, err := NewPlan(dir, []configs.File{{Name: "foreign.service", Content: "x\n"}}); err == nil {
+		t.Error("expected an error when overwriting a foreign file")
+	}
+}
+
+func TestPlanLeavesIdenticalForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	foreign := filepath.Join(dir, "web.service")
+	if err := os.WriteFile(foreign, []byte("web\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := apply(t, dir, []configs.File{
+		{Name: "a.service", Content: "a\n", Source: "service.a"},
+		{Name: "web.service", Content: "web\n", Source: "service.web"},
+	})
+	if got, want := actions(p), "create a.service"; got != want {
+		t.Errorf("first build: %s, want %s", got, want)
+	}
+	if len(p.Foreign) != 1 || p.Foreign[0] != "web.service" {
+		t.Errorf("foreign = %q, want web.service", p.Foreign)
+	}
+	if m, err := ReadManifest(dir); err != nil {
+		t.Fatal(err)
+	} else if _, ok := m.Lookup("web.service"); ok {
+		t.Error("identical foreign file was adopted into the manifest")
+	}
+	if fi, err := os.Stat(foreign); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("foreign file metadata changed: %v, %v", fi.Mode(), err)
+	}
+
+	// The unit is no longer rendered: the file is not ours to prune.
+	p = apply(t, dir, []configs.File{{Name: "a.service", Content: "a\n", Source: "service.a"}})
+	if len(p.Changes) != 0 {
+		t.Errorf("unit removed: %s, want no changes", actions(p))
+	}
+	if data, err := os.ReadFile(foreign); err != nil || string(data) != "web\n" {
+		t.Errorf("foreign file = %q, %v", data, err)
+	}
+}
+
+func TestPlanSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	link := configs.File{Name: "multi-user.target.wants/a.service", Link: "/etc/systemd/system/a.service", Source: "service.a"}
+
+	p := apply(t, dir, []configs.File{link})
+	if got, want := actions(p), "create multi-user.target.wants/a.service"; got != want {
+		t.Errorf("create: %s, want %s", got, want)
+	}
+	if target, err := os.Readlink(filepath.Join(dir, link.Name)); err != nil || target != link.Link {
+		t.Errorf("link target %q, %v", target, err)
+	}
+
+	p = apply(t, dir, []configs.File{link})
+	if !p.Empty() {
+		t.Errorf("unchanged link planned: %s", actions(p))
+	}
+
+	link.Link = "/etc/systemd/system/b.service"
+	p = apply(t, dir, []configs.File{link})
+	if got, want := actions(p), "update multi-user.target.wants/a.service"; got != want {
+		t.Errorf("retarget: %s, want %s", got, want)
+	}
+
+	p = apply(t, dir, nil)
+	if got, want := actions(p), "delete multi-user.target.wants/a.service"; got != want {
+		t.Errorf("delete: %s, want %s", got, want)
+	}
+}
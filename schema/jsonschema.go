@@ -0,0 +1,111 @@
+package schema
+
+import "sort"
+
+// object is a JSON Schema object.
+type object = map[string]any
+
+// expression matches the strings the HCL JSON syntax evaluates as templates,
+// which is how references and functions are written there: "${service.db}".
+var expression = object{"type": "string", "pattern": `\$\{`}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) of configuration files
+// written in the HCL JSON syntax (.hcl.json).
+func JSONSchema() object {
+	root := Config()
+	defs := object{"expression": expression}
+
+	props := object{}
+	for _, b := range root.Blocks {
+		defs[b.Type] = bodySchema(b.Body, b.Description, defs, b.Type+".")
+		props[b.Type] = object{
+			"description":          b.Description,
+			"type":                 "object",
+			"additionalProperties": blockValue(object{"$ref": "#/$defs/" + b.Type}),
+		}
+	}
+
+	return object{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "unitd configuration",
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+		"$defs":                defs,
+	}
+}
+
+// bodySchema describes a block body, adding the schemas of nested blocks to
+// defs under prefix+type.
+func bodySchema(body Body, description string, defs object, prefix string) object {
+	props := object{}
+	var required []string
+
+	for _, a := range body.Attributes {
+		props[a.Name] = attributeSchema(a)
+		if a.Required {
+			required = append(required, a.Name)
+		}
+	}
+	for _, b := range body.Blocks {
+		name := prefix + b.Type
+		def := bodySchema(b.Body, b.Description, defs, name+".")
+		if b.Systemd != "" {
+			def["title"] = b.Systemd
+		}
+		defs[name] = def
+		props[b.Type] = blockValue(object{"$ref": "#/$defs/" + name})
+		if b.Required {
+			required = append(required, b.Type)
+		}
+	}
+
+	s := object{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if description != "" {
+		s["description"] = description
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		s["required"] = required
+	}
+	return s
+}
+
+// blockValue accepts a block as one object or, as the HCL JSON syntax
+// allows, an array of objects.
+func blockValue(ref object) object {
+	return object{"anyOf": []any{ref, object{"type": "array", "items": ref}}}
+}
+
+func attributeSchema(a Attribute) object {
+	var s object
+	switch {
+	case len(a.Values) > 0:
+		s = object{"anyOf": []any{object{"enum": a.Values}, expressionRef()}}
+	case a.Type == "bool":
+		s = object{"anyOf": []any{object{"type": "boolean"}, expressionRef()}}
+	case a.Type == "number":
+		s = object{"anyOf": []any{object{"type": "integer"}, expressionRef()}}
+	case a.Type == "list(string)":
+		s = object{"anyOf": []any{object{"type": "array", "items": object{"type": "string"}}, expressionRef()}}
+	case a.Type == "map(string)":
+		s = object{"anyOf": []any{object{"type": "object", "additionalProperties": object{"type": "string"}}, expressionRef()}}
+	default:
+		s = object{"type": "string"}
+	}
+	if a.Systemd != "" {
+		s["title"] = a.Systemd
+	}
+	if a.Description != "" {
+		s["description"] = a.Description
+	}
+	return s
+}
+
+func expressionRef() object {
+	return object{"$ref": "#/$defs/expression"}
+}
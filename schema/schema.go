@@ -0,0 +1,148 @@
+// Package schema describes the unitd configuration language for tools that
+// do not link Go: as a JSON Schema for the HCL JSON syntax, or as the tree
+// of hcl.BodySchema the decoder applies.
+package schema
+
+import "github.com/vanviethieuanh/unitd/configs"
+
+// Body is an hcl.BodySchema with the schemas of the nested block bodies and
+// the types of the attributes.
+type Body struct {
+	Attributes []Attribute `json:"attributes"`
+	Blocks     []Block     `json:"blocks"`
+}
+
+// Attribute is an hcl.AttributeSchema with its type.
+type Attribute struct {
+	Name        string   `json:"name"`
+	Required    bool     `json:"required"`
+	Type        string   `json:"type"`
+	Values      []string `json:"values,omitempty"`
+	Systemd     string   `json:"systemd,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Block is an hcl.BlockHeaderSchema with the schema of its body.
+type Block struct {
+	Type        string   `json:"type"`
+	LabelNames  []string `json:"label_names"`
+	Required    bool     `json:"required"`
+	Systemd     string   `json:"systemd,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Body        Body     `json:"body"`
+}
+
+// rootBlocks returns the top-level blocks. Their own attributes are unitd
+// settings rather than directives; the sections of a unit come from the
+// directive registry.
+func rootBlocks() []Block {
+	return []Block{
+		{
+			Type:        "service",
+			LabelNames:  []string{"name"},
+			Description: "A systemd service unit.",
+			Body: unitBody("service",
+				Attribute{Name: "template", Type: "bool"},
+				Attribute{Name: "for_each", Type: "map(string)"},
+				Attribute{
+					Name:   "restart_policy",
+					Type:   "string",
+					Values: []string{configs.RestartPolicyRestart, configs.RestartPolicyReload, configs.RestartPolicyNone},
+				},
+			),
+		},
+		{
+			Type:        "instance",
+			LabelNames:  []string{"name"},
+			Description: "Instances of a template service.",
+			Body: Body{
+				Attributes: []Attribute{
+					{Name: "template", Required: true, Type: "string"},
+					{Name: "instances", Required: true, Type: "list(string)"},
+				},
+				Blocks: []Block{},
+			},
+		},
+		{
+			Type:        "host",
+			LabelNames:  []string{"name"},
+			Description: "A machine and the units applied to it.",
+			Body: Body{
+				Attributes: []Attribute{
+					{Name: "enable", Type: "list(string)"},
+					{Name: "disable", Type: "list(string)"},
+				},
+				Blocks: []Block{
+					{
+						Type:       "ssh",
+						LabelNames: []string{},
+						Body: Body{
+							Attributes: []Attribute{
+								{Name: "name", Type: "string"},
+								{Name: "user", Type: "string"},
+								{Name: "host", Type: "string"},
+								{Name: "port", Type: "number"},
+								{Name: "identity_file", Type: "string"},
+								{Name: "known_hosts", Type: "string"},
+							},
+							Blocks: []Block{},
+						},
+					},
+					{
+						Type:       "package",
+						LabelNames: []string{},
+						Body: Body{
+							Attributes: []Attribute{
+								{Name: "name", Required: true, Type: "string"},
+								{Name: "version", Required: true, Type: "string"},
+								{Name: "depends", Type: "list(string)"},
+								{Name: "maintainer", Type: "string"},
+								{Name: "description", Type: "string"},
+								{Name: "architecture", Type: "string"},
+							},
+							Blocks: []Block{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Config returns the schema of a configuration file.
+func Config() Body {
+	return Body{Attributes: []Attribute{}, Blocks: rootBlocks()}
+}
+
+// unitBody returns the body of a unit block of unitType: attrs followed by a
+// required block for each of its sections.
+func unitBody(unitType string, attrs ...Attribute) Body {
+	body := Body{Attributes: attrs, Blocks: []Block{}}
+	for _, s := range configs.UnitSections(unitType) {
+		body.Blocks = append(body.Blocks, sectionBlock(s))
+	}
+	return body
+}
+
+// sectionBlock returns the block of a section, with an attribute for each of
+// its directives.
+func sectionBlock(s configs.Section) Block {
+	body := Body{Attributes: []Attribute{}, Blocks: []Block{}}
+	for _, d := range configs.SectionDirectives(s.Name) {
+		body.Attributes = append(body.Attributes, Attribute{
+			Name:        d.Name,
+			Type:        d.Type,
+			Values:      d.Values,
+			Systemd:     d.Systemd + "=",
+			Description: d.Doc,
+		})
+	}
+	return Block{
+		Type:        s.Name,
+		LabelNames:  []string{},
+		Required:    true,
+		Systemd:     s.Systemd,
+		Description: s.Doc,
+		Body:        body,
+	}
+}
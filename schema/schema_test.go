@@ -0,0 +1,129 @@
+package schema
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/vanviethieuanh/unitd/configs"
+)
+
+func findBlock(blocks []Block, typ string) (Block, bool) {
+	for _, b := range blocks {
+		if b.Type == typ {
+			return b, true
+		}
+	}
+	return Block{}, false
+}
+
+func TestConfig(t *testing.T) {
+	svc, ok := findBlock(Config().Blocks, "service")
+	if !ok || len(svc.LabelNames) != 1 {
+		t.Fatalf("service block: %+v", svc)
+	}
+
+	for _, name := range []string{"unit", "service", "install"} {
+		b, ok := findBlock(svc.Body.Blocks, name)
+		if !ok || !b.Required {
+			t.Errorf("%s block missing or optional", name)
+			continue
+		}
+		if got, want := len(b.Body.Attributes), len(configs.SectionDirectives(name)); got != want {
+			t.Errorf("%s block: %d attributes, registry has %d", name, got, want)
+		}
+	}
+
+	section, _ := findBlock(svc.Body.Blocks, "service")
+	for _, a := range section.Body.Attributes {
+		if a.Name == "restart" && (a.Systemd != "Restart=" || a.Type != "string" || len(a.Values) == 0) {
+			t.Errorf("restart: %+v", a)
+		}
+	}
+}
+
+// TestDecoderSchema checks the blocks that are not sections against the
+// structs the decoder fills.
+func TestDecoderSchema(t *testing.T) {
+	root := Config()
+	host := mustBlock(t, root.Blocks, "host")
+	tests := []struct {
+		body Body
+		v    any
+	}{
+		{root, &configs.Config{}},
+		{mustBlock(t, root.Blocks, "service").Body, &configs.Service{}},
+		{mustBlock(t, root.Blocks, "instance").Body, &configs.Instance{}},
+		{host.Body, &configs.Host{}},
+		{mustBlock(t, host.Body.Blocks, "ssh").Body, &configs.SSH{}},
+		{mustBlock(t, host.Body.Blocks, "package").Body, &configs.Package{}},
+	}
+	for _, tt := range tests {
+		implied, _ := gohcl.ImpliedBodySchema(tt.v)
+		var want, got []string
+		for _, a := range implied.Attributes {
+			want = append(want, a.Name+required(a.Required))
+		}
+		for _, b := range implied.Blocks {
+			want = append(want, b.Type+"{}")
+		}
+		for _, a := range tt.body.Attributes {
+			got = append(got, a.Name+required(a.Required))
+		}
+		for _, b := range tt.body.Blocks {
+			got = append(got, b.Type+"{}")
+		}
+		sort.Strings(want)
+		sort.Strings(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%T (-decoder +schema):\n%s", tt.v, diff)
+		}
+	}
+}
+
+func mustBlock(t *testing.T, blocks []Block, typ string) Block {
+	t.Helper()
+	b, ok := findBlock(blocks, typ)
+	if !ok {
+		t.Fatalf("no %s block", typ)
+	}
+	return b
+}
+
+func required(r bool) string {
+	if r {
+		return "!"
+	}
+	return ""
+}
+
+func TestJSONSchema(t *testing.T) {
+	out, err := json.Marshal(JSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Defs map[string]struct {
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(doc.Defs["service"].Required, ","); got != "install,service,unit" {
+		t.Errorf("service required: %s", got)
+	}
+	if got := strings.Join(doc.Defs["instance"].Required, ","); got != "instances,template" {
+		t.Errorf("instance required: %s", got)
+	}
+	restart := string(doc.Defs["service.service"].Properties["restart"])
+	if !strings.Contains(restart, `"on-failure"`) || !strings.Contains(restart, `"title":"Restart="`) {
+		t.Errorf("restart: %s", restart)
+	}
+}
@@ -46,7 +46,6 @@ _TYPE_NORMALIZE: dict[str, str] = {
     "DEVICE": "STRING [...]",
     "DEVICELATENCY": "STRING [...]",
     "DEVICEWEIGHT": "STRING [...]",
-    "ENVIRON": "STRING [...]",
     "FAMILIES": "STRING [...]",
     "FILESYSTEMS": "STRING [...]",
     "LIMIT": "STRING",
@@ -75,8 +74,9 @@ _TYPE_NORMALIZE: dict[str, str] = {
     "CPUWEIGHT": "UNSIGNED",
     "NANOSECONDS": "SECONDS",
     "WEIGHT": "UNSIGNED",
-    # Complex compound types → simplified
-    "PATH [ARGUMENT [...]]": "STRING",
+    # Complex compound types → simplified. Command lines (config_parse_exec)
+    # accumulate: every ExecStart= adds one, and an empty one resets them.
+    "PATH [ARGUMENT [...]]": "STRING [...]",
     "PATH[:PATH[:OPTIONS]] [...]": "STRING [...]",
 }
 
@@ -114,6 +114,13 @@ def extract_macros(load_fragment_c: str, conf_parser_c: str) -> dict[str, str]:
 
 # Ordered patterns: first match wins.
 _NAME_PATTERNS: list[tuple[re.Pattern, str]] = [
+    # Environment= takes KEY=VALUE assignments; PassEnvironment= and
+    # UnsetEnvironment= (config_parse_{pass,unset}_environ) are plain name lists.
+    (re.compile(r"^config_parse_environ$"), "ENVIRON"),
+    (re.compile(r"_env_file$"), "PATH [...]"),
+    # Enum parsers whose names would otherwise hit the generic patterns below.
+    (re.compile(r"_timeout_failure_mode$"), "TIMEOUTMODE"),
+    (re.compile(r"_emergency_action$"), "ACTION"),
     (re.compile(r"_sec_|_timeout_|_duration_"), "SECONDS"),
     (re.compile(r"_path_strv|_paths$"), "PATH [...]"),
     (re.compile(r"_path$|_pid_file|_working_directory"), "PATH"),
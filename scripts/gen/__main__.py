@@ -16,10 +16,19 @@ from pathlib import Path
 
 import yaml
 
-from .codegen import gen_common, generate_known_units_code, generate_unit_code, render_unit_file
+from .codegen import (
+    gen_common,
+    generate_enums_code,
+    generate_known_units_code,
+    generate_registry_code,
+    generate_unit_code,
+    render_unit_file,
+)
 from .directive import Directive
+from .enums import extract_enums
 from .gperf import extract_all_gperfs, load_all_directives, load_parser_map
-from .parser import parse_applicable_types, parse_descriptions, parse_special_units, parse_unit, scan_shipped_units
+from .parser import Unit, parse_applicable_types, parse_descriptions, parse_special_units, parse_unit, scan_shipped_units
+from .registry import COMMON_SECTIONS, RegistryDirective, RegistrySection, directive_entries, section_entry
 
 log = logging.getLogger(__name__)
 
@@ -50,17 +59,40 @@ def main() -> None:
     directives = load_all_directives(gperf_records, parser_map)
     log.info("built %d directives", len(directives))
 
+    unit_types = sorted(m.removeprefix("systemd.") for m in man_files if m != "systemd.unit")
+    registry_sections: list[RegistrySection] = []
+    registry_directives: list[RegistryDirective] = []
+    for section in COMMON_SECTIONS:
+        registry_sections.append(section_entry(section, unit_types, "systemd.unit", ""))
+        registry_directives += directive_entries(directives, section, unit_types, "systemd.unit")
+
     # --- Generate unit config files ---
     for man in man_files:
         if man == "systemd.unit":
             code = gen_common(pkg, directives)
         else:
-            code = _generate_unit(man, man_dir, pkg, shared_mans, directives)
+            code, unit = _generate_unit(man, man_dir, pkg, shared_mans, directives)
+            if unit.options:
+                unit_type = man.removeprefix("systemd.")
+                registry_sections.append(section_entry(unit.name, [unit_type], man, unit.description))
+                registry_directives += directive_entries(unit.options, unit.name, [unit_type], man)
 
         out_path = output_dir / f"{man}.go"
         _write_go_file(out_path, code)
         log.info("wrote %s", out_path)
 
+    # --- Generate the runtime directive registry ---
+    out_path = output_dir / "registry.go"
+    _write_go_file(out_path, generate_registry_code(pkg, registry_sections, registry_directives))
+    log.info("wrote %s", out_path)
+
+    # --- Generate enum types for typed directives ---
+    enums = extract_enums(systemd_src)
+    log.info("extracted %d enum tables", len(enums))
+    out_path = output_dir / "enums.go"
+    _write_go_file(out_path, generate_enums_code(pkg, enums))
+    log.info("wrote %s", out_path)
+
     # --- Generate known units registry ---
     code = _generate_known_units(man_dir, systemd_src / "units", pkg)
     out_path = output_dir / "known_units.go"
@@ -127,12 +159,13 @@ def _generate_unit(
     pkg: str,
     shared_mans: list[str],
     directives: list[Directive],
-) -> str:
+) -> tuple[str, Unit]:
     """Generate code for a single unit type."""
     xml_path = man_dir / f"{man}.xml"
     unit_type = man.removeprefix("systemd.")
 
     extra_descriptions: dict[str, str] = {}
+    extra_sources: dict[str, str] = {}
     for extra in shared_mans:
         extra_path = man_dir / f"{extra}.xml"
         if not extra_path.exists():
@@ -148,12 +181,13 @@ def _generate_unit(
         for k, v in descs.items():
             if k not in extra_descriptions:
                 extra_descriptions[k] = v
+                extra_sources[k] = extra
 
-    unit = parse_unit(xml_path, directives, extra_descriptions)
+    unit = parse_unit(xml_path, directives, extra_descriptions, extra_sources)
 
     unit_code, imports = generate_unit_code(unit)
 
-    return render_unit_file(pkg, man, sorted(imports), unit_code)
+    return render_unit_file(pkg, man, sorted(imports), unit_code), unit
 
 
 def _generate_known_units(man_dir: Path, units_dir: Path, pkg: str) -> str:
@@ -2,12 +2,15 @@
 
 from __future__ import annotations
 
+import json
 from pathlib import Path
 
 import jinja2
 
 from .directive import Directive, generate_block_struct
+from .enums import Enum
 from .parser import KnownUnit, Unit
+from .registry import RegistryDirective, RegistrySection
 from .util import to_pascal_case, to_snake_case, wrap_comment
 
 _TEMPLATES_DIR = Path(__file__).parent / "templates"
@@ -19,9 +22,24 @@ _JINJA_ENV = jinja2.Environment(
     trim_blocks=True,
 )
 
+
+def _go_string(s: str) -> str:
+    """Quote s as a Go interpreted string literal."""
+    return json.dumps(s, ensure_ascii=False)
+
+
+def _go_strings(items: list[str]) -> str:
+    return "[]string{" + ", ".join(_go_string(i) for i in items) + "}"
+
+
+_JINJA_ENV.filters["gostr"] = _go_string
+_JINJA_ENV.filters["gostrs"] = _go_strings
+
 _COMMON_TEMPLATE = _JINJA_ENV.get_template("common.go.j2")
 _UNIT_TEMPLATE = _JINJA_ENV.get_template("unit.go.j2")
 _KNOWN_UNITS_TEMPLATE = _JINJA_ENV.get_template("known_units.go.j2")
+_ENUMS_TEMPLATE = _JINJA_ENV.get_template("enums.go.j2")
+_REGISTRY_TEMPLATE = _JINJA_ENV.get_template("registry.go.j2")
 
 
 def gen_common(pkg: str, directives: list[Directive]) -> str:
@@ -64,8 +82,14 @@ def generate_unit_code(u: Unit) -> tuple[str, set[str]]:
     struct_lines = [f"type {block_type} struct {{"]
     struct_lines.append(f'\tName string `hcl:"name,label"`')
     struct_lines.append("")
-    struct_lines.append(f'\tTemplate bool              `hcl:"template,optional"`')
-    struct_lines.append(f'\tForEach  map[string]string `hcl:"for_each,optional"`')
+    if u.name == "service":
+        # Only services are restarted or reloaded when their file changes.
+        struct_lines.append(f'\tTemplate      bool              `hcl:"template,optional"`')
+        struct_lines.append(f'\tForEach       map[string]string `hcl:"for_each,optional"`')
+        struct_lines.append(f'\tRestartPolicy string            `hcl:"restart_policy,optional"`')
+    else:
+        struct_lines.append(f'\tTemplate bool              `hcl:"template,optional"`')
+        struct_lines.append(f'\tForEach  map[string]string `hcl:"for_each,optional"`')
     struct_lines.append("")
     struct_lines.append(f'\tUnit    UnitBlock    `hcl:"unit,block"`')
     if has_block_type:
@@ -145,3 +169,17 @@ def generate_known_units_code(
     all_units.sort(key=lambda u: u.name)
 
     return _KNOWN_UNITS_TEMPLATE.render(pkg=pkg, units=all_units)
+
+
+def generate_enums_code(pkg: str, enums: list[Enum]) -> str:
+    """Generate Go code for the enum types of typed directives."""
+    return _ENUMS_TEMPLATE.render(pkg=pkg, enums=enums)
+
+
+def generate_registry_code(
+    pkg: str,
+    sections: list[RegistrySection],
+    directives: list[RegistryDirective],
+) -> str:
+    """Generate Go code for the runtime directive registry."""
+    return _REGISTRY_TEMPLATE.render(pkg=pkg, sections=sections, directives=directives)
@@ -23,12 +23,21 @@ class Directive:
     deps: list[str] = field(default_factory=list)
     native_type: bool = True
     ref: str | None = None
+    man_page: str = ""  # man page the description comes from, e.g. "systemd.exec"
+    verbatim: bool = False  # systemd reads "%" literally, without expanding specifiers
+
+
+# Scalar directives are generated as pointers so that an explicit false or
+# zero (PrivateTmp=no, Nice=0) can be told apart from an unset attribute.
+_TRISTATE_TYPES = frozenset({"bool", "int", "int64", "uint64"})
 
 
 def new_directive(section: str, key: str, system: str, type_str: str) -> Directive:
     """Create a Directive by parsing a type expression string."""
     parsed = parse_type_expr(type_str)
     go_type, deps = parsed.to_go_type()
+    if go_type in _TRISTATE_TYPES:
+        go_type = "*" + go_type
 
     return Directive(
         identifier=DirectiveIdentifier(section=section, key=key),
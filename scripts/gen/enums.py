@@ -0,0 +1,68 @@
+"""Extract enum value sets from systemd string tables."""
+
+from __future__ import annotations
+
+import logging
+import re
+from dataclasses import dataclass
+from pathlib import Path
+
+from .types import ENUM_SPECS, EnumSpec
+from .util import to_pascal_case
+
+log = logging.getLogger(__name__)
+
+# Matches e.g.
+#   static const char* const service_type_table[_SERVICE_TYPE_MAX] = {
+#           [SERVICE_SIMPLE] = "simple",
+#           ...
+#   };
+_TABLE_RE = re.compile(
+    r"const\s+char\s*\*\s*const\s+(\w+)_table\s*\[[^\]]*\]\s*=\s*\{(.*?)\};",
+    re.DOTALL,
+)
+_ENTRY_RE = re.compile(r'\[\s*\w+\s*\]\s*=\s*"([^"]*)"')
+
+
+@dataclass
+class Enum:
+    go_name: str
+    table: str
+    values: list[str]
+
+    def const_name(self, value: str) -> str:
+        """Go constant name for a value, e.g. ServiceTypeNotifyReload."""
+        return self.go_name + to_pascal_case(value)
+
+
+def parse_string_tables(source: str) -> dict[str, list[str]]:
+    """Return {table_name: [values...]} for every string table in a C source."""
+    tables: dict[str, list[str]] = {}
+    for m in _TABLE_RE.finditer(source):
+        tables[m.group(1)] = _ENTRY_RE.findall(m.group(2))
+    return tables
+
+
+def extract_enums(systemd_src: Path) -> list[Enum]:
+    """Collect the values of every table referenced by ENUM_SPECS."""
+    wanted: dict[str, EnumSpec] = {spec.table: spec for spec in ENUM_SPECS.values()}
+    found: dict[str, list[str]] = {}
+
+    for path in sorted(systemd_src.glob("src/**/*.c")):
+        source = path.read_text(errors="replace")
+        if "_table[" not in source:
+            continue
+        for name, values in parse_string_tables(source).items():
+            if name in wanted and name not in found:
+                found[name] = values
+
+    enums: list[Enum] = []
+    for table, spec in wanted.items():
+        values = found.get(table)
+        if not values:
+            log.warning("string table %s_table not found — skipping %s", table, spec.go_name)
+            continue
+        enums.append(Enum(go_name=spec.go_name, table=table, values=values))
+
+    enums.sort(key=lambda e: e.go_name)
+    return enums
@@ -149,6 +149,16 @@ _NULL_PARSER_TYPES: dict[str, str] = {
 }
 
 
+# Parsers that read "%" as a percentage (CPUQuota=20%, MemoryMax=50%) and
+# never expand specifiers. A literal "%" must not be doubled for them.
+_VERBATIM_PARSERS = frozenset({
+    "config_parse_cpu_quota",
+    "config_parse_managed_oom_mem_pressure_limit",
+    "config_parse_memory_limit",
+    "config_parse_tasks_max",
+})
+
+
 def load_all_directives(
     gperf_records: list[GperfRecord],
     parser_map: dict[str, str],
@@ -182,6 +192,7 @@ def load_all_directives(
         except ValueError as e:
             log.warning("parse type %r (parser %s): %s — skipping", type_str, gr.parser, e)
             continue
+        d.verbatim = gr.parser in _VERBATIM_PARSERS
 
         result.append(d)
 
@@ -38,10 +38,17 @@ def parse_unit(
     path: Path,
     directives_list: list[Directive],
     extra_descriptions: dict[str, str] | None = None,
+    extra_sources: dict[str, str] | None = None,
 ) -> Unit:
-    """Parse a systemd XML man page and enrich directives with descriptions."""
+    """Parse a systemd XML man page and enrich directives with descriptions.
+
+    extra_sources maps the keys of extra_descriptions to the shared man page
+    they were read from; it is recorded as the directive's man page.
+    """
     if extra_descriptions is None:
         extra_descriptions = {}
+    if extra_sources is None:
+        extra_sources = {}
 
     tree = ET.parse(path)
     root = tree.getroot()
@@ -62,8 +69,10 @@ def parse_unit(
 
         if key in parsed_options:
             d.description = parsed_options[key]
+            d.man_page = path.stem
         elif key in extra_descriptions:
             d.description = extra_descriptions[key]
+            d.man_page = extra_sources.get(key, "")
 
         options.append(d)
 
@@ -128,7 +137,7 @@ def _parse_description(root: ET.Element) -> str:
     for sect in root.iter("refsect1"):
         title_el = sect.find("title")
         if title_el is not None and title_el.text == "Description":
-            paras = [_text_only(p) for p in sect.findall("para")]
+            paras = [_inline_text(p) for p in sect.findall("para")]
             return _join_cleaned(paras)
     return ""
 
@@ -225,15 +234,21 @@ def _inner_xml(el: ET.Element) -> str:
     return ""
 
 
-def _text_only(el: ET.Element) -> str:
-    """Extract only direct text nodes, discarding child elements."""
-    parts: list[str] = []
-    if el.text:
-        parts.append(el.text)
+def _inline_text(el: ET.Element) -> str:
+    """Extract the text of el, keeping the text of inline elements.
+
+    <citerefentry> is rendered as man renders it, "systemd.unit(5)"; other
+    elements (<filename>, <varname>, <literal>, <ulink>, ...) keep their text.
+    """
+    parts: list[str] = [el.text or ""]
     for child in el:
-        # Skip child element content entirely — only grab tail text
-        if child.tail:
-            parts.append(child.tail)
+        if child.tag == "citerefentry":
+            title = child.findtext("refentrytitle") or ""
+            volume = child.findtext("manvolnum")
+            parts.append(f"{title}({volume})" if volume else title)
+        else:
+            parts.append(_inline_text(child))
+        parts.append(child.tail or "")
     return "".join(parts)
 
 
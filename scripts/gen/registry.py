@@ -0,0 +1,106 @@
+"""Runtime directive registry: one entry per directive written in HCL."""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+from .directive import Directive
+from .types import ENUM_SPECS
+from .util import to_pascal_case, to_snake_case
+
+# Unit and Install directives apply to every unit type.
+COMMON_SECTIONS = ("Unit", "Install")
+
+_ENUM_TYPES = frozenset(spec.go_name for spec in ENUM_SPECS.values())
+
+
+@dataclass
+class RegistrySection:
+    name: str  # HCL block name, e.g. "service"
+    systemd: str  # "[Service]"
+    unit_types: list[str]
+    man_page: str  # "systemd.service(5)"
+    doc: str
+
+
+@dataclass
+class RegistryDirective:
+    name: str  # HCL attribute name, e.g. "exec_start"
+    systemd: str  # "ExecStart"
+    section: str  # HCL block name of the section
+    unit_types: list[str]
+    hcl_type: str  # "string", "bool", "number", "list(string)", "map(string)"
+    enum: str | None  # Go enum type name, e.g. "ServiceType"
+    ref: str | None
+    verbatim: bool  # systemd reads "%" literally
+    man_page: str
+    doc: str
+
+
+def hcl_type(go_type: str) -> tuple[str, str | None]:
+    """Map a generated Go field type to its HCL type and enum type, if any."""
+    base = go_type.removeprefix("*")
+    if base in _ENUM_TYPES:
+        return "string", base
+    if base == "bool":
+        return "bool", None
+    if base in ("int", "int64", "uint64"):
+        return "number", None
+    if base.startswith("map["):
+        return "map(string)", None
+    if base.startswith("[]"):
+        return "list(string)", None
+    return "string", None
+
+
+def man_page_ref(man: str) -> str:
+    return f"{man}(5)"
+
+
+def section_entry(section: str, unit_types: list[str], man: str, doc: str) -> RegistrySection:
+    return RegistrySection(
+        name=to_snake_case(to_pascal_case(section)),
+        systemd=f"[{to_pascal_case(section)}]",
+        unit_types=unit_types,
+        man_page=man_page_ref(man),
+        doc=doc,
+    )
+
+
+def directive_entries(
+    directives: list[Directive],
+    section: str,
+    unit_types: list[str],
+    default_man: str,
+) -> list[RegistryDirective]:
+    """Build the entries of one section, in generated struct field order.
+
+    Directives without an HCL attribute (non-native types) are left out.
+    """
+    selected = [
+        d
+        for d in directives
+        if d.identifier.section.lower() == section.lower() and d.native_type
+    ]
+    selected.sort(key=lambda d: to_pascal_case(d.identifier.key))
+
+    entries: list[RegistryDirective] = []
+    seen: set[str] = set()
+    for d in selected:
+        if d.identifier.key in seen:
+            continue
+        seen.add(d.identifier.key)
+        typ, enum = hcl_type(d.type)
+        entries.append(RegistryDirective(
+            name=to_snake_case(d.identifier.key),
+            systemd=d.identifier.key,
+            section=to_snake_case(to_pascal_case(section)),
+            unit_types=unit_types,
+            hcl_type=typ,
+            enum=enum,
+            ref=d.ref,
+            verbatim=d.verbatim,
+            man_page=man_page_ref(d.man_page or default_man),
+            doc=d.description.strip(),
+        ))
+    return entries
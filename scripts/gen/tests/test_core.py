@@ -4,6 +4,9 @@ from __future__ import annotations
 
 import pytest
 
+from scripts.gen.directive import new_directive
+from scripts.gen.enums import Enum, parse_string_tables
+from scripts.gen.registry import directive_entries, hcl_type
 from scripts.gen.types import TypeExpr, ValueType, parse_type_expr
 from scripts.gen.util import to_pascal_case, to_snake_case
 
@@ -117,7 +120,7 @@ def test_go_type_three_layer_nested() -> None:
         base=ValueType.INTEGER,
         inner=TypeExpr(
             base=ValueType.LEVEL,
-            inner=TypeExpr(base=ValueType.SERVICEEXITTYPE, repeated=True),
+            inner=TypeExpr(base=ValueType.SECONDS, repeated=True),
         ),
     )
     assert expr.to_go_type()[0] == "[][][]int"
@@ -130,3 +133,66 @@ def test_go_type_mismatch_error() -> None:
     )
     with pytest.raises(ValueError, match="mismatch"):
         expr.to_go_type()
+
+
+def test_go_type_environ_map() -> None:
+    expr = parse_type_expr("ENVIRON")
+    assert expr.to_go_type() == ("map[string]string", [])
+
+
+# ---------- enum string tables ----------
+
+
+def test_parse_string_tables() -> None:
+    source = """
+static const char* const service_restart_mode_table[_SERVICE_RESTART_MODE_MAX] = {
+        [SERVICE_RESTART_MODE_NORMAL] = "normal",
+        [SERVICE_RESTART_MODE_DIRECT] = "direct",
+        [SERVICE_RESTART_MODE_DEBUG]  = "debug",
+};
+"""
+    assert parse_string_tables(source) == {
+        "service_restart_mode": ["normal", "direct", "debug"],
+    }
+
+
+def test_enum_const_name() -> None:
+    e = Enum(go_name="ServiceType", table="service_type", values=["notify-reload"])
+    assert e.const_name("notify-reload") == "ServiceTypeNotifyReload"
+
+
+def test_go_type_enum() -> None:
+    expr = parse_type_expr("SERVICETYPE")
+    assert expr.to_go_type() == ("ServiceType", [])
+
+
+# ---------- directive registry ----------
+
+
+@pytest.mark.parametrize(
+    "go_type,expected",
+    [
+        ("string", ("string", None)),
+        ("*bool", ("bool", None)),
+        ("*uint64", ("number", None)),
+        ("[]string", ("list(string)", None)),
+        ("map[string]string", ("map(string)", None)),
+        ("ServiceType", ("string", "ServiceType")),
+    ],
+)
+def test_registry_hcl_type(go_type: str, expected: tuple[str, str | None]) -> None:
+    assert hcl_type(go_type) == expected
+
+
+def test_registry_directive_entries() -> None:
+    start = new_directive("Service", "ExecStart", "core", "STRING")
+    user = new_directive("Service", "User", "core", "STRING")
+    user.man_page = "systemd.exec"
+    other = new_directive("Socket", "Accept", "core", "BOOLEAN")
+
+    entries = directive_entries([user, start, other], "Service", ["service"], "systemd.service")
+
+    assert [(e.name, e.section, e.man_page) for e in entries] == [
+        ("exec_start", "service", "systemd.service(5)"),
+        ("user", "service", "systemd.exec(5)"),
+    ]
@@ -12,6 +12,7 @@ class ValueType(StrEnum):
     ARGUMENT = "ARGUMENT"
     BOOLEAN = "BOOLEAN"
     CONDITION = "CONDITION"
+    ENVIRON = "ENVIRON"
     INTEGER = "INTEGER"
     LEVEL = "LEVEL"
     LONG = "LONG"
@@ -47,27 +48,46 @@ class ValueType(StrEnum):
         """Return the ref name for types that represent references, or None."""
         return _REF_MAP.get(self)
 
+    @property
+    def enum(self) -> EnumSpec | None:
+        """Return the enum spec for types with a fixed set of values, or None."""
+        return ENUM_SPECS.get(self)
+
 
 _REF_MAP: dict[ValueType, str] = {
     ValueType.UNIT: "unit",
 }
 
 
+@dataclass(frozen=True)
+class EnumSpec:
+    go_name: str  # Go type name, e.g. "ServiceType"
+    table: str    # systemd string table, e.g. "service_type" for service_type_table[]
+
+
+# Value types backed by a systemd string table. The allowed values are read
+# from the <table>_table[] definitions in the systemd source (see enums.py).
+ENUM_SPECS: dict[ValueType, EnumSpec] = {
+    ValueType.ACTION: EnumSpec("EmergencyAction", "emergency_action"),
+    ValueType.IOCLASS: EnumSpec("IOClass", "ioprio_class"),
+    ValueType.SERVICEEXITTYPE: EnumSpec("ServiceExitType", "service_exit_type"),
+    ValueType.SERVICERESTART: EnumSpec("ServiceRestart", "service_restart"),
+    ValueType.SERVICERESTARTMODE: EnumSpec("ServiceRestartMode", "service_restart_mode"),
+    ValueType.SERVICETYPE: EnumSpec("ServiceType", "service_type"),
+    ValueType.TIMEOUTMODE: EnumSpec("TimeoutFailureMode", "service_timeout_failure_mode"),
+}
+
+
 _GO_TYPE_MAP: dict[ValueType, tuple[str, list[str]]] = {
     ValueType.ACCESS: ("string", []),
-    ValueType.ACTION: ("string", []),
     ValueType.ARGUMENT: ("string", []),
     ValueType.CONDITION: ("string", []),
     ValueType.NETWORKINTERFACE: ("string", []),
     ValueType.NODE: ("string", []),
     ValueType.PATH: ("string", []),
     ValueType.SERVICE: ("string", []),
-    ValueType.SERVICERESTART: ("string", []),
-    ValueType.SERVICERESTARTMODE: ("string", []),
-    ValueType.SERVICETYPE: ("string", []),
     ValueType.STATUS: ("string", []),
     ValueType.STRING: ("string", []),
-    ValueType.TIMEOUTMODE: ("string", []),
     ValueType.URL: ("string", []),
     ValueType.UNIT: ("string", []),
     ValueType.BOOLEAN: ("bool", []),
@@ -78,18 +98,19 @@ _GO_TYPE_MAP: dict[ValueType, tuple[str, list[str]]] = {
     ValueType.UNSIGNED: ("uint64", []),
     ValueType.MODE: ("os.FileMode", ["os"]),
     ValueType.TIMER: ("time.Duration", ["time"]),
-    ValueType.SERVICEEXITTYPE: ("int", []),
     ValueType.SIGNAL: ("syscall.Signal", ["syscall"]),
     ValueType.SOCKETS: ("[]string", []),
+    ValueType.ENVIRON: ("map[string]string", []),
     ValueType.LEVEL: ("int", []),
     ValueType.UNKNOWN: ("string", []),
     ValueType.NOTSUPPORTED: ("string", []),
-    ValueType.IOCLASS: ("string", []),
 }
 
 
 def value_type_to_go(vt: ValueType) -> tuple[str, list[str]]:
     """Return (go_type, imports) for a base ValueType."""
+    if vt.enum is not None:
+        return vt.enum.go_name, []
     return _GO_TYPE_MAP.get(vt, ("string", []))
 
 
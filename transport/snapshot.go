@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshot is a directory tree held in memory, read over SFTP.
+type snapshot map[string]*entry
+
+type entry struct {
+	mode fs.FileMode
+	data string // content of a regular file or target of a symlink
+}
+
+func (s snapshot) lookup(op, name string) (*entry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := s[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return e, nil
+}
+
+func (s snapshot) Open(name string) (fs.File, error) {
+	e, err := s.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.mode&fs.ModeSymlink != 0 {
+		// Links are not followed: they may point anywhere on the host.
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	return &file{info: info{name: path.Base(name), entry: e}, r: strings.NewReader(e.data)}, nil
+}
+
+func (s snapshot) ReadFile(name string) ([]byte, error) {
+	f, err := s.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(f.(*file).info.data), nil
+}
+
+func (s snapshot) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := s.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	var entries []fs.DirEntry
+	for child, e := range s {
+		if child != "." && path.Dir(child) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(info{name: path.Base(child), entry: e}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (s snapshot) Lstat(name string) (fs.FileInfo, error) {
+	e, err := s.lookup("lstat", name)
+	if err != nil {
+		return nil, err
+	}
+	return info{name: path.Base(name), entry: e}, nil
+}
+
+func (s snapshot) ReadLink(name string) (string, error) {
+	e, err := s.lookup("readlink", name)
+	if err != nil {
+		return "", err
+	}
+	if e.mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return e.data, nil
+}
+
+type info struct {
+	name string
+	*entry
+}
+
+func (i info) Name() string       { return i.name }
+func (i info) Size() int64        { return int64(len(i.data)) }
+func (i info) Mode() fs.FileMode  { return i.mode }
+func (i info) ModTime() time.Time { return time.Time{} }
+func (i info) IsDir() bool        { return i.mode.IsDir() }
+func (i info) Sys() any           { return nil }
+
+type file struct {
+	info info
+	r    *strings.Reader
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Read(b []byte) (int, error) { return f.r.Read(b) }
+func (f *file) Close() error               { return nil }
@@ -0,0 +1,628 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kevinburke/ssh_config"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+// SSH is a remote host reached over SSH. Files travel over SFTP; commands
+// run in sessions of the same connection. The host only needs an SSH
+// server with the SFTP subsystem, which OpenSSH enables by default.
+//
+// SFTP cannot change the owner of a symlink, so the links Apply writes
+// belong to the login user. systemd does not look at it.
+type SSH struct {
+	client *ssh.Client
+	sftp   *sftp.Client
+	dest   string
+}
+
+// endpoint is where and as whom to connect, once the ssh block and
+// ~/.ssh/config are merged.
+type endpoint struct {
+	addr       string // host:port
+	user       string
+	identities []string // private key files
+	knownHosts []string // known_hosts files
+}
+
+// NewSSH connects to the host cfg describes. Keys come from the SSH agent
+// and the identity files; the host key must be in the known hosts files.
+func NewSSH(cfg *configs.SSH) (*SSH, error) {
+	e := resolve(cfg, ssh_config.Get)
+
+	hostKeys, err := knownhosts.New(existing(e.knownHosts)...)
+	if err != nil {
+		return nil, err
+	}
+	var signers []ssh.Signer
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			defer conn.Close()
+			if keys, err := agent.NewClient(conn).Signers(); err == nil {
+				signers = append(signers, keys...)
+			}
+		}
+	}
+	for _, name := range existing(e.identities) {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ssh.ParsePrivateKey(data)
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			continue // the agent holds it, or nothing does
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		signers = append(signers, key)
+	}
+
+	return dial(e.addr, &ssh.ClientConfig{
+		User:            e.user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		HostKeyCallback: hostKeys,
+	})
+}
+
+// resolve merges the ssh block with the client configuration lookup
+// returns, as the ssh client would: the block wins over ~/.ssh/config.
+func resolve(cfg *configs.SSH, lookup func(alias, key string) string) endpoint {
+	alias := cfg.Name
+	if alias == "" {
+		alias = cfg.Host
+	}
+	get := func(value, key string) string {
+		if value == "" {
+			value = lookup(alias, key)
+		}
+		return value
+	}
+
+	host := cfg.Host
+	if host == "" {
+		host = get("", "HostName")
+	}
+	if host == "" {
+		host = alias
+	}
+	port := ""
+	if cfg.Port != 0 {
+		port = strconv.Itoa(cfg.Port)
+	}
+	if port = get(port, "Port"); port == "" {
+		port = "22"
+	}
+	user := get(cfg.User, "User")
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+
+	var e endpoint
+	e.addr = net.JoinHostPort(host, port)
+	e.user = user
+	if id := get(cfg.IdentityFile, "IdentityFile"); id != "" {
+		e.identities = append(e.identities, expandHome(id))
+	}
+	if cfg.IdentityFile == "" {
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			e.identities = append(e.identities, expandHome("~/.ssh/"+name))
+		}
+	}
+	if cfg.KnownHosts != "" {
+		e.knownHosts = []string{expandHome(cfg.KnownHosts)}
+	} else {
+		files := get("", "UserKnownHostsFile")
+		if files == "" {
+			files = "~/.ssh/known_hosts"
+		}
+		for _, name := range strings.Fields(files) {
+			e.knownHosts = append(e.knownHosts, expandHome(name))
+		}
+		e.knownHosts = append(e.knownHosts, "/etc/ssh/ssh_known_hosts")
+	}
+	return e
+}
+
+// dial opens the connection and its SFTP session.
+func dial(addr string, config *ssh.ClientConfig) (*SSH, error) {
+	client, err := ssh.Dial("tcp", addr, config)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", addr, err)
+	}
+	files, err := sftp.NewClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("%s: sftp: %w", addr, err)
+	}
+	return &SSH{client: client, sftp: files, dest: addr}, nil
+}
+
+func (s *SSH) Snapshot(dir string) (fs.FS, error) {
+	snap := snapshot{".": {mode: fs.ModeDir | 0o755}}
+	if _, err := s.sftp.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return snap, nil
+	} else if err != nil {
+		return nil, s.wrap(err)
+	}
+
+	root, err := s.follow(dir)
+	if err != nil {
+		return nil, err
+	}
+	walker := s.sftp.Walk(root)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return nil, s.wrap(err)
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(walker.Path(), root), "/")
+		if rel == "" {
+			continue
+		}
+		fi := walker.Stat()
+		switch mode := fi.Mode(); {
+		case mode.IsRegular():
+			data, err := s.ReadFile(walker.Path())
+			if err != nil {
+				return nil, err
+			}
+			snap[rel] = &entry{mode: mode.Perm(), data: string(data)}
+		case mode&fs.ModeSymlink != 0:
+			target, err := s.sftp.ReadLink(walker.Path())
+			if err != nil {
+				return nil, s.wrap(err)
+			}
+			snap[rel] = &entry{mode: fs.ModeSymlink | 0o777, data: target}
+		case mode.IsDir():
+			snap[rel] = &entry{mode: fs.ModeDir | mode.Perm()}
+		default:
+			snap[rel] = &entry{mode: fs.ModeIrregular | mode.Perm()}
+		}
+	}
+	return snap, nil
+}
+
+// Apply mirrors output.Plan.Apply over SFTP. The tree is staged next to
+// the directory and swapped in with an exchange on the host; where mv
+// cannot exchange, the directory is briefly missing between two renames,
+// but never holds a mix of old and new files.
+func (s *SSH) Apply(p *output.Plan, opts output.Options, progress func(output.Change)) error {
+	changed := make(map[string]bool, len(p.Changes))
+	for _, c := range p.Changes {
+		changed[c.Name] = true
+	}
+	dir := path.Clean(p.Dir)
+
+	for _, e := range p.Manifest.Files {
+		if !changed[e.File] {
+			if err := s.setMetadata(path.Join(dir, e.File), opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	manifest, err := p.Manifest.Marshal()
+	if err != nil {
+		return err
+	}
+	if current, err := s.ReadFile(path.Join(dir, output.ManifestName)); err == nil && p.Empty() && bytes.Equal(current, manifest) {
+		return nil
+	}
+
+	parent, base := path.Dir(dir), path.Base(dir)
+	if err := s.sftp.MkdirAll(parent); err != nil {
+		return s.wrap(err)
+	}
+	s.removeStale(parent, base)
+
+	stage := path.Join(parent, output.StagePrefix(base)+randomSuffix())
+	if err := s.sftp.Mkdir(stage); err != nil {
+		return s.wrap(err)
+	}
+	swapped := false
+	defer func() {
+		if !swapped {
+			s.sftp.RemoveAll(stage)
+		}
+	}()
+
+	if err := s.stage(p, dir, stage, changed, manifest, opts); err != nil {
+		return err
+	}
+	if err := s.swap(dir, stage); err != nil {
+		return err
+	}
+	swapped = true
+
+	if progress != nil {
+		for _, c := range p.Changes {
+			progress(c)
+		}
+	}
+	return nil
+}
+
+// stage fills the directory stage with the tree the plan produces.
+func (s *SSH) stage(p *output.Plan, dir, stage string, changed map[string]bool, manifest []byte, opts output.Options) error {
+	mode := os.FileMode(0o755)
+	fi, err := s.sftp.Stat(dir)
+	if err == nil {
+		mode = fi.Mode().Perm()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return s.wrap(err)
+	}
+	if err := s.sftp.Chmod(stage, mode); err != nil {
+		return s.wrap(err)
+	}
+
+	// Everything else in the directory, owned or not, is carried over.
+	if err == nil {
+		skip := func(rel string) bool { return rel == output.ManifestName || changed[rel] }
+		if err := s.mirror(dir, stage, skip); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range p.Changes {
+		if c.Action == output.Delete {
+			continue
+		}
+		name := path.Join(stage, c.Name)
+		if err := s.sftp.MkdirAll(path.Dir(name)); err != nil {
+			return s.wrap(err)
+		}
+		if c.Symlink {
+			err = s.sftp.Symlink(c.New, name)
+		} else {
+			err = s.writeFile(name, []byte(c.New), opts.Mode, opts)
+		}
+		if err != nil {
+			return s.wrap(err)
+		}
+	}
+	return s.writeFile(path.Join(stage, output.ManifestName), manifest, 0o644, opts)
+}
+
+// mirror recreates the tree at src in dst, except for the paths for which
+// skip is true, as output does locally. Regular files are hard linked, or
+// copied when the server cannot link; directories and copies keep their
+// owner.
+func (s *SSH) mirror(src, dst string, skip func(rel string) bool) error {
+	src, err := s.follow(src)
+	if err != nil {
+		return err
+	}
+	walker := s.sftp.Walk(src)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return s.wrap(err)
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(walker.Path(), src), "/")
+		if rel == "" {
+			continue
+		}
+		fi := walker.Stat()
+		if skip(rel) {
+			if fi.IsDir() {
+				walker.SkipDir()
+			}
+			continue
+		}
+
+		from, to := walker.Path(), path.Join(dst, rel)
+		var err error
+		switch mode := fi.Mode(); {
+		case mode.IsDir():
+			if err = s.sftp.Mkdir(to); err == nil {
+				err = s.sftp.Chmod(to, mode.Perm())
+			}
+			if err == nil {
+				err = s.keepOwner(to, fi)
+			}
+		case mode&fs.ModeSymlink != 0:
+			var target string
+			if target, err = s.sftp.ReadLink(from); err == nil {
+				err = s.sftp.Symlink(target, to)
+			}
+		case mode.IsRegular():
+			if s.sftp.Link(from, to) == nil {
+				continue
+			}
+			var data []byte
+			if data, err = s.ReadFile(from); err == nil {
+				err = s.writeFile(to, data, mode.Perm(), output.Options{UID: -1, GID: -1})
+			}
+			if err == nil {
+				err = s.keepOwner(to, fi)
+			}
+		default:
+			return fmt.Errorf("%s: %s: unsupported file type %s", s.dest, from, mode.Type())
+		}
+		if err != nil {
+			return s.wrap(err)
+		}
+	}
+	return nil
+}
+
+// follow returns the directory the symlink dir points to, or dir itself
+// when it is not a symlink.
+func (s *SSH) follow(dir string) (string, error) {
+	fi, err := s.sftp.Lstat(dir)
+	if err != nil {
+		return "", s.wrap(err)
+	}
+	if fi.Mode()&fs.ModeSymlink == 0 {
+		return dir, nil
+	}
+	target, err := s.sftp.ReadLink(dir)
+	if err != nil {
+		return "", s.wrap(err)
+	}
+	if !path.IsAbs(target) {
+		target = path.Join(path.Dir(dir), target)
+	}
+	return target, nil
+}
+
+// keepOwner gives name the owner and group of fi.
+func (s *SSH) keepOwner(name string, fi fs.FileInfo) error {
+	st, ok := fi.Sys().(*sftp.FileStat)
+	if !ok {
+		return nil
+	}
+	if err := s.sftp.Chown(name, int(st.UID), int(st.GID)); err != nil && !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	return nil
+}
+
+// swap puts the tree at stage in place of dir.
+func (s *SSH) swap(dir, stage string) error {
+	fi, err := s.sftp.Lstat(dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return s.wrap(s.sftp.PosixRename(stage, dir))
+	case err != nil:
+		return s.wrap(err)
+	case fi.Mode()&fs.ModeSymlink != 0:
+		return s.flipSymlink(dir, stage)
+	case !fi.IsDir():
+		return fmt.Errorf("%s: %s is not a directory", s.dest, dir)
+	}
+
+	if _, err := s.Run("mv", "--exchange", "-T", stage, dir); err == nil {
+		return s.wrap(s.sftp.RemoveAll(stage))
+	}
+	old := stage + ".old"
+	if err := s.sftp.PosixRename(dir, old); err != nil {
+		return s.wrap(err)
+	}
+	if err := s.sftp.PosixRename(stage, dir); err != nil {
+		s.sftp.PosixRename(old, dir)
+		return s.wrap(err)
+	}
+	return s.wrap(s.sftp.RemoveAll(old))
+}
+
+// flipSymlink points the symlink dir to stage. The previous target is
+// removed when it is a tree staged by unitd.
+func (s *SSH) flipSymlink(dir, stage string) error {
+	previous, err := s.sftp.ReadLink(dir)
+	if err != nil {
+		return s.wrap(err)
+	}
+	tmp := stage + ".link"
+	if err := s.sftp.Symlink(path.Base(stage), tmp); err != nil {
+		return s.wrap(err)
+	}
+	if err := s.sftp.PosixRename(tmp, dir); err != nil {
+		s.sftp.Remove(tmp)
+		return s.wrap(err)
+	}
+	if path.Dir(previous) == "." && strings.HasPrefix(previous, output.StagePrefix(path.Base(dir))) {
+		return s.wrap(s.sftp.RemoveAll(path.Join(path.Dir(dir), previous)))
+	}
+	return nil
+}
+
+// removeStale removes the staged trees an interrupted Apply left behind,
+// except the one the directory links to.
+func (s *SSH) removeStale(parent, base string) {
+	live, _ := s.sftp.ReadLink(path.Join(parent, base))
+	entries, err := s.sftp.ReadDir(parent)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), output.StagePrefix(base)) && e.Name() != live {
+			s.sftp.RemoveAll(path.Join(parent, e.Name()))
+		}
+	}
+}
+
+// setMetadata gives name the mode and owner of opts, as output does.
+// Symlinks are left alone.
+func (s *SSH) setMetadata(name string, opts output.Options) error {
+	fi, err := s.sftp.Lstat(name)
+	if errors.Is(err, fs.ErrNotExist) || err == nil && fi.Mode()&fs.ModeSymlink != 0 {
+		return nil
+	}
+	if err != nil {
+		return s.wrap(err)
+	}
+	if fi.Mode().Perm() != opts.Mode {
+		if err := s.sftp.Chmod(name, opts.Mode); err != nil {
+			return s.wrap(err)
+		}
+	}
+	return s.chown(name, fi, opts)
+}
+
+// chown gives name the owner and group of opts; -1 keeps those of fi.
+func (s *SSH) chown(name string, fi fs.FileInfo, opts output.Options) error {
+	if opts.UID < 0 && opts.GID < 0 {
+		return nil
+	}
+	uid, gid := opts.UID, opts.GID
+	if st, ok := fi.Sys().(*sftp.FileStat); ok {
+		if uid < 0 {
+			uid = int(st.UID)
+		}
+		if gid < 0 {
+			gid = int(st.GID)
+		}
+	}
+	return s.wrap(s.sftp.Chown(name, uid, gid))
+}
+
+// writeFile creates name with data and flushes it to disk, when the server
+// supports it.
+func (s *SSH) writeFile(name string, data []byte, mode os.FileMode, opts output.Options) error {
+	f, err := s.sftp.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL)
+	if err != nil {
+		return s.wrap(err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return s.wrap(err)
+	}
+	if _, ok := s.sftp.HasExtension("fsync@openssh.com"); ok {
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return s.wrap(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return s.wrap(err)
+	}
+	if err := s.sftp.Chmod(name, mode); err != nil {
+		return s.wrap(err)
+	}
+	fi, err := s.sftp.Stat(name)
+	if err != nil {
+		return s.wrap(err)
+	}
+	return s.chown(name, fi, opts)
+}
+
+func (s *SSH) ReadDir(dir string) ([]fs.DirEntry, error) {
+	infos, err := s.sftp.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, s.wrap(err)
+	}
+	entries := make([]fs.DirEntry, len(infos))
+	for i, fi := range infos {
+		entries[i] = fs.FileInfoToDirEntry(fi)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (s *SSH) RemoveAll(name string) error {
+	return s.wrap(s.sftp.RemoveAll(name))
+}
+
+func (s *SSH) ReadFile(name string) ([]byte, error) {
+	f, err := s.sftp.Open(name)
+	if err != nil {
+		return nil, s.wrap(err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	return data, s.wrap(err)
+}
+
+func (s *SSH) Run(name string, args ...string) ([]byte, error) {
+	session, err := s.client.NewSession()
+	if err != nil {
+		return nil, s.wrap(err)
+	}
+	defer session.Close()
+
+	words := []string{shellQuote(name)}
+	for _, a := range args {
+		words = append(words, shellQuote(a))
+	}
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if err := session.Run(strings.Join(words, " ")); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s: %s", s.dest, name, msg)
+		}
+		return nil, fmt.Errorf("%s: %s: %w", s.dest, name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Close closes the connection.
+func (s *SSH) Close() error {
+	s.sftp.Close()
+	return s.client.Close()
+}
+
+// wrap names the host in err, keeping nil and the error chain.
+func (s *SSH) wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", s.dest, err)
+}
+
+func randomSuffix() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// existing returns the files of names that exist.
+func existing(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if _, err := os.Stat(name); err == nil {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func expandHome(p string) string {
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return p
+}
@@ -0,0 +1,97 @@
+// Package transport reaches the file system of the hosts unitd apply
+// deploys to: the local machine, a directory standing for a host, or a
+// remote machine over SSH.
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+// Transport is the remote side of unitd apply. Paths are absolute paths on
+// the host.
+type Transport interface {
+	// Snapshot returns the content of dir. A missing directory is empty.
+	Snapshot(dir string) (fs.FS, error)
+
+	// ReadDir returns the entries of dir sorted by name. A missing
+	// directory is empty.
+	ReadDir(dir string) ([]fs.DirEntry, error)
+
+	// ReadFile returns the content of the file name.
+	ReadFile(name string) ([]byte, error)
+
+	// RemoveAll removes name and everything it contains.
+	RemoveAll(name string) error
+
+	// Apply brings the directory of the plan in line with it, with the
+	// guarantees of output.Plan.Apply: the new tree is staged next to the
+	// directory and swapped in at once.
+	Apply(p *output.Plan, opts output.Options, progress func(output.Change)) error
+
+	// Run runs a command on the host and returns its standard output.
+	Run(name string, args ...string) ([]byte, error)
+
+	// Close releases the connection to the host.
+	Close() error
+}
+
+// Local is a host whose file system is the directory Root of this machine.
+// A Root of "/" is the local machine itself.
+type Local struct {
+	Root string
+}
+
+func (l *Local) Snapshot(dir string) (fs.FS, error) {
+	return os.DirFS(l.path(dir)), nil
+}
+
+func (l *Local) ReadDir(dir string) ([]fs.DirEntry, error) {
+	entries, err := os.ReadDir(l.path(dir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return entries, err
+}
+
+func (l *Local) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(l.path(name))
+}
+
+func (l *Local) RemoveAll(name string) error {
+	return os.RemoveAll(l.path(name))
+}
+
+func (l *Local) Apply(p *output.Plan, opts output.Options, progress func(output.Change)) error {
+	local := *p
+	local.Dir = l.path(p.Dir)
+	return local.Apply(opts, progress)
+}
+
+// Run runs the command on this machine, whatever Root is.
+func (l *Local) Run(name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if msg := strings.TrimSpace(stderr.String()); err != nil && msg != "" {
+		return nil, fmt.Errorf("%s: %s", name, msg)
+	}
+	return out, err
+}
+
+func (l *Local) Close() error {
+	return nil
+}
+
+func (l *Local) path(dir string) string {
+	return filepath.Join(l.Root, filepath.FromSlash(dir))
+}
@@ -0,0 +1,369 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kevinburke/ssh_config"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/vanviethieuanh/unitd/configs"
+	"github.com/vanviethieuanh/unitd/output"
+)
+
+// serveSSH runs an SSH server on a loopback port for the test, serving the
+// SFTP subsystem and exec requests against this machine. Only key accepts
+// as client key.
+func serveSSH(t *testing.T, key ssh.PublicKey) (addr string, hostKey ssh.PublicKey) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, k ssh.PublicKey) (*ssh.Permissions, error) {
+			if !bytes.Equal(k.Marshal(), key.Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveConn(conn, config)
+		}
+	}()
+	return l.Addr().String(), signer.PublicKey()
+}
+
+func serveConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for nc := range chans {
+		if nc.ChannelType() != "session" {
+			nc.Reject(ssh.UnknownChannelType, "sessions only")
+			continue
+		}
+		ch, reqs, err := nc.Accept()
+		if err != nil {
+			return
+		}
+		go serveSession(ch, reqs)
+	}
+}
+
+func serveSession(ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer ch.Close()
+	for req := range reqs {
+		var payload struct{ Value string }
+		ssh.Unmarshal(req.Payload, &payload)
+		switch {
+		case req.Type == "subsystem" && payload.Value == "sftp":
+			req.Reply(true, nil)
+			server, err := sftp.NewServer(ch)
+			if err == nil {
+				server.Serve()
+			}
+			return
+		case req.Type == "exec":
+			req.Reply(true, nil)
+			cmd := exec.Command("sh", "-c", payload.Value)
+			cmd.Stdout, cmd.Stderr = ch, ch.Stderr()
+			status := uint32(0)
+			if err := cmd.Run(); err != nil {
+				status = 1
+				if exit, ok := err.(*exec.ExitError); ok {
+					status = uint32(exit.ExitCode())
+				}
+			}
+			ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+			return
+		default:
+			req.Reply(false, nil)
+		}
+	}
+}
+
+// newTestSSH returns an SSH transport to an in-process server.
+func newTestSSH(t *testing.T) *SSH {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, hostKey := serveSSH(t, signer.PublicKey())
+	s, err := dial(addr, &ssh.ClientConfig{
+		User:            "test",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestTransports(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		new  func(t *testing.T, root string) Transport
+		dir  func(root string) string // the unit directory, as the transport names it
+	}{
+		{
+			name: "local",
+			new:  func(t *testing.T, root string) Transport { return &Local{Root: root} },
+			dir:  func(string) string { return "/etc/systemd/system" },
+		},
+		{
+			name: "ssh",
+			new:  func(t *testing.T, root string) Transport { return newTestSSH(t) },
+			dir:  func(root string) string { return filepath.Join(root, "etc/systemd/system") },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			tr := tc.new(t, root)
+			dir := tc.dir(root)
+			local := filepath.Join(root, "etc/systemd/system")
+
+			if err := os.MkdirAll(local, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(local, "foreign.service"), []byte("x\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			files := []configs.File{
+				{Name: "a.service", Content: "a\n", Source: "service.a"},
+				{Name: "b.service", Content: "b\n", Source: "service.b"},
+				{Name: "multi-user.target.wants/a.service", Link: "/etc/systemd/system/a.service", Source: "service.a"},
+			}
+			deploy(t, tr, dir, files, 3)
+			before, _ := os.Stat(filepath.Join(local, "a.service"))
+
+			files[0].Content = "a2\n"
+			deploy(t, tr, dir, files[:2], 2)
+			deploy(t, tr, dir, files[:2], 0)
+
+			for name, want := range map[string]string{
+				"a.service":       "a2\n",
+				"b.service":       "b\n",
+				"foreign.service": "x\n",
+			} {
+				if data, err := os.ReadFile(filepath.Join(local, name)); err != nil || string(data) != want {
+					t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+				}
+			}
+			if _, err := os.Lstat(filepath.Join(local, "multi-user.target.wants/a.service")); !os.IsNotExist(err) {
+				t.Error("link no longer rendered was not removed")
+			}
+			if after, _ := os.Stat(filepath.Join(local, "b.service")); after.Mode().Perm() != 0o640 {
+				t.Errorf("b.service has mode %o, want 640", after.Mode().Perm())
+			}
+			if after, _ := os.Stat(filepath.Join(local, "a.service")); os.SameFile(before, after) {
+				t.Error("changed a.service kept its inode")
+			}
+
+			if data, err := tr.ReadFile(dir + "/a.service"); err != nil || string(data) != "a2\n" {
+				t.Errorf("ReadFile(a.service) = %q, %v", data, err)
+			}
+			var names []string
+			listing, err := tr.ReadDir(dir)
+			for _, e := range listing {
+				names = append(names, e.Name())
+			}
+			if got := strings.Join(names, " "); err != nil || got != ".unitd-manifest.json a.service b.service foreign.service multi-user.target.wants" {
+				t.Errorf("ReadDir = %s, %v", got, err)
+			}
+			if err := tr.RemoveAll(dir + "/multi-user.target.wants"); err != nil {
+				t.Error(err)
+			}
+			if listing, err := tr.ReadDir(dir + "/multi-user.target.wants"); err != nil || len(listing) != 0 {
+				t.Errorf("ReadDir of a removed directory = %v, %v", listing, err)
+			}
+
+			entries, _ := os.ReadDir(filepath.Join(root, "etc/systemd"))
+			for _, e := range entries {
+				if strings.Contains(e.Name(), ".unitd-") {
+					t.Errorf("staged tree %s was left behind", e.Name())
+				}
+			}
+		})
+	}
+}
+
+// deploy plans files against the snapshot of dir and applies the plan,
+// which must have n changes.
+func deploy(t *testing.T, tr Transport, dir string, files []configs.File, n int) {
+	t.Helper()
+	current, err := tr.Snapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := output.NewPlanFS(dir, current, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != n {
+		t.Fatalf("plan has %d changes, want %d: %+v", len(plan.Changes), n, plan.Changes)
+	}
+	opts := output.Options{Mode: 0o640, UID: -1, GID: -1}
+	if err := tr.Apply(plan, opts, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotForeignFile(t *testing.T) {
+	s := newTestSSH(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.service"), []byte("mine\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	current, err := s.Snapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = output.NewPlanFS(dir, current, []configs.File{{Name: "a.service", Content: "theirs\n"}})
+	if err == nil || !strings.Contains(err.Error(), "not written by unitd") {
+		t.Errorf("overwriting a foreign file: err = %v", err)
+	}
+}
+
+func TestNewSSH(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SSH_AUTH_SOCK", "")
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, hostKey := serveSSH(t, signer.PublicKey())
+	host, port, _ := net.SplitHostPort(addr)
+
+	dir := t.TempDir()
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(identity, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	knownHosts := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(addr)}, hostKey)
+	if err := os.WriteFile(knownHosts, []byte(line+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &configs.SSH{Host: host, User: "deploy", IdentityFile: identity, KnownHosts: knownHosts}
+	cfg.Port, _ = strconv.Atoi(port)
+	s, err := NewSSH(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if out, err := s.Run("echo", "it's up"); err != nil || string(out) != "it's up\n" {
+		t.Errorf("Run = %q, %v", out, err)
+	}
+	if _, err := s.Run("false"); err == nil {
+		t.Error("a failing command did not fail")
+	}
+
+	// The host key must be known.
+	if err := os.WriteFile(knownHosts, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := NewSSH(cfg); err == nil {
+		s.Close()
+		t.Error("connected to a host with an unknown key")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	userConfig, err := ssh_config.DecodeBytes([]byte(`
+Host web
+  HostName 10.0.0.9
+  User admin
+  Port 2200
+  IdentityFile /keys/web
+  UserKnownHostsFile /keys/known_hosts
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := func(alias, key string) string {
+		v, _ := userConfig.Get(alias, key)
+		return v
+	}
+
+	for _, tc := range []struct {
+		cfg  configs.SSH
+		want endpoint
+	}{
+		{
+			cfg: configs.SSH{Name: "web"},
+			want: endpoint{
+				addr:       "10.0.0.9:2200",
+				user:       "admin",
+				identities: []string{"/keys/web"},
+				knownHosts: []string{"/keys/known_hosts"},
+			},
+		},
+		{
+			cfg: configs.SSH{Name: "web", Host: "10.0.0.1", User: "deploy", Port: 22, IdentityFile: "/keys/deploy", KnownHosts: "/etc/unitd/known_hosts"},
+			want: endpoint{
+				addr:       "10.0.0.1:22",
+				user:       "deploy",
+				identities: []string{"/keys/deploy"},
+				knownHosts: []string{"/etc/unitd/known_hosts"},
+			},
+		},
+	} {
+		got := resolve(&tc.cfg, lookup)
+		got.identities = got.identities[:1] // drop the default keys
+		if got.knownHosts[len(got.knownHosts)-1] == "/etc/ssh/ssh_known_hosts" {
+			got.knownHosts = got.knownHosts[:len(got.knownHosts)-1]
+		}
+		if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(endpoint{})); diff != "" {
+			t.Errorf("resolve(%+v) (-want +got):\n%s", tc.cfg, diff)
+		}
+	}
+}